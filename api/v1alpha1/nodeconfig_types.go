@@ -71,6 +71,80 @@ type KubeletOptions struct {
 	// Flags are [command-line `kubelet`` arguments](https://kubernetes.io/docs/reference/command-line-tools-reference/kubelet/).
 	// that will be appended to the defaults.
 	Flags []string `json:"flags,omitempty"`
+
+	// Reservation is the policy used to compute the resources reserved for the `kubelet`,
+	// the container runtime and the operating system, and the hard eviction thresholds.
+	// Values in Config take precedence.
+	// +optional
+	Reservation *ReservationPolicy `json:"reservation,omitempty"`
+}
+
+// ReservationPolicy computes the `kubeReserved`, `systemReserved` and `evictionHard` values
+// of the `kubelet` from the node's capacity.
+type ReservationPolicy struct {
+	// Profile is the predefined policy the other fields override. `default` keeps the
+	// reservations nodeadm has always made, `minimal` reserves about half of its memory and
+	// `edge` reserves the least, for small edge devices. Defaults to `default`.
+	// +optional
+	Profile ReservationProfile `json:"profile,omitempty"`
+
+	// KubeReserved overrides the reservation of the profile for the `kubelet` and the
+	// container runtime. Each resource set replaces the profile's.
+	// +optional
+	KubeReserved *ResourceReservation `json:"kubeReserved,omitempty"`
+
+	// SystemReserved overrides the reservation of the profile for the operating system
+	// daemons. Profiles don't reserve resources for the operating system.
+	// +optional
+	SystemReserved *ResourceReservation `json:"systemReserved,omitempty"`
+
+	// EvictionHard are hard eviction thresholds, like `memory.available: 100Mi`, that are
+	// set in addition to the profile's.
+	// +optional
+	EvictionHard map[string]string `json:"evictionHard,omitempty"`
+}
+
+// ReservationProfile is a predefined reservation policy.
+// +kubebuilder:validation:Enum={default, minimal, edge}
+type ReservationProfile string
+
+const (
+	// ReservationProfileDefault keeps the reservations nodeadm has always made.
+	ReservationProfileDefault ReservationProfile = "default"
+
+	// ReservationProfileMinimal reserves about half of the default memory.
+	ReservationProfileMinimal ReservationProfile = "minimal"
+
+	// ReservationProfileEdge reserves the least resources, for small edge devices.
+	ReservationProfileEdge ReservationProfile = "edge"
+)
+
+// ResourceReservation computes the resources reserved from the node's capacity.
+type ResourceReservation struct {
+	// CPU are the tiers of the node's CPU that are reserved.
+	// +optional
+	CPU []ReservationTier `json:"cpu,omitempty"`
+
+	// Memory are the tiers of the node's memory that are reserved.
+	// +optional
+	Memory []ReservationTier `json:"memory,omitempty"`
+
+	// EphemeralStorage is the ephemeral storage reserved, like `1Gi`.
+	// +optional
+	EphemeralStorage string `json:"ephemeralStorage,omitempty"`
+}
+
+// ReservationTier reserves a percentage of the capacity between the previous tier's
+// UpTo and its own. A single tier without UpTo reserves a percentage of the whole capacity.
+type ReservationTier struct {
+	// UpTo is the capacity the tier ends at, like `2` or `2000m` CPUs, or `4Gi` of memory.
+	// Only the last tier can omit it, to apply to the rest of the capacity.
+	// +optional
+	UpTo string `json:"upTo,omitempty"`
+
+	// Percent of the capacity in the tier that is reserved, with up to two decimals, like `0.25`.
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]{1,2})?$`
+	Percent string `json:"percent"`
 }
 
 // ContainerdOptions are additional parameters passed to `containerd`.
@@ -79,6 +153,111 @@ type ContainerdOptions struct {
 	// that will be [imported](https://github.com/containerd/containerd/blob/32169d591dbc6133ef7411329b29d0c0433f8c4d/docs/man/containerd-config.toml.5.md?plain=1#L146-L154)
 	// by the default configuration file.
 	Config string `json:"config,omitempty"`
+
+	// Registries configures how images are pulled from each registry, keyed by the registry
+	// host, like `docker.io` or `registry.example.com:5000`. The `_default` key applies to
+	// every registry without its own entry. Each registry is written to
+	// [`/etc/containerd/certs.d/<host>/hosts.toml`](https://github.com/containerd/containerd/blob/main/docs/hosts.md).
+	Registries map[string]RegistryOptions `json:"registries,omitempty"`
+
+	// Runtimes are additional runtime handlers, like gVisor, Kata or the NVIDIA container
+	// runtime, keyed by handler name. Pods select a handler with a `RuntimeClass`. `runc`
+	// remains the default runtime.
+	Runtimes map[string]RuntimeOptions `json:"runtimes,omitempty"`
+}
+
+// RegistryOptions configures how `containerd` pulls images from a registry.
+type RegistryOptions struct {
+	// Server is the URL of the registry, if it's not `https://<host>`.
+	Server string `json:"server,omitempty"`
+
+	// Mirrors are tried in order before the registry server.
+	Mirrors []RegistryMirror `json:"mirrors,omitempty"`
+
+	// TLS configures the connection to the registry server.
+	TLS RegistryTLS `json:"tls,omitempty"`
+
+	// Auth are the credentials for the registry server.
+	Auth RegistryAuth `json:"auth,omitempty"`
+}
+
+// RegistryMirror is an endpoint, like a Harbor or Artifactory proxy, that images of a
+// registry are pulled from.
+type RegistryMirror struct {
+	// Endpoint is the URL of the mirror, like `https://harbor.example.com`.
+	Endpoint string `json:"endpoint"`
+
+	// Capabilities are the operations the mirror is used for, out of `pull`, `resolve`
+	// and `push`. Defaults to `pull` and `resolve`.
+	// +optional
+	Capabilities []string `json:"capabilities,omitempty"`
+
+	// OverridePath uses the path of Endpoint as the registry API root instead of appending
+	// `/v2`, for mirrors that serve a registry under a path, like Artifactory.
+	// +optional
+	OverridePath bool `json:"overridePath,omitempty"`
+
+	// TLS configures the connection to the mirror.
+	TLS RegistryTLS `json:"tls,omitempty"`
+
+	// Auth are the credentials for the mirror.
+	Auth RegistryAuth `json:"auth,omitempty"`
+}
+
+// RegistryTLS configures the TLS connection to a registry or mirror.
+type RegistryTLS struct {
+	// CABundle is a PEM encoded bundle of the certificate authorities trusted for the
+	// endpoint, in addition to the system roots.
+	// +optional
+	CABundle string `json:"caBundle,omitempty"`
+
+	// ClientCertificatePath is the location on disk of the client certificate used to
+	// authenticate to the endpoint. Requires ClientKeyPath.
+	// +optional
+	ClientCertificatePath string `json:"clientCertificatePath,omitempty"`
+
+	// ClientKeyPath is the location on disk of the client certificate's private key.
+	// +optional
+	ClientKeyPath string `json:"clientKeyPath,omitempty"`
+
+	// InsecureSkipVerify disables the verification of the endpoint's certificate.
+	// +optional
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+}
+
+// RegistryAuth are the credentials sent to a registry or mirror with every request.
+// Username and Password are sent with basic authentication and are mutually exclusive
+// with Token, which is sent as a bearer token.
+type RegistryAuth struct {
+	// +optional
+	Username string `json:"username,omitempty"`
+	// +optional
+	Password string `json:"password,omitempty"`
+	// +optional
+	Token string `json:"token,omitempty"`
+}
+
+// RuntimeOptions configures a `containerd` runtime handler.
+type RuntimeOptions struct {
+	// Type is the `containerd` shim of the runtime, like `io.containerd.runsc.v1` for gVisor,
+	// `io.containerd.kata.v2` for Kata or `io.containerd.runc.v2` for the NVIDIA container runtime.
+	Type string `json:"type"`
+
+	// BinaryPath is the OCI runtime binary the shim runs instead of `runc`, like
+	// `/usr/bin/nvidia-container-runtime`. It's only supported by runc compatible shims.
+	// +optional
+	BinaryPath string `json:"binaryPath,omitempty"`
+
+	// ConfigPath is the location on disk of the runtime configuration, like
+	// `/etc/kata-containers/configuration.toml`. It's not supported by runc shims.
+	// +optional
+	ConfigPath string `json:"configPath,omitempty"`
+
+	// Options are additional options of the shim, like `TypeUrl` for gVisor. Values that
+	// are `true`, `false` or integers are written as TOML booleans and integers, the rest
+	// as strings.
+	// +optional
+	Options map[string]string `json:"options,omitempty"`
 }
 
 // InstanceOptions determines how the node's operating system and devices are configured.
@@ -118,8 +297,45 @@ type HybridOptions struct {
 	// SSM includes Systems Manager specific configuration and is mutually exclusive with
 	// IAMRolesAnywhere.
 	SSM *SSM `json:"ssm,omitempty"`
+
+	// PodNetwork describes how the CNI allocates pod IPs to the node. nodeadm uses it to
+	// compute the node's max pods and the memory reserved for them.
+	// +optional
+	PodNetwork *PodNetworkOptions `json:"podNetwork,omitempty"`
 }
 
+// PodNetworkOptions describes the pod CIDR the CNI allocates to each node.
+type PodNetworkOptions struct {
+	// CNI is the CNI plugin that allocates pod IPs. It sets the default node CIDR mask
+	// size: 24 for `cilium`, its default cluster pool mask size, and 26 for `calico`, its
+	// default IPAM block size. Calico can allocate more blocks to a node, or borrow IPs
+	// from other nodes' blocks, once its block is full, so the max pods computed for a
+	// single block can be lower than the pods the node can run. Set nodeCIDRMaskSize to
+	// the CIDR the node's pods can use to allow more pods.
+	// +optional
+	CNI CNIType `json:"cni,omitempty"`
+
+	// NodeCIDRMaskSize is the prefix length of the pod CIDR allocated to each node, like
+	// Cilium's `clusterPoolIPv4MaskSize` or Calico's IPAM `blockSize`.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=30
+	// +optional
+	NodeCIDRMaskSize int32 `json:"nodeCIDRMaskSize,omitempty"`
+}
+
+// CNIType is a CNI plugin supported on hybrid nodes.
+// +kubebuilder:validation:Enum={cilium, calico}
+type CNIType string
+
+const (
+	// CNITypeCilium allocates a pod CIDR from Cilium's cluster pool to each node.
+	CNITypeCilium CNIType = "cilium"
+
+	// CNITypeCalico allocates IPAM blocks to each node. nodeadm assumes a single block
+	// per node.
+	CNITypeCalico CNIType = "calico"
+)
+
 // IsHybridNode returns true when the nc.Hybrid configuration is non-nil.
 func (nc NodeConfig) IsHybridNode() bool {
 	return nc.Spec.Hybrid != nil
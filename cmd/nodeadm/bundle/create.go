@@ -0,0 +1,148 @@
+package bundle
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	"github.com/integrii/flaggy"
+	"go.uber.org/zap"
+
+	"github.com/aws/eks-hybrid/internal/aws"
+	"github.com/aws/eks-hybrid/internal/bundle"
+	"github.com/aws/eks-hybrid/internal/cli"
+	"github.com/aws/eks-hybrid/internal/logger"
+	"github.com/aws/eks-hybrid/internal/signature"
+	"github.com/aws/eks-hybrid/internal/ssm"
+)
+
+func NewCreateCommand() cli.Command {
+	cmd := createCmd{
+		timeout: 30 * time.Minute,
+		arch:    runtime.GOARCH,
+		region:  ssm.DefaultSsmInstallerRegion,
+	}
+
+	fc := flaggy.NewSubcommand("create")
+	fc.Description = "Create an offline install bundle with every artifact needed to install a Kubernetes version"
+	fc.AddPositionalValue(&cmd.kubernetesVersion, "KUBERNETES_VERSION", 1, true, "The major[.minor[.patch]] version of Kubernetes to bundle.")
+	fc.String(&cmd.arch, "a", "arch", "Target architecture for artifacts.")
+	fc.String(&cmd.region, "r", "region", "AWS region for downloading regional artifacts.")
+	fc.String(&cmd.output, "o", "output", "Path of the bundle archive. Defaults to nodeadm-bundle-<version>-<arch>.tar.zst.")
+	fc.String(&cmd.manifestOverride, "m", "manifest-override", "URI to a manifest file containing custom artifact URLs. Supports file:// for local files and https:// for remote files.")
+	fc.Duration(&cmd.timeout, "t", "timeout", "Maximum bundle command duration.")
+	fc.String(&cmd.signingKey, "", "signing-key", "Path to an unlocked armored OpenPGP private key to sign the bundle with. Requires --trust-root. "+
+		"Bundles extracted with --trust-root must be signed by one of its keys.")
+	cmd.download = cli.RegisterDownloadFlags(fc)
+	cmd.signature = cli.RegisterSignatureFlags(fc)
+	cmd.pin = cli.RegisterPinFlags(fc)
+	// The bundle is usually created on a workstation, so don't default to the
+	// cache in /opt, which requires root.
+	cmd.download.CacheDir = filepath.Join(os.TempDir(), "nodeadm-cache")
+	cmd.flaggy = fc
+
+	return &cmd
+}
+
+type createCmd struct {
+	flaggy            *flaggy.Subcommand
+	kubernetesVersion string
+	arch              string
+	region            string
+	output            string
+	manifestOverride  string
+	timeout           time.Duration
+	signingKey        string
+	download          *cli.DownloadFlags
+	signature         *cli.SignatureFlags
+	pin               *cli.PinFlags
+}
+
+func (c *createCmd) Flaggy() *flaggy.Subcommand {
+	return c.flaggy
+}
+
+func (c *createCmd) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
+	ctx := context.Background()
+	ctx = logger.NewContext(ctx, log)
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	manifestOpts, err := c.signature.ManifestOpts()
+	if err != nil {
+		return err
+	}
+	var signer *signature.Signer
+	if c.signingKey != "" {
+		// Only verified artifacts are signed.
+		if !c.signature.Enabled() {
+			return fmt.Errorf("--signing-key requires --trust-root")
+		}
+		if signer, err = signature.LoadSigner(c.signingKey); err != nil {
+			return err
+		}
+	}
+	pinOpts, err := c.pin.ManifestOpts()
+	if err != nil {
+		return err
+	}
+	manifestOpts = append(manifestOpts, pinOpts...)
+	fetchers, err := c.download.Fetchers(c.region)
+	if err != nil {
+		return err
+	}
+	manifestOpts = append(manifestOpts, aws.WithFetchers(fetchers))
+	manifest, err := aws.GetManifest(ctx, c.region, c.manifestOverride, manifestOpts...)
+	if err != nil {
+		return err
+	}
+	awsSource, err := aws.NewSourceFromManifest(c.kubernetesVersion, c.region, manifest, manifestOpts...)
+	if err != nil {
+		return err
+	}
+	log.Info("Using Kubernetes version", zap.String("version", awsSource.Eks.Version))
+
+	output := c.output
+	if output == "" {
+		output = fmt.Sprintf("nodeadm-bundle-%s-%s.tar.zst", awsSource.Eks.Version, c.arch)
+	}
+
+	creator := &bundle.Creator{
+		Manifest:   manifest,
+		Source:     awsSource,
+		Arch:       c.arch,
+		Region:     c.region,
+		Downloader: c.download.Downloader(log, fetchers),
+		Logger:     log,
+		Signer:     signer,
+	}
+
+	// Write to a temp file next to the output so a failed run doesn't leave a
+	// truncated bundle behind.
+	tmp, err := os.CreateTemp(filepath.Dir(output), filepath.Base(output)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	err = creator.Write(ctx, tmp)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("creating bundle: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), output); err != nil {
+		return err
+	}
+
+	log.Info("Created bundle", zap.String("path", output))
+	return nil
+}
@@ -0,0 +1,19 @@
+package bundle
+
+import (
+	"github.com/aws/eks-hybrid/internal/cli"
+)
+
+const bundleHelpText = `Examples:
+  # Create an offline install bundle for Kubernetes version 1.31 on ARM64 hosts
+  nodeadm bundle create 1.31 --arch arm64 -o bundle.tar.zst
+
+  # Install from the bundle on a host without internet access
+  nodeadm install 1.31 --credential-provider ssm --bundle bundle.tar.zst`
+
+func NewCommand() cli.Command {
+	container := cli.NewCommandContainer("bundle", "Manage offline install bundles")
+	container.Flaggy().AdditionalHelpAppend = bundleHelpText
+	container.AddCommand(NewCreateCommand())
+	return container.AsCommand()
+}
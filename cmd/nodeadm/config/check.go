@@ -1,24 +1,29 @@
 package config
 
 import (
+	"errors"
+
 	"github.com/integrii/flaggy"
 	"go.uber.org/zap"
 
+	apibridge "github.com/aws/eks-hybrid/internal/api/bridge"
 	"github.com/aws/eks-hybrid/internal/cli"
 	"github.com/aws/eks-hybrid/internal/configprovider"
 	"github.com/aws/eks-hybrid/internal/node"
 )
 
 type fileCmd struct {
-	cmd          *flaggy.Subcommand
-	configSource string
+	cmd            *flaggy.Subcommand
+	configSource   string
+	configCABundle string
 }
 
 func NewCheckCommand() cli.Command {
 	file := fileCmd{}
 	file.cmd = flaggy.NewSubcommand("check")
 	file.cmd.Description = "Verify configuration"
-	file.cmd.String(&file.configSource, "c", "config-source", "Source of node configuration. The format is a URI with supported schemes: [file, imds].")
+	file.cmd.String(&file.configSource, "c", "config-source", "Source of node configuration. The format is a URI with supported schemes: [file, imds, s3, https].")
+	file.cmd.String(&file.configCABundle, "", "config-source-ca-bundle", "Path to a PEM encoded CA bundle used to verify https config sources.")
 	return &file
 }
 
@@ -28,16 +33,26 @@ func (c *fileCmd) Flaggy() *flaggy.Subcommand {
 
 func (c *fileCmd) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
 	log.Info("Checking configuration", zap.String("source", c.configSource))
-	provider, err := configprovider.BuildConfigProvider(c.configSource)
+	provider, err := configprovider.BuildConfigProvider(c.configSource, configprovider.WithCABundle(c.configCABundle))
 	if err != nil {
 		return err
 	}
 	_, err = provider.Provide()
 	if err != nil {
+		var unresolved *apibridge.UnresolvedReferencesError
+		if errors.As(err, &unresolved) {
+			for _, ref := range unresolved.References {
+				log.Error("Unresolved reference in configuration",
+					zap.String("field", ref.Path),
+					zap.String("reference", ref.Reference),
+					zap.Error(ref.Err),
+				)
+			}
+		}
 		return err
 	}
 
-	nodeProvider, err := node.NewNodeProvider(c.configSource, []string{}, log)
+	nodeProvider, err := node.NewNodeProvider(c.configSource, []string{}, nil, log, configprovider.WithCABundle(c.configCABundle))
 	if err != nil {
 		return err
 	}
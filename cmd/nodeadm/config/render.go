@@ -0,0 +1,151 @@
+package config
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/integrii/flaggy"
+	"go.uber.org/zap"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-hybrid/internal/cli"
+	"github.com/aws/eks-hybrid/internal/configprovider"
+	"github.com/aws/eks-hybrid/internal/daemon"
+	"github.com/aws/eks-hybrid/internal/flows"
+	"github.com/aws/eks-hybrid/internal/logger"
+	"github.com/aws/eks-hybrid/internal/node"
+	"github.com/aws/eks-hybrid/internal/util"
+)
+
+type renderCmd struct {
+	cmd              *flaggy.Subcommand
+	configSource     string
+	configCABundle   string
+	manifestOverride string
+	filesDir         string
+	filesArchive     string
+}
+
+func NewRenderCommand() cli.Command {
+	render := renderCmd{}
+	render.cmd = flaggy.NewSubcommand("render")
+	render.cmd.Description = "Render the resolved configuration and the files init would write"
+	render.cmd.String(&render.configSource, "c", "config-source", "Source of node configuration. The format is a URI with supported schemes: [file, imds, s3, https].")
+	render.cmd.String(&render.configCABundle, "", "config-source-ca-bundle", "Path to a PEM encoded CA bundle used to verify https config sources.")
+	render.cmd.String(&render.manifestOverride, "m", "manifest-override", "URI to a manifest file containing custom artifact URLs. Supports file:// for local files and https:// for remote files.")
+	render.cmd.String(&render.filesDir, "", "files-dir", "Write the files init would write under this directory instead of printing the NodeConfig.")
+	render.cmd.String(&render.filesArchive, "", "files-archive", "Write the files init would write to this tar.gz archive instead of printing the NodeConfig.")
+	return &render
+}
+
+func (c *renderCmd) Flaggy() *flaggy.Subcommand {
+	return c.cmd
+}
+
+func (c *renderCmd) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
+	ctx := context.Background()
+	ctx = logger.NewContext(ctx, log)
+
+	if c.configSource == "" {
+		flaggy.ShowHelpAndExit("--config-source is a required flag. The format is a URI with supported schemes: [file, imds, s3, https].")
+	}
+	if c.filesDir != "" && c.filesArchive != "" {
+		return fmt.Errorf("only one of --files-dir and --files-archive can be specified")
+	}
+
+	log.Info("Rendering configuration", zap.String("source", c.configSource))
+	provider, err := configprovider.BuildConfigProvider(c.configSource, configprovider.WithCABundle(c.configCABundle))
+	if err != nil {
+		return err
+	}
+	nodeConfig, err := provider.Provide()
+	if err != nil {
+		return err
+	}
+
+	daemonManager := daemon.NewRecordingDaemonManager(nil)
+	nodeProvider, err := node.NewRecordingNodeProvider(ctx, nodeConfig, nil, nil, daemonManager, log)
+	if err != nil {
+		return err
+	}
+
+	renderer := &flows.Renderer{
+		NodeProvider:     nodeProvider,
+		DaemonManager:    daemonManager,
+		ManifestOverride: c.manifestOverride,
+		Logger:           log,
+	}
+	rendered, err := renderer.Run(ctx)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case c.filesDir != "":
+		log.Info("Writing rendered files", zap.String("dir", c.filesDir))
+		return writeRenderedFilesToDir(c.filesDir, rendered.Files)
+	case c.filesArchive != "":
+		log.Info("Writing rendered files", zap.String("archive", c.filesArchive))
+		return writeRenderedFilesToArchive(c.filesArchive, rendered.Files)
+	default:
+		data, err := yaml.Marshal(rendered.NodeConfig)
+		if err != nil {
+			return err
+		}
+		// Values resolved from references might be secrets.
+		fmt.Print(logger.Redact(string(data)))
+		return nil
+	}
+}
+
+func writeRenderedFilesToDir(dir string, files []util.MemoryFile) error {
+	for _, file := range files {
+		path := filepath.Join(dir, file.Path)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, file.Data, file.Perm); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeRenderedFilesToArchive(archivePath string, files []util.MemoryFile) error {
+	archive, err := os.Create(archivePath)
+	if err != nil {
+		return err
+	}
+	defer archive.Close()
+
+	gzipWriter := gzip.NewWriter(archive)
+	tarWriter := tar.NewWriter(gzipWriter)
+	modTime := time.Now()
+	for _, file := range files {
+		header := &tar.Header{
+			Name:    strings.TrimPrefix(file.Path, "/"),
+			Mode:    int64(file.Perm.Perm()),
+			Size:    int64(len(file.Data)),
+			ModTime: modTime,
+		}
+		if err := tarWriter.WriteHeader(header); err != nil {
+			return err
+		}
+		if _, err := tarWriter.Write(file.Data); err != nil {
+			return err
+		}
+	}
+	if err := tarWriter.Close(); err != nil {
+		return err
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return err
+	}
+	return archive.Close()
+}
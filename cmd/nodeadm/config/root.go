@@ -7,6 +7,15 @@ import (
 const configHelpText = `Examples:
   # Check configuration file
   nodeadm config check --config-source file:///root/nodeConfig.yaml
+
+  # Print the fully resolved configuration, including status
+  nodeadm config render --config-source file:///root/nodeConfig.yaml
+
+  # Write every file init would generate to an archive for review
+  nodeadm config render --config-source file:///root/nodeConfig.yaml --files-archive rendered.tar.gz
+
+  # Print the RuntimeClasses for the containerd runtimes in the configuration
+  nodeadm config runtime-classes --config-source file:///root/nodeConfig.yaml --node-selector sandboxed=true
   
 Documentation:
   https://docs.aws.amazon.com/eks/latest/userguide/hybrid-nodes-nodeadm.html#_config_check`
@@ -15,5 +24,7 @@ func NewConfigCommand() cli.Command {
 	container := cli.NewCommandContainer("config", "Manage configuration")
 	container.Flaggy().AdditionalHelpAppend = configHelpText
 	container.AddCommand(NewCheckCommand())
+	container.AddCommand(NewRenderCommand())
+	container.AddCommand(NewRuntimeClassesCommand())
 	return container.AsCommand()
 }
@@ -0,0 +1,78 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/integrii/flaggy"
+	"go.uber.org/zap"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-hybrid/internal/cli"
+	"github.com/aws/eks-hybrid/internal/configprovider"
+	"github.com/aws/eks-hybrid/internal/containerd"
+)
+
+type runtimeClassesCmd struct {
+	cmd            *flaggy.Subcommand
+	configSource   string
+	configCABundle string
+	nodeSelector   []string
+}
+
+func NewRuntimeClassesCommand() cli.Command {
+	runtimeClasses := runtimeClassesCmd{}
+	runtimeClasses.cmd = flaggy.NewSubcommand("runtime-classes")
+	runtimeClasses.cmd.Description = "Print the RuntimeClass manifests for the containerd runtimes in the configuration"
+	runtimeClasses.cmd.String(&runtimeClasses.configSource, "c", "config-source", "Source of node configuration. The format is a URI with supported schemes: [file, imds, s3, https].")
+	runtimeClasses.cmd.String(&runtimeClasses.configCABundle, "", "config-source-ca-bundle", "Path to a PEM encoded CA bundle used to verify https config sources.")
+	runtimeClasses.cmd.StringSlice(&runtimeClasses.nodeSelector, "", "node-selector", "Node label, as <key>=<value>, that pods using the RuntimeClasses must be scheduled to. Can be repeated.")
+	return &runtimeClasses
+}
+
+func (c *runtimeClassesCmd) Flaggy() *flaggy.Subcommand {
+	return c.cmd
+}
+
+func (c *runtimeClassesCmd) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
+	if c.configSource == "" {
+		flaggy.ShowHelpAndExit("--config-source is a required flag. The format is a URI with supported schemes: [file, imds, s3, https].")
+	}
+	nodeSelector := map[string]string{}
+	for _, label := range c.nodeSelector {
+		key, value, ok := strings.Cut(label, "=")
+		if !ok || key == "" {
+			return fmt.Errorf("invalid --node-selector %q, expected <key>=<value>", label)
+		}
+		nodeSelector[key] = value
+	}
+
+	provider, err := configprovider.BuildConfigProvider(c.configSource, configprovider.WithCABundle(c.configCABundle))
+	if err != nil {
+		return err
+	}
+	nodeConfig, err := provider.Provide()
+	if err != nil {
+		return err
+	}
+	runtimes := nodeConfig.Spec.Containerd.Runtimes
+	if err := containerd.ValidateRuntimes(runtimes); err != nil {
+		return err
+	}
+	if len(runtimes) == 0 {
+		log.Info("No containerd runtimes in configuration")
+		return nil
+	}
+
+	for i, runtimeClass := range containerd.RuntimeClasses(runtimes, nodeSelector) {
+		data, err := yaml.Marshal(runtimeClass)
+		if err != nil {
+			return err
+		}
+		if i > 0 {
+			fmt.Println("---")
+		}
+		fmt.Print(string(data))
+	}
+	return nil
+}
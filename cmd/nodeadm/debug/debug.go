@@ -4,8 +4,11 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"slices"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/eks/types"
 	"github.com/aws/smithy-go/logging"
 	"github.com/integrii/flaggy"
 	"go.uber.org/zap"
@@ -30,14 +33,20 @@ const debugHelpText = `Examples:
   # Debug using a local config file
   nodeadm debug --config-source file://nodeConfig.yaml
 
+  # Debug and print the results as a JUnit report
+  nodeadm debug --config-source file://nodeConfig.yaml --output junit
+
 Documentation:
   https://docs.aws.amazon.com/eks/latest/userguide/hybrid-nodes-nodeadm.html#_debug`
 
 func NewCommand() cli.Command {
 	debug := debug{}
 	debug.cmd = flaggy.NewSubcommand("debug")
-	debug.cmd.String(&debug.nodeConfigSource, "c", "config-source", "Source of node configuration. The format is a URI with supported schemes: [file, imds].")
+	debug.cmd.String(&debug.nodeConfigSource, "c", "config-source", "Source of node configuration. The format is a URI with supported schemes: [file, imds, s3, https].")
+	debug.cmd.String(&debug.configCABundle, "", "config-source-ca-bundle", "Path to a PEM encoded CA bundle used to verify https config sources.")
 	debug.cmd.Bool(&debug.noColor, "", "no-color", "If set, suppresses color output.")
+	debug.cmd.String(&debug.output, "o", "output", "Output format of the validation results. One of: [text, json, junit].")
+	debug.validationPolicy = cli.RegisterValidationPolicyFlags(debug.cmd)
 	debug.cmd.Description = "Debug the node registration process"
 	debug.cmd.AdditionalHelpPrepend = debugHelpText
 	return &debug
@@ -46,7 +55,72 @@ func NewCommand() cli.Command {
 type debug struct {
 	cmd              *flaggy.Subcommand
 	nodeConfigSource string
+	configCABundle   string
 	noColor          bool
+	output           string
+	validationPolicy *cli.ValidationPolicyFlags
+}
+
+const (
+	outputText  = "text"
+	outputJSON  = "json"
+	outputJUnit = "junit"
+
+	junitSuiteName = "nodeadm-debug"
+)
+
+const (
+	ntpSyncValidation            = "ntp-sync"
+	swapValidation               = "swap"
+	ulimitValidation             = "ulimit"
+	awsAuthValidation            = "aws-auth"
+	proxyConfigValidation        = "proxy-config"
+	k8sEndpointNetworkValidation = "k8s-endpoint-network"
+	k8sAuthenticationValidation  = "k8s-authentication"
+	k8sIdentityValidation        = "k8s-identity"
+	k8sVPCNetworkValidation      = "k8s-vpc-network"
+	k8sCertificateValidation     = "k8s-certificate"
+	networkInterfaceValidation   = "network-interface"
+	activeNodeValidation         = "active-node-validation"
+)
+
+// validationNames returns the names of the validations run by debug.
+func validationNames() []string {
+	names := creds.ValidationNames()
+	apiServerValidations, otherValidations := clusterValidations(&api.ClusterDetails{}, nil)
+	for _, v := range slices.Concat(nodeValidations(aws.Config{}), apiServerValidations, otherValidations) {
+		names = append(names, v.Name)
+	}
+	return names
+}
+
+// nodeValidations returns the validations that don't require cluster details.
+func nodeValidations(awsConfig aws.Config) []validation.Validation[*api.NodeConfig] {
+	return []validation.Validation[*api.NodeConfig]{
+		validation.New(ntpSyncValidation, system.NewNTPValidator().Run),
+		validation.New(swapValidation, system.NewSwapValidator().Run),
+		validation.New(ulimitValidation, system.NewUlimitValidator().Run),
+		validation.New(awsAuthValidation, sts.NewAuthenticationValidator(awsConfig).Run),
+		validation.New(proxyConfigValidation, network.NewProxyValidator().Run),
+	}
+}
+
+// clusterValidations returns the validations that require cluster details. The API
+// server validations are run until one of them fails.
+func clusterValidations(clusterDetail *api.ClusterDetails, cluster *types.Cluster) (apiServer, others []validation.Validation[*api.NodeConfig]) {
+	apiServerValidator := kubernetes.NewAPIServerValidator(kubelet.New())
+	apiServer = []validation.Validation[*api.NodeConfig]{
+		validation.New(k8sEndpointNetworkValidation, kubernetes.NewAccessValidator(clusterDetail).Run),
+		validation.New(k8sAuthenticationValidation, apiServerValidator.MakeAuthenticatedRequest),
+		validation.New(k8sIdentityValidation, apiServerValidator.CheckIdentity),
+		validation.New(k8sVPCNetworkValidation, apiServerValidator.CheckVPCEndpointAccess),
+	}
+	others = []validation.Validation[*api.NodeConfig]{
+		validation.New(k8sCertificateValidation, kubernetes.NewKubeletCertificateValidator(clusterDetail).Run),
+		validation.New(networkInterfaceValidation, network.NewNetworkInterfaceValidator(network.WithCluster(cluster)).Run),
+		validation.New(activeNodeValidation, nodevalidator.NewActiveNodeValidator().Run),
+	}
+	return apiServer, others
 }
 
 func (c *debug) Flaggy() *flaggy.Subcommand {
@@ -58,53 +132,57 @@ func (c *debug) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
 	ctx = logger.NewContext(ctx, log)
 
 	if c.nodeConfigSource == "" {
-		flaggy.ShowHelpAndExit("--config-source is a required flag. The format is a URI with supported schemes: [file, imds]." +
+		flaggy.ShowHelpAndExit("--config-source is a required flag. The format is a URI with supported schemes: [file, imds, s3, https]." +
 			" For example on hybrid nodes --config-source file://nodeConfig.yaml")
 	}
 
-	provider, err := configprovider.BuildConfigProvider(c.nodeConfigSource)
+	var informer validation.Informer
+	var report *validation.Report
+	switch c.output {
+	case "", outputText:
+		var printerOpts []validation.PrinterOpt
+		if c.noColor {
+			printerOpts = append(printerOpts, validation.WithNoColor())
+		}
+		informer = validation.NewPrinter(printerOpts...)
+	case outputJSON, outputJUnit:
+		report = validation.NewReport()
+		informer = report
+	default:
+		return fmt.Errorf("invalid --output %q, must be one of: [%s, %s, %s]", c.output, outputText, outputJSON, outputJUnit)
+	}
+
+	validationOpts, err := c.validationPolicy.RunnerOpts(validationNames())
 	if err != nil {
-		return err
+		return c.reportFailure(ctx, informer, report, "validation-policy", "Parsing validation policy", err)
+	}
+
+	provider, err := configprovider.BuildConfigProvider(c.nodeConfigSource, configprovider.WithCABundle(c.configCABundle))
+	if err != nil {
+		return c.reportFailure(ctx, informer, report, "node-config", "Reading node config", err)
 	}
 	nodeConfig, err := provider.Provide()
 	if err != nil {
-		return err
+		return c.reportFailure(ctx, informer, report, "node-config", "Reading node config", err)
 	}
 
 	awsConfig, err := creds.ReadConfigAsKubelet(ctx, nodeConfig, config.WithLogger(logging.Nop{}))
 	if err != nil {
-		return err
+		return c.reportFailure(ctx, informer, report, "aws-config", "Loading AWS config", err)
 	}
 
-	printer := validation.NewPrinterWithStdCapture("stderr", c.noColor)
-	if err := printer.Init(); err != nil {
-		return err
+	// The runner captures the output of the external processes each validation runs,
+	// like the credential process for IAM Roles Anywhere, and reports it with the
+	// validation result instead of letting it interfere with the printer.
+	runnerOpts := []validation.RunnerOpt{
+		validation.WithDefaultTimeout(validation.DefaultTimeout),
 	}
-	defer printer.Close()
-
-	// We want to capture stderr and let the printer control it.
-	// When the AWS SDK calls the credentials_process for IAM Roles Anywhere
-	// or when the k8s client-go calls the aws-iam-authenticator binary, those processes
-	// output to stderr and those logs are not returned to the caller in the go error.
-	// In order to not have interfere with the printer logs or get lost,
-	// we just override the global stderr and restore after we are done running validations.
-	originalStderr := os.Stderr
-	defer func() { os.Stderr = originalStderr }()
-	os.Stderr = printer.File
-
-	runner := validation.NewRunner[*api.NodeConfig](printer)
-	apiServerValidator := kubernetes.NewAPIServerValidator(kubelet.New())
+	runner := validation.NewRunner[*api.NodeConfig](informer, append(runnerOpts, validationOpts...)...)
 	clusterProvider := kubernetes.NewClusterProvider(awsConfig)
 
 	// Register validations that do not require cluster details first
 	runner.Register(creds.Validations(awsConfig, nodeConfig)...)
-	runner.Register(
-		validation.New("ntp-sync", system.NewNTPValidator().Run),
-		validation.New("swap", system.NewSwapValidator().Run),
-		validation.New("ulimit", system.NewUlimitValidator().Run),
-		validation.New("aws-auth", sts.NewAuthenticationValidator(awsConfig).Run),
-		validation.New("proxy-config", network.NewProxyValidator().Run),
-	)
+	runner.Register(nodeValidations(awsConfig)...)
 
 	clusterDetail, err := clusterProvider.ReadClusterDetails(ctx, nodeConfig)
 	if err != nil {
@@ -112,27 +190,27 @@ func (c *debug) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
 		// Otherwise, there is no need to surface we are reading from the EKS API.
 		err = validation.WithRemediation(err, "Ensure the Kubernetes API server endpoint is provided or "+
 			"the node has access and permissions to call EKS DescribeCluster API.")
-		printer.Starting(ctx, "cluster-details-retrieval", "Retrieving cluster details")
-		printer.Done(ctx, "cluster-details-retrieval", err)
-		return err
+		return c.reportFailure(ctx, informer, report, "cluster-details-retrieval", "Retrieving cluster details", err)
 	}
 
-	runner.Register(
-		runner.UntilError(
-			validation.New("k8s-endpoint-network", kubernetes.NewAccessValidator(clusterDetail).Run),
-			validation.New("k8s-authentication", apiServerValidator.MakeAuthenticatedRequest),
-			validation.New("k8s-identity", apiServerValidator.CheckIdentity),
-			validation.New("k8s-vpc-network", apiServerValidator.CheckVPCEndpointAccess),
-		),
-		validation.New("k8s-certificate", kubernetes.NewKubeletCertificateValidator(clusterDetail).Run),
-	)
-
 	cluster, _ := eks.ReadCluster(ctx, awsConfig, nodeConfig)
-	runner.Register(validation.New("network-interface", network.NewNetworkInterfaceValidator(network.WithCluster(cluster)).Run))
-
-	runner.Register(validation.New("active-node-validation", nodevalidator.NewActiveNodeValidator().Run))
+	apiServerValidations, otherValidations := clusterValidations(clusterDetail, cluster)
+	runner.Register(runner.UntilError(apiServerValidations...))
+	runner.Register(otherValidations...)
+
+	err = runner.Concurrently(ctx, nodeConfig)
+	if report != nil {
+		if writeErr := c.writeReport(report); writeErr != nil {
+			return writeErr
+		}
+		if err != nil {
+			// Failures are part of the report, only the exit code needs to reflect them.
+			return errors.NewSilent(err)
+		}
+		return nil
+	}
 
-	if err := runner.Sequentially(ctx, nodeConfig); err != nil {
+	if err != nil {
 		fmt.Println("")
 		fmt.Println("Issues found during validation. Please follow the remediation advice above.")
 		// Errors are already presented by the printer
@@ -142,3 +220,24 @@ func (c *debug) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
 
 	return nil
 }
+
+// reportFailure signals err, which prevents the validations from running, as a failed
+// validation. With a report output, the report is written so it always has a document.
+func (c *debug) reportFailure(ctx context.Context, informer validation.Informer, report *validation.Report, name, message string, err error) error {
+	informer.Starting(ctx, name, message)
+	informer.Done(ctx, name, err)
+	if report != nil {
+		if writeErr := c.writeReport(report); writeErr != nil {
+			return writeErr
+		}
+		return errors.NewSilent(err)
+	}
+	return err
+}
+
+func (c *debug) writeReport(report *validation.Report) error {
+	if c.output == outputJUnit {
+		return report.WriteJUnit(os.Stdout, junitSuiteName)
+	}
+	return report.WriteJSON(os.Stdout)
+}
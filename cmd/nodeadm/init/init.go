@@ -11,12 +11,15 @@ import (
 	"k8s.io/utils/strings/slices"
 
 	"github.com/aws/eks-hybrid/internal/cli"
+	"github.com/aws/eks-hybrid/internal/configprovider"
 	"github.com/aws/eks-hybrid/internal/containerd"
+	"github.com/aws/eks-hybrid/internal/daemon"
 	"github.com/aws/eks-hybrid/internal/flows"
 	"github.com/aws/eks-hybrid/internal/logger"
 	"github.com/aws/eks-hybrid/internal/node"
 	"github.com/aws/eks-hybrid/internal/system"
 	"github.com/aws/eks-hybrid/internal/tracker"
+	"github.com/aws/eks-hybrid/internal/validation"
 )
 
 const (
@@ -54,17 +57,26 @@ const initHelpText = `Examples:
   # Initialize using configuration file
   nodeadm init --config-source file://nodeConfig.yaml
 
+  # Initialize using configuration stored in S3
+  nodeadm init --config-source s3://my-bucket/nodeConfig.yaml?region=us-west-2
+
+  # Show the files, registrations and daemon operations init would apply without changing the host
+  nodeadm init --config-source file://nodeConfig.yaml --dry-run
+
 Documentation:
   https://docs.aws.amazon.com/eks/latest/userguide/hybrid-nodes-nodeadm.html#_init`
 
 func NewInitCommand() cli.Command {
 	init := initCmd{}
 	init.cmd = flaggy.NewSubcommand("init")
-	init.cmd.String(&init.configSource, "c", "config-source", "Source of node configuration. The format is a URI with supported schemes: [file, imds].")
+	init.cmd.String(&init.configSource, "c", "config-source", "Source of node configuration. The format is a URI with supported schemes: [file, imds, s3, https].")
+	init.cmd.String(&init.configCABundle, "", "config-source-ca-bundle", "Path to a PEM encoded CA bundle used to verify https config sources.")
 	init.cmd.StringSlice(&init.daemons, "d", "daemon", "Specify one or more of `containerd` and `kubelet`. This is intended for testing and should not be used in a production environment.")
 	init.cmd.StringSlice(&init.skipPhases, "s", "skip", fmt.Sprintf("Phases of the bootstrap to skip. Allowed values: [%s].", strings.Join(Phases(), ", ")))
 	init.cmd.String(&init.manifestOverride, "m", "manifest-override", "URI to a manifest file containing custom artifact URLs. Supports file:// for local files and https:// for remote files.")
 	init.cmd.Bool(&init.privateMode, "", "private-mode", "Enable private init mode (requires --manifest-override for region config).")
+	init.cmd.Bool(&init.dryRun, "", "dry-run", "Print the changes init would make to the host without applying them.")
+	init.validationPolicy = cli.RegisterValidationPolicyFlags(init.cmd)
 	init.cmd.Description = "Initialize this instance as a node in an EKS cluster"
 	init.cmd.AdditionalHelpAppend = initHelpText
 	return &init
@@ -73,10 +85,13 @@ func NewInitCommand() cli.Command {
 type initCmd struct {
 	cmd              *flaggy.Subcommand
 	configSource     string
+	configCABundle   string
 	skipPhases       []string
 	daemons          []string
 	manifestOverride string
 	privateMode      bool
+	dryRun           bool
+	validationPolicy *cli.ValidationPolicyFlags
 }
 
 func (c *initCmd) Flaggy() *flaggy.Subcommand {
@@ -96,7 +111,7 @@ func (c *initCmd) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
 	}
 
 	if c.configSource == "" {
-		flaggy.ShowHelpAndExit("--config-source is a required flag. The format is a URI with supported schemes: [file, imds]." +
+		flaggy.ShowHelpAndExit("--config-source is a required flag. The format is a URI with supported schemes: [file, imds, s3, https]." +
 			" For example on hybrid nodes --config-source file://nodeConfig.yaml")
 	}
 
@@ -104,6 +119,20 @@ func (c *initCmd) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
 		return fmt.Errorf("--private-mode requires --manifest-override to be specified")
 	}
 
+	validationOpts, err := c.validationPolicy.RunnerOpts(node.ValidationNames())
+	if err != nil {
+		return err
+	}
+	// Validation results are included in the node status report.
+	validations := validation.NewReport()
+	validationOpts = append(validationOpts, validation.WithInformers(validations))
+
+	// The install and firewall validations reload systemd units and flush firewall rules,
+	// so they are skipped when planning.
+	if c.dryRun {
+		return c.plan(ctx, validationOpts, log)
+	}
+
 	if !slices.Contains(c.skipPhases, installValidation) {
 		log.Info("Loading installed components")
 		_, err = tracker.GetInstalledArtifacts()
@@ -128,7 +157,7 @@ func (c *initCmd) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
 		}
 	}
 
-	nodeProvider, err := node.NewNodeProvider(c.configSource, c.skipPhases, log)
+	nodeProvider, err := node.NewNodeProvider(c.configSource, c.skipPhases, validationOpts, log, configprovider.WithCABundle(c.configCABundle))
 	if err != nil {
 		return err
 	}
@@ -139,11 +168,42 @@ func (c *initCmd) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
 		Logger:           log,
 		ManifestOverride: c.manifestOverride,
 		PrivateMode:      c.privateMode,
+		Validations:      validations,
 	}
 
 	return initer.Run(ctx)
 }
 
+func (c *initCmd) plan(ctx context.Context, validationOpts []validation.RunnerOpt, log *zap.Logger) error {
+	provider, err := configprovider.BuildConfigProvider(c.configSource, configprovider.WithCABundle(c.configCABundle))
+	if err != nil {
+		return err
+	}
+	nodeConfig, err := provider.Provide()
+	if err != nil {
+		return err
+	}
+
+	daemonManager := daemon.NewRecordingDaemonManager(nil)
+	nodeProvider, err := node.NewRecordingNodeProvider(ctx, nodeConfig, c.skipPhases, validationOpts, daemonManager, log)
+	if err != nil {
+		return err
+	}
+
+	initer := &flows.Initer{
+		NodeProvider:     nodeProvider,
+		SkipPhases:       c.skipPhases,
+		Logger:           log,
+		ManifestOverride: c.manifestOverride,
+		PrivateMode:      c.privateMode,
+	}
+	plan, err := initer.Plan(ctx, daemonManager)
+	if err != nil {
+		return err
+	}
+	return plan.Write(os.Stdout)
+}
+
 func validateFirewallOpenPorts() error {
 	firewallManager := system.NewFirewallManager()
 	enabled, err := firewallManager.IsEnabled()
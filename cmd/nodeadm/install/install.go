@@ -3,12 +3,14 @@ package install
 import (
 	"context"
 	"fmt"
+	"os"
 	"time"
 
 	"github.com/integrii/flaggy"
 	"go.uber.org/zap"
 
 	"github.com/aws/eks-hybrid/internal/aws"
+	"github.com/aws/eks-hybrid/internal/bundle"
 	"github.com/aws/eks-hybrid/internal/cli"
 	"github.com/aws/eks-hybrid/internal/containerd"
 	"github.com/aws/eks-hybrid/internal/creds"
@@ -29,6 +31,12 @@ const installHelpText = `Examples:
   # Install from a private installation using a local custom manifest (for air-gapped environments)
   nodeadm install 1.31 --credential-provider ssm --manifest-override file://./manifest-1.31.13-arm64-linux-1765487946.yaml --private-mode
 
+  # Show what would be installed without changing the host
+  nodeadm install 1.31 --credential-provider ssm --dry-run
+
+  # Install from an offline install bundle created with nodeadm bundle create
+  nodeadm install 1.31 --credential-provider ssm --bundle bundle.tar.zst
+
   # Install from a private installation using a remote custom manifest
   nodeadm install 1.31 --credential-provider ssm --manifest-override https://my-bucket.s3.us-west-2.amazonaws.com/manifests/manifest.yaml --private-mode
 
@@ -50,8 +58,13 @@ func NewCommand() cli.Command {
 	fc.String(&cmd.containerdSource, "s", "containerd-source", "Source for containerd artifact. Allowed values: [none, distro, docker].")
 	fc.String(&cmd.region, "r", "region", "AWS region for downloading regional artifacts.")
 	fc.String(&cmd.manifestOverride, "m", "manifest-override", "URI to a manifest file containing custom artifact URLs. Supports file:// for local files and https:// for remote files.")
+	fc.String(&cmd.bundle, "b", "bundle", "Path to an offline install bundle created with nodeadm bundle create. All artifacts are read from the bundle and OS packages are skipped.")
 	fc.Bool(&cmd.privateMode, "", "private-mode", "Enable private installation mode (skips OS packages, requires --manifest-override).")
 	fc.Duration(&cmd.timeout, "t", "timeout", "Maximum install command duration. Input follows duration format. Example: 1h23s")
+	fc.Bool(&cmd.dryRun, "", "dry-run", "Print the packages, artifacts and files that would be installed without changing the host.")
+	cmd.download = cli.RegisterDownloadFlags(fc)
+	cmd.signature = cli.RegisterSignatureFlags(fc)
+	cmd.pin = cli.RegisterPinFlags(fc)
 	cmd.flaggy = fc
 
 	return &cmd
@@ -64,8 +77,13 @@ type command struct {
 	containerdSource   string
 	region             string
 	manifestOverride   string
+	bundle             string
 	privateMode        bool
+	dryRun             bool
 	timeout            time.Duration
+	download           *cli.DownloadFlags
+	signature          *cli.SignatureFlags
+	pin                *cli.PinFlags
 }
 
 func (c *command) Flaggy() *flaggy.Subcommand {
@@ -88,6 +106,26 @@ func (c *command) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
 		flaggy.ShowHelpAndExit("--credential-provider is a required flag. Allowed values are ssm & iam-ra")
 	}
 
+	var ssmInstallerOpts []ssm.SSMInstallerOption
+	if c.bundle != "" {
+		if c.manifestOverride != "" {
+			return fmt.Errorf("--bundle and --manifest-override can't be used together")
+		}
+		bundleOpts, err := c.signature.BundleOpts()
+		if err != nil {
+			return err
+		}
+		log.Info("Extracting bundle", zap.String("bundle", c.bundle))
+		b, err := bundle.Open(c.bundle, bundleOpts...)
+		if err != nil {
+			return err
+		}
+		defer b.Close()
+		c.manifestOverride = b.ManifestURI()
+		c.privateMode = true
+		ssmInstallerOpts = b.SSMInstallerOpts()
+	}
+
 	if c.privateMode && c.manifestOverride == "" {
 		return fmt.Errorf("--private-mode requires --manifest-override to be specified")
 	}
@@ -108,13 +146,33 @@ func (c *command) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
 	ctx, cancel := context.WithTimeout(ctx, c.timeout)
 	defer cancel()
 
+	var manifestOpts []aws.ManifestOption
+	if c.bundle == "" {
+		// Bundles are verified when they are opened, their manifest is generated
+		// when the bundle is created so it has no signature.
+		manifestOpts, err = c.signature.ManifestOpts()
+		if err != nil {
+			return err
+		}
+	}
+	pinOpts, err := c.pin.ManifestOpts()
+	if err != nil {
+		return err
+	}
+	manifestOpts = append(manifestOpts, pinOpts...)
+	fetchers, err := c.download.Fetchers(c.region)
+	if err != nil {
+		return err
+	}
+	manifestOpts = append(manifestOpts, aws.WithFetchers(fetchers))
+
 	var awsSource aws.Source
 	var packageManager *packagemanager.DistroPackageManager
 
 	// Use manifest override if provided, otherwise use default AWS source
 	if c.manifestOverride != "" {
 		log.Info("Using manifest override", zap.String("manifest", c.manifestOverride))
-		awsSource, err = aws.GetLatestSourceFromManifest(ctx, c.kubernetesVersion, c.region, c.manifestOverride)
+		awsSource, err = aws.GetLatestSourceFromManifest(ctx, c.kubernetesVersion, c.region, c.manifestOverride, manifestOpts...)
 		if err != nil {
 			return err
 		}
@@ -122,12 +180,15 @@ func (c *command) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
 	} else {
 		log.Info("Validating Kubernetes version", zap.Reflect("kubernetes version", c.kubernetesVersion))
 		// Create a Source for all AWS managed artifacts.
-		awsSource, err = aws.GetLatestSource(ctx, c.kubernetesVersion, c.region)
+		awsSource, err = aws.GetLatestSource(ctx, c.kubernetesVersion, c.region, manifestOpts...)
 		if err != nil {
 			return err
 		}
 		log.Info("Using Kubernetes version", zap.String("version", awsSource.Eks.Version))
 	}
+	if c.bundle == "" {
+		awsSource.Downloader = c.download.Downloader(log, fetchers)
+	}
 
 	// Create package manager unless in private mode
 	if !c.privateMode {
@@ -146,6 +207,15 @@ func (c *command) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
 		CredentialProvider: credentialProvider,
 		Logger:             log,
 		PrivateMode:        c.privateMode,
+		SsmInstallerOpts:   ssmInstallerOpts,
+	}
+
+	if c.dryRun {
+		plan, err := installer.Plan(ctx)
+		if err != nil {
+			return err
+		}
+		return plan.Write(os.Stdout)
 	}
 
 	return installer.Run(ctx)
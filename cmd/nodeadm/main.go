@@ -6,14 +6,20 @@ import (
 	"github.com/integrii/flaggy"
 	"go.uber.org/zap"
 
+	"github.com/aws/eks-hybrid/cmd/nodeadm/bundle"
 	"github.com/aws/eks-hybrid/cmd/nodeadm/config"
 	"github.com/aws/eks-hybrid/cmd/nodeadm/debug"
 	initcmd "github.com/aws/eks-hybrid/cmd/nodeadm/init"
 	"github.com/aws/eks-hybrid/cmd/nodeadm/install"
+	"github.com/aws/eks-hybrid/cmd/nodeadm/manifest"
+	"github.com/aws/eks-hybrid/cmd/nodeadm/rollback"
+	"github.com/aws/eks-hybrid/cmd/nodeadm/status"
 	"github.com/aws/eks-hybrid/cmd/nodeadm/sync_artifacts"
 	"github.com/aws/eks-hybrid/cmd/nodeadm/uninstall"
 	"github.com/aws/eks-hybrid/cmd/nodeadm/upgrade"
+	"github.com/aws/eks-hybrid/cmd/nodeadm/verify"
 	"github.com/aws/eks-hybrid/cmd/nodeadm/version"
+	"github.com/aws/eks-hybrid/cmd/nodeadm/versions"
 	"github.com/aws/eks-hybrid/internal/cli"
 	"github.com/aws/eks-hybrid/internal/errors"
 )
@@ -34,11 +40,17 @@ func main() {
 	cmds := []cli.Command{
 		config.NewConfigCommand(),
 		sync_artifacts.NewCommand(),
+		bundle.NewCommand(),
 		initcmd.NewInitCommand(),
 		install.NewCommand(),
 		uninstall.NewCommand(),
 		upgrade.NewUpgradeCommand(),
+		rollback.NewCommand(),
 		debug.NewCommand(),
+		status.NewCommand(),
+		verify.NewCommand(),
+		versions.NewCommand(),
+		manifest.NewCommand(),
 	}
 
 	for _, cmd := range cmds {
@@ -0,0 +1,103 @@
+package manifest
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"runtime"
+	"text/tabwriter"
+
+	"github.com/integrii/flaggy"
+	"go.uber.org/zap"
+
+	"github.com/aws/eks-hybrid/internal/aws"
+	"github.com/aws/eks-hybrid/internal/cli"
+	"github.com/aws/eks-hybrid/internal/logger"
+)
+
+func NewArtifactsCommand() cli.Command {
+	cmd := artifactsCmd{
+		arch: runtime.GOARCH,
+		os:   "linux",
+	}
+
+	fc := flaggy.NewSubcommand("artifacts")
+	fc.Description = "Show the artifacts installed for a Kubernetes version"
+	fc.AddPositionalValue(&cmd.kubernetesVersion, "KUBERNETES_VERSION", 1, true, "The major.minor[.patch] version of Kubernetes to show artifacts for.")
+	fc.String(&cmd.arch, "a", "arch", "Architecture of the artifacts.")
+	fc.String(&cmd.manifestOverride, "m", "manifest-override", "URI to a manifest file to read instead of the default release manifest. Supports file://, https://, s3:// and oci:// URIs.")
+	fc.String(&cmd.output, "o", "output", "Output format. One of: [text, json].")
+	cmd.manifest = cli.RegisterManifestFlags(fc)
+	cmd.flaggy = fc
+
+	return &cmd
+}
+
+type artifactsCmd struct {
+	flaggy            *flaggy.Subcommand
+	kubernetesVersion string
+	arch              string
+	os                string
+	manifestOverride  string
+	output            string
+	manifest          *cli.ManifestFlags
+}
+
+func (c *artifactsCmd) Flaggy() *flaggy.Subcommand {
+	return c.flaggy
+}
+
+type releaseArtifact struct {
+	Release string `json:"release"`
+	Version string `json:"version"`
+	aws.Artifact
+}
+
+func (c *artifactsCmd) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
+	ctx := logger.NewContext(context.Background(), log)
+
+	if err := validateOutput(c.output); err != nil {
+		return err
+	}
+
+	manifest, err := c.manifest.GetManifest(ctx, c.manifestOverride)
+	if err != nil {
+		return err
+	}
+	eksRelease, err := manifest.EksRelease(c.kubernetesVersion)
+	if err != nil {
+		return err
+	}
+	iamRelease, err := manifest.LatestIamRolesAnywhereRelease()
+	if err != nil {
+		return err
+	}
+
+	var artifacts []releaseArtifact
+	for _, artifact := range eksRelease.Artifacts {
+		if artifact.Arch == c.arch && artifact.OS == c.os {
+			artifacts = append(artifacts, releaseArtifact{Release: "eks", Version: eksRelease.Version, Artifact: artifact})
+		}
+	}
+	for _, artifact := range iamRelease.Artifacts {
+		if artifact.Arch == c.arch && artifact.OS == c.os {
+			artifacts = append(artifacts, releaseArtifact{Release: "iam-roles-anywhere", Version: iamRelease.Version, Artifact: artifact})
+		}
+	}
+	if len(artifacts) == 0 {
+		return fmt.Errorf("no artifacts for Kubernetes version %s, arch %s and os %s", eksRelease.Version, c.arch, c.os)
+	}
+
+	if c.output == outputJSON {
+		return writeJSON(artifacts)
+	}
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "Kubernetes version:\t%s\n", eksRelease.Version)
+	fmt.Fprintf(tw, "Release date:\t%s\n", eksRelease.ReleaseDate)
+	fmt.Fprintln(tw)
+	fmt.Fprintln(tw, "RELEASE\tVERSION\tNAME\tURI\tCHECKSUM URI")
+	for _, artifact := range artifacts {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", artifact.Release, artifact.Version, artifact.Name, artifact.URI, artifact.ChecksumURI)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,84 @@
+package manifest
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/integrii/flaggy"
+	"go.uber.org/zap"
+
+	"github.com/aws/eks-hybrid/internal/aws"
+	"github.com/aws/eks-hybrid/internal/cli"
+	"github.com/aws/eks-hybrid/internal/errors"
+	"github.com/aws/eks-hybrid/internal/logger"
+)
+
+func NewDiffCommand() cli.Command {
+	cmd := diffCmd{}
+
+	fc := flaggy.NewSubcommand("diff")
+	fc.Description = "Show the differences between two release manifests. Exits with status 1 if they differ"
+	fc.AddPositionalValue(&cmd.oldManifest, "OLD_MANIFEST", 1, true, "URI of the manifest to compare from. Supports file://, https://, s3:// and oci:// URIs.")
+	fc.AddPositionalValue(&cmd.newManifest, "NEW_MANIFEST", 2, true, "URI of the manifest to compare to.")
+	fc.String(&cmd.output, "o", "output", "Output format. One of: [text, json].")
+	cmd.manifest = cli.RegisterManifestFlags(fc)
+	cmd.flaggy = fc
+
+	return &cmd
+}
+
+type diffCmd struct {
+	flaggy      *flaggy.Subcommand
+	oldManifest string
+	newManifest string
+	output      string
+	manifest    *cli.ManifestFlags
+}
+
+func (c *diffCmd) Flaggy() *flaggy.Subcommand {
+	return c.flaggy
+}
+
+func (c *diffCmd) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
+	ctx := logger.NewContext(context.Background(), log)
+
+	if err := validateOutput(c.output); err != nil {
+		return err
+	}
+
+	oldManifest, err := c.manifest.GetManifest(ctx, c.oldManifest)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", c.oldManifest, err)
+	}
+	newManifest, err := c.manifest.GetManifest(ctx, c.newManifest)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", c.newManifest, err)
+	}
+
+	changes := aws.DiffManifests(oldManifest, newManifest)
+	if c.output == outputJSON {
+		if changes == nil {
+			changes = []aws.ManifestChange{}
+		}
+		if err := writeJSON(changes); err != nil {
+			return err
+		}
+	} else {
+		for _, change := range changes {
+			switch {
+			case change.Old == "":
+				fmt.Printf("+ %s: %s\n", change.Field, change.New)
+			case change.New == "":
+				fmt.Printf("- %s: %s\n", change.Field, change.Old)
+			default:
+				fmt.Printf("~ %s: %s -> %s\n", change.Field, change.Old, change.New)
+			}
+		}
+	}
+
+	if len(changes) > 0 {
+		// Like diff, exit with status 1 so scripts can check for changes.
+		return errors.NewSilent(fmt.Errorf("manifests differ"))
+	}
+	return nil
+}
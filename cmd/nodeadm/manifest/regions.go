@@ -0,0 +1,72 @@
+package manifest
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"text/tabwriter"
+
+	"github.com/integrii/flaggy"
+	"go.uber.org/zap"
+
+	"github.com/aws/eks-hybrid/internal/cli"
+	"github.com/aws/eks-hybrid/internal/logger"
+)
+
+// credentialProviders are the credential providers shown for each region, in the
+// same order and with the same names as the --credential-provider install flag.
+var credentialProviders = []string{"ssm", "iam-ra"}
+
+func NewRegionsCommand() cli.Command {
+	cmd := regionsCmd{}
+
+	fc := flaggy.NewSubcommand("regions")
+	fc.Description = "Show the regions in the release manifest and the credential providers they support"
+	fc.String(&cmd.manifestOverride, "m", "manifest-override", "URI to a manifest file to read instead of the default release manifest. Supports file://, https://, s3:// and oci:// URIs.")
+	fc.String(&cmd.output, "o", "output", "Output format. One of: [text, json].")
+	cmd.manifest = cli.RegisterManifestFlags(fc)
+	cmd.flaggy = fc
+
+	return &cmd
+}
+
+type regionsCmd struct {
+	flaggy           *flaggy.Subcommand
+	manifestOverride string
+	output           string
+	manifest         *cli.ManifestFlags
+}
+
+func (c *regionsCmd) Flaggy() *flaggy.Subcommand {
+	return c.flaggy
+}
+
+func (c *regionsCmd) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
+	ctx := logger.NewContext(context.Background(), log)
+
+	if err := validateOutput(c.output); err != nil {
+		return err
+	}
+
+	manifest, err := c.manifest.GetManifest(ctx, c.manifestOverride)
+	if err != nil {
+		return err
+	}
+
+	if c.output == outputJSON {
+		return writeJSON(manifest.RegionConfig)
+	}
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "REGION\tPARTITION\tDNS SUFFIX\tECR ACCOUNT\tSSM\tIAM-RA")
+	for _, region := range slices.Sorted(maps.Keys(manifest.RegionConfig)) {
+		data := manifest.RegionConfig[region]
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s", region, data.Partition, data.DnsSuffix, data.EcrAccountID)
+		for _, provider := range credentialProviders {
+			fmt.Fprintf(tw, "\t%t", data.CredProviders[provider])
+		}
+		fmt.Fprintln(tw)
+	}
+	return tw.Flush()
+}
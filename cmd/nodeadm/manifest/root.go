@@ -0,0 +1,49 @@
+package manifest
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/aws/eks-hybrid/internal/cli"
+)
+
+const manifestHelpText = `Examples:
+  # Show the artifacts of Kubernetes version 1.31 for ARM64 hosts
+  nodeadm manifest artifacts 1.31 --arch arm64
+
+  # Show which credential providers each region supports
+  nodeadm manifest regions
+
+  # Review the changes to a private mirror's manifest before publishing it
+  nodeadm manifest diff https://mirror.example.com/manifest.yaml file:///tmp/manifest.yaml
+
+Documentation:
+  https://docs.aws.amazon.com/eks/latest/userguide/hybrid-nodes-nodeadm.html`
+
+const (
+	outputText = "text"
+	outputJSON = "json"
+)
+
+func NewCommand() cli.Command {
+	container := cli.NewCommandContainer("manifest", "Inspect release manifests")
+	container.Flaggy().AdditionalHelpAppend = manifestHelpText
+	container.AddCommand(NewArtifactsCommand())
+	container.AddCommand(NewRegionsCommand())
+	container.AddCommand(NewDiffCommand())
+	return container.AsCommand()
+}
+
+func validateOutput(output string) error {
+	if output != "" && output != outputText && output != outputJSON {
+		return fmt.Errorf("invalid --output %q, must be one of: [%s, %s]", output, outputText, outputJSON)
+	}
+	return nil
+}
+
+func writeJSON(v any) error {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
@@ -0,0 +1,69 @@
+package rollback
+
+import (
+	"context"
+
+	"github.com/integrii/flaggy"
+	"go.uber.org/zap"
+
+	"github.com/aws/eks-hybrid/internal/cli"
+	"github.com/aws/eks-hybrid/internal/daemon"
+	"github.com/aws/eks-hybrid/internal/flows"
+	"github.com/aws/eks-hybrid/internal/logger"
+)
+
+const rollbackHelpText = `Examples:
+  # Restore the binaries and configuration from before the last upgrade
+  nodeadm rollback
+
+Documentation:
+  https://docs.aws.amazon.com/eks/latest/userguide/hybrid-nodes-nodeadm.html`
+
+func NewCommand() cli.Command {
+	cmd := command{}
+
+	fc := flaggy.NewSubcommand("rollback")
+	fc.Description = "Restore the binaries and configuration replaced by the last upgrade"
+	fc.AdditionalHelpAppend = rollbackHelpText
+	cmd.flaggy = fc
+
+	return &cmd
+}
+
+type command struct {
+	flaggy *flaggy.Subcommand
+}
+
+func (c *command) Flaggy() *flaggy.Subcommand {
+	return c.flaggy
+}
+
+func (c *command) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
+	ctx := context.Background()
+	ctx = logger.NewContext(ctx, log)
+
+	root, err := cli.IsRunningAsRoot()
+	if err != nil {
+		return err
+	}
+	if !root {
+		return cli.ErrMustRunAsRoot
+	}
+
+	log.Info("Creating daemon manager...")
+	daemonManager, err := daemon.NewDaemonManager()
+	if err != nil {
+		return err
+	}
+	defer daemonManager.Close()
+
+	rollbacker := &flows.Rollbacker{
+		DaemonManager: daemonManager,
+		Logger:        log,
+	}
+	if err := rollbacker.Run(ctx); err != nil {
+		return err
+	}
+	log.Info("Rollback complete")
+	return nil
+}
@@ -0,0 +1,84 @@
+package status
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+
+	"github.com/integrii/flaggy"
+	"go.uber.org/zap"
+
+	"github.com/aws/eks-hybrid/internal/cli"
+	"github.com/aws/eks-hybrid/internal/nodestatus"
+	"github.com/aws/eks-hybrid/internal/tracker"
+)
+
+const statusHelpText = `Examples:
+  # Show a summary of the node status
+  nodeadm status
+
+  # Print the node status report and installed components as JSON
+  nodeadm status --output json
+
+Documentation:
+  https://docs.aws.amazon.com/eks/latest/userguide/hybrid-nodes-nodeadm.html`
+
+const (
+	outputText = "text"
+	outputJSON = "json"
+)
+
+func NewCommand() cli.Command {
+	cmd := command{}
+
+	fc := flaggy.NewSubcommand("status")
+	fc.Description = "Show the outcome of the last init or upgrade and the installed components"
+	fc.AdditionalHelpAppend = statusHelpText
+	fc.String(&cmd.output, "o", "output", "Output format. One of: [text, json].")
+	cmd.flaggy = fc
+
+	return &cmd
+}
+
+type command struct {
+	flaggy *flaggy.Subcommand
+	output string
+}
+
+func (c *command) Flaggy() *flaggy.Subcommand {
+	return c.flaggy
+}
+
+func (c *command) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
+	report, err := nodestatus.Load()
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	installed, err := tracker.GetInstalledArtifacts()
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	switch c.output {
+	case "", outputText:
+		return nodestatus.WriteSummary(os.Stdout, report, installed)
+	case outputJSON:
+		status := struct {
+			Status    *nodestatus.Report          `json:"status"`
+			Artifacts *tracker.InstalledArtifacts `json:"artifacts"`
+		}{
+			Status: report,
+		}
+		if installed != nil {
+			status.Artifacts = installed.Artifacts
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(status)
+	default:
+		return fmt.Errorf("invalid --output %q, must be one of: [%s, %s]", c.output, outputText, outputJSON)
+	}
+}
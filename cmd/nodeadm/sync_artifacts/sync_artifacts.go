@@ -3,25 +3,30 @@ package sync_artifacts
 import (
 	"context"
 	"fmt"
-	"net/http"
+	"net/url"
 	"os"
+	"path"
 	"runtime"
+	"slices"
 	"strings"
 	"time"
 
 	awsSDKv2 "github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
-	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
-	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/integrii/flaggy"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
+	"golang.org/x/mod/semver"
 	"sigs.k8s.io/yaml"
 
+	"github.com/aws/eks-hybrid/internal/artifact"
 	"github.com/aws/eks-hybrid/internal/aws"
+	s3dest "github.com/aws/eks-hybrid/internal/aws/s3"
 	"github.com/aws/eks-hybrid/internal/cli"
 	"github.com/aws/eks-hybrid/internal/logger"
+	"github.com/aws/eks-hybrid/internal/mirror"
+	"github.com/aws/eks-hybrid/internal/ssm"
 )
 
 const syncArtifactsHelpText = `Examples:
@@ -34,6 +39,18 @@ const syncArtifactsHelpText = `Examples:
   # Sync all Linux dependencies corresponding to host system's architecture to a non-default region in S3
   nodeadm sync-artifacts 1.34 --region ap-south-1 --s3-bucket my-private-bucket --s3-prefix eks-deps/ap-south-1/v1.34
 
+  # Sync Kubernetes versions 1.29 to 1.31 and 1.33 for AMD64 and ARM64 to S3, with a single manifest for all of them
+  nodeadm sync-artifacts 1.29-1.31,1.33 --arch amd64,arm64 --s3-bucket my-private-bucket --s3-prefix eks-deps
+
+  # Sync to a USB drive mounted at /media/usb, which nodes mount at /mnt/usb
+  nodeadm sync-artifacts 1.31 --destination /media/usb/eks --base-uri file:///mnt/usb/eks
+
+  # Sync to a WebDAV share, skipping artifacts that are already there
+  NODEADM_MIRROR_USERNAME=sync NODEADM_MIRROR_PASSWORD=... nodeadm sync-artifacts 1.31 --destination https://webdav.example.com/eks --incremental
+
+  # Sync to a Harbor registry, as OCI blobs
+  nodeadm sync-artifacts 1.31 --destination oci://harbor.example.com/eks/artifacts --mirror-ca-bundle /etc/pki/harbor-ca.pem
+
 Documentation:
   https://docs.aws.amazon.com/eks/latest/userguide/hybrid-nodes-nodeadm.html#_syncartifacts`
 
@@ -46,13 +63,20 @@ func NewCommand() cli.Command {
 	}
 
 	fc := flaggy.NewSubcommand("sync-artifacts")
-	fc.Description = "Sync EKS hybrid node dependencies directly to S3 for private installation"
+	fc.Description = "Sync EKS hybrid node dependencies to S3, a directory, an HTTP server or an OCI registry for private installation"
 	fc.AdditionalHelpAppend = syncArtifactsHelpText
-	fc.AddPositionalValue(&cmd.kubernetesVersion, "KUBERNETES_VERSION", 1, true, "The major[.minor[.patch]] version of Kubernetes to sync dependencies for.")
-	fc.String(&cmd.arch, "a", "arch", "Target architecture for artifacts.")
+	fc.AddPositionalValue(&cmd.kubernetesVersion, "KUBERNETES_VERSION", 1, true, "Comma separated major[.minor[.patch]] versions of Kubernetes to sync dependencies for. "+
+		"Inclusive major.minor ranges like 1.29-1.31 select every supported version in the range.")
+	fc.String(&cmd.arch, "a", "arch", "Comma separated target architectures for artifacts.")
 	fc.String(&cmd.region, "r", "region", "AWS region for downloading regional artifacts.")
-	fc.String(&cmd.s3Bucket, "", "s3-bucket", "S3 bucket to sync the dependencies to (required).")
-	fc.String(&cmd.s3Prefix, "", "s3-prefix", "S3 key prefix for the synced artifacts (required).")
+	fc.String(&cmd.destination, "d", "destination", "Where to sync the dependencies to: a local directory, an http(s):// URL uploaded to with PUT, "+
+		"an oci://<registry>/<repository> or an s3://<bucket>/<prefix>.")
+	fc.String(&cmd.baseURI, "", "base-uri", "URI prefix nodes read the synced artifacts from, if it's not the destination. Not supported for oci:// destinations.")
+	fc.Bool(&cmd.incremental, "", "incremental", "Store artifacts at the same paths as in the release instead of a new directory per sync, "+
+		"and skip artifacts whose checksum already matches at the destination.")
+	fc.String(&cmd.mirrorCABundle, "", "mirror-ca-bundle", "Path to a PEM CA bundle trusted for https:// and oci:// destinations, in addition to the system roots.")
+	fc.String(&cmd.s3Bucket, "", "s3-bucket", "S3 bucket to sync the dependencies to. Same as --destination s3://<bucket>/<prefix>.")
+	fc.String(&cmd.s3Prefix, "", "s3-prefix", "S3 key prefix for the synced artifacts, required with --s3-bucket.")
 	fc.Duration(&cmd.timeout, "t", "timeout", "Maximum sync command duration.")
 	cmd.flaggy = fc
 
@@ -65,6 +89,10 @@ type command struct {
 	arch              string
 	os                string
 	region            string
+	destination       string
+	baseURI           string
+	incremental       bool
+	mirrorCABundle    string
 	s3Bucket          string
 	s3Prefix          string
 	timeout           time.Duration
@@ -81,64 +109,159 @@ func (c *command) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
 	ctx, cancel := context.WithTimeout(ctx, c.timeout)
 	defer cancel()
 
-	log.Info("Validating Kubernetes version", zap.String("version", c.kubernetesVersion))
+	if c.s3Bucket != "" {
+		if c.destination != "" {
+			return fmt.Errorf("--s3-bucket and --destination can't be used together")
+		}
+		if c.s3Prefix == "" {
+			return fmt.Errorf("--s3-prefix is required")
+		}
+		c.destination = fmt.Sprintf("s3://%s/%s", c.s3Bucket, c.s3Prefix)
+	}
+	if c.destination == "" {
+		return fmt.Errorf("--destination or --s3-bucket is required")
+	}
+
+	log.Info("Validating Kubernetes versions", zap.String("versions", c.kubernetesVersion))
 
-	// Create a Source for all AWS managed artifacts
-	awsSource, err := aws.GetLatestSource(ctx, c.kubernetesVersion, c.region)
+	manifest, err := aws.GetManifest(ctx, c.region, "")
 	if err != nil {
 		return err
 	}
-	log.Info("Using Kubernetes version", zap.String("version", awsSource.Eks.Version))
+	versions, err := manifest.MatchVersions(c.kubernetesVersion)
+	if err != nil {
+		return err
+	}
+
+	// Create a Source for all AWS managed artifacts of each version
+	var awsSources []aws.Source
+	for _, version := range versions {
+		awsSource, err := aws.NewSourceFromManifest(version, c.region, manifest)
+		if err != nil {
+			return err
+		}
+		if slices.ContainsFunc(awsSources, func(source aws.Source) bool { return source.Eks.Version == awsSource.Eks.Version }) {
+			continue
+		}
+		log.Info("Using Kubernetes version", zap.String("version", awsSource.Eks.Version))
+		awsSources = append(awsSources, awsSource)
+	}
 
-	// Validate S3 parameters - both are required now
-	if c.s3Bucket == "" {
-		return fmt.Errorf("--s3-bucket is required")
+	var arches []string
+	for _, arch := range strings.Split(c.arch, ",") {
+		if arch = strings.TrimSpace(arch); arch != "" && !slices.Contains(arches, arch) {
+			arches = append(arches, arch)
+		}
+	}
+	if len(arches) == 0 {
+		return fmt.Errorf("--arch is required")
+	}
+
+	destination, err := c.newDestination(ctx, log, awsSources[0])
+	if err != nil {
+		return err
 	}
-	if c.s3Prefix == "" {
-		return fmt.Errorf("--s3-prefix is required")
+
+	downloader := &Downloader{
+		AwsSources: awsSources,
+		Arches:     arches,
+		OS:         c.os,
+		Region:     c.region,
+		Syncer: &mirror.Syncer{
+			Destination: destination,
+			Fetchers:    artifact.DefaultFetchers(),
+			Incremental: c.incremental,
+			Logger:      log,
+		},
+		Logger:        log,
+		SyncTimestamp: time.Now().Unix(),
+	}
+
+	return downloader.Run(ctx)
+}
+
+// newDestination returns the destination for the --destination URI.
+func (c *command) newDestination(ctx context.Context, log *zap.Logger, awsSource aws.Source) (mirror.Destination, error) {
+	destination, err := url.Parse(c.destination)
+	if err != nil {
+		return nil, fmt.Errorf("parsing destination %s: %w", c.destination, err)
+	}
+
+	switch destination.Scheme {
+	case "s3":
+		return c.newS3Destination(ctx, log, awsSource, destination.Host, strings.Trim(destination.Path, "/"))
+	case "http", "https":
+		client, err := cli.MirrorHTTPClient(c.mirrorCABundle)
+		if err != nil {
+			return nil, err
+		}
+		return mirror.NewHTTPDestination(c.destination, c.baseURI, cli.MirrorHTTPOpts(client)...)
+	case "oci":
+		if c.baseURI != "" {
+			return nil, fmt.Errorf("--base-uri is not supported for oci:// destinations")
+		}
+		repo, err := artifact.ParseOCIRepository(c.destination)
+		if err != nil {
+			return nil, err
+		}
+		client, err := cli.MirrorHTTPClient(c.mirrorCABundle)
+		if err != nil {
+			return nil, err
+		}
+		return mirror.NewOCIDestination(repo, cli.MirrorOCIFetcher(client, []string{repo.Registry})), nil
+	case "file":
+		return mirror.NewDirDestination(destination.Path, c.baseURI)
+	case "":
+		return mirror.NewDirDestination(c.destination, c.baseURI)
+	default:
+		return nil, fmt.Errorf("unsupported destination %s, expected a directory, http(s)://, oci:// or s3://", c.destination)
+	}
+}
+
+func (c *command) newS3Destination(ctx context.Context, log *zap.Logger, awsSource aws.Source, bucket, prefix string) (mirror.Destination, error) {
+	if bucket == "" {
+		return nil, fmt.Errorf("invalid destination %s, expected s3://<bucket>/<prefix>", c.destination)
 	}
 
 	// Load AWS config once
 	log.Info("Loading AWS configuration", zap.String("region", c.region))
 	cfg, err := config.LoadDefaultConfig(ctx, config.WithRegion(c.region))
 	if err != nil {
-		return fmt.Errorf("failed to load AWS config: %w", err)
+		return nil, fmt.Errorf("failed to load AWS config: %w", err)
 	}
 
 	// Create S3 service client once
 	svc := s3.NewFromConfig(cfg)
 
 	// Validate S3 bucket exists and is accessible
-	log.Info("Validating S3 bucket exists", zap.String("bucket", c.s3Bucket))
-	if err := validateS3Bucket(ctx, svc, c.s3Bucket); err != nil {
-		return fmt.Errorf("S3 bucket validation failed: %w", err)
-	}
-	log.Info("S3 bucket validation successful", zap.String("bucket", c.s3Bucket))
-
-	downloader := &Downloader{
-		AwsSource:     awsSource,
-		Arch:          c.arch,
-		OS:            c.os,
-		Region:        c.region,
-		S3Bucket:      c.s3Bucket,
-		S3Prefix:      c.s3Prefix,
-		Logger:        log,
-		S3Client:      svc,
-		SyncTimestamp: time.Now().Unix(),
+	log.Info("Validating S3 bucket exists", zap.String("bucket", bucket))
+	if err := validateS3Bucket(ctx, svc, bucket); err != nil {
+		return nil, fmt.Errorf("S3 bucket validation failed: %w", err)
+	}
+	log.Info("S3 bucket validation successful", zap.String("bucket", bucket))
+
+	baseURI := c.baseURI
+	if baseURI == "" {
+		dnsSuffix := awsSource.RegionInfo.DnsSuffix
+		if dnsSuffix == "" {
+			partition := aws.GetPartitionFromRegionFallback(c.region)
+			dnsSuffix = aws.GetPartitionDNSSuffix(partition)
+		}
+		baseURI = fmt.Sprintf("https://%s.s3.%s.%s/%s", bucket, c.region, dnsSuffix, prefix)
 	}
-
-	return downloader.Run(ctx)
+	return s3dest.NewDestination(svc, bucket, prefix, baseURI), nil
 }
 
+// Downloader syncs the artifacts of several EKS releases and architectures to a
+// destination and writes a single manifest for all of them.
 type Downloader struct {
-	AwsSource     aws.Source
-	Arch          string
+	// AwsSources has a source for each EKS release to sync.
+	AwsSources    []aws.Source
+	Arches        []string
 	OS            string
 	Region        string
-	S3Bucket      string
-	S3Prefix      string
+	Syncer        *mirror.Syncer
 	Logger        *zap.Logger
-	S3Client      *s3.Client
 	SyncTimestamp int64
 }
 
@@ -147,13 +270,23 @@ type ArtifactInfo struct {
 	URL         string
 	ChecksumURL string
 	LocalPath   string
+	Arch        string
+	// EksVersion is the EKS release of the artifact, empty for artifacts shared by
+	// every release like the IAM Roles Anywhere signing helper and the SSM installer.
+	EksVersion string
+	// Synced is where the artifact was stored in the destination.
+	Synced mirror.Synced
 }
 
 func (d *Downloader) Run(ctx context.Context) error {
-	d.Logger.Info("Starting dependency sync to S3",
-		zap.String("s3Bucket", d.S3Bucket),
-		zap.String("s3Prefix", d.S3Prefix),
-		zap.String("arch", d.Arch),
+	var versions []string
+	for _, source := range d.AwsSources {
+		versions = append(versions, source.Eks.Version)
+	}
+	d.Logger.Info("Starting dependency sync",
+		zap.Bool("incremental", d.Syncer.Incremental),
+		zap.Strings("versions", versions),
+		zap.Strings("arches", d.Arches),
 		zap.String("os", d.OS),
 	)
 
@@ -164,206 +297,155 @@ func (d *Downloader) Run(ctx context.Context) error {
 
 	d.Logger.Info("Found artifacts to sync", zap.Int("count", len(artifacts)))
 
-	for _, artifact := range artifacts {
-		if err := d.syncArtifactToS3(ctx, artifact); err != nil {
+	skipped := 0
+	for i, artifact := range artifacts {
+		synced, err := d.Syncer.Sync(ctx, mirror.Artifact{
+			Name:        artifact.Name,
+			URI:         artifact.URL,
+			ChecksumURI: artifact.ChecksumURL,
+			Path:        d.destinationPath(artifact),
+		})
+		if err != nil {
 			return errors.Wrapf(err, "sync artifact %s", artifact.Name)
 		}
+		artifacts[i].Synced = synced
+		if synced.Skipped {
+			skipped++
+		}
 	}
 
-	// Generate local manifest with custom S3 URIs
+	// Generate local manifest with the synced URIs
 	if err := d.generateCustomManifest(artifacts); err != nil {
 		return errors.Wrap(err, "generating custom manifest")
 	}
 
-	d.Logger.Info("Successfully synced all dependencies to S3",
-		zap.String("bucket", d.S3Bucket),
-		zap.String("prefix", d.S3Prefix),
-		zap.Int("artifacts", len(artifacts)))
+	d.Logger.Info("Successfully synced all dependencies",
+		zap.Int("artifacts", len(artifacts)),
+		zap.Int("skipped", skipped))
 
 	return nil
 }
 
+// collectArtifacts returns the artifacts of every release and architecture. Artifacts
+// shared by several releases, like the IAM Roles Anywhere signing helper and the SSM
+// installer, are only returned once.
 func (d *Downloader) collectArtifacts() ([]ArtifactInfo, error) {
 	var artifacts []ArtifactInfo
+	seen := map[string]bool{}
+	add := func(artifact ArtifactInfo) {
+		if seen[artifact.URL] {
+			return
+		}
+		seen[artifact.URL] = true
+		artifacts = append(artifacts, artifact)
+	}
+
+	for _, arch := range d.Arches {
+		for _, source := range d.AwsSources {
+			// EKS core artifacts
+			eksArtifacts := []string{"kubelet", "kubectl", "cni-plugins", "ecr-credential-provider", "aws-iam-authenticator"}
+			for _, name := range eksArtifacts {
+				if artifact := d.findArtifact(source.Eks.Artifacts, name, arch); artifact != nil {
+					add(ArtifactInfo{
+						Name:        name,
+						URL:         artifact.URI,
+						ChecksumURL: artifact.ChecksumURI,
+						LocalPath:   fmt.Sprintf("eks/%s/%s/%s", source.Eks.Version, arch, name),
+						Arch:        arch,
+						EksVersion:  source.Eks.Version,
+					})
+				}
+			}
+
+			// IAM Roles Anywhere artifacts
+			if artifact := d.findArtifact(source.Iam.Artifacts, "aws_signing_helper", arch); artifact != nil {
+				add(ArtifactInfo{
+					Name:        "aws_signing_helper",
+					URL:         artifact.URI,
+					ChecksumURL: artifact.ChecksumURI,
+					LocalPath:   fmt.Sprintf("iam-ra/%s/%s/aws_signing_helper", source.Iam.Version, arch),
+					Arch:        arch,
+				})
+			}
+		}
 
-	// EKS core artifacts
-	eksArtifacts := []string{"kubelet", "kubectl", "cni-plugins", "ecr-credential-provider", "aws-iam-authenticator"}
-	for _, name := range eksArtifacts {
-		if artifact := d.findArtifact(d.AwsSource.Eks.Artifacts, name); artifact != nil {
-			artifacts = append(artifacts, ArtifactInfo{
-				Name:        name,
-				URL:         artifact.URI,
-				ChecksumURL: artifact.ChecksumURI,
-				LocalPath:   fmt.Sprintf("eks/%s", name),
+		// SSM installers, stored like in bundles, for every platform of the architecture
+		// since the platform depends on the host package manager.
+		for _, platform := range ssm.Platforms(arch) {
+			installerURL := ssm.InstallerURL(d.Region, d.AwsSources[0].RegionInfo.DnsSuffix, platform)
+			add(ArtifactInfo{
+				Name:      "ssm-setup-cli",
+				URL:       installerURL,
+				LocalPath: ssm.InstallerPath(platform),
+				Arch:      arch,
+			})
+			// Signature files don't have checksums
+			add(ArtifactInfo{
+				Name:      "ssm-setup-cli.sig",
+				URL:       installerURL + ".sig",
+				LocalPath: ssm.InstallerPath(platform) + ".sig",
+				Arch:      arch,
 			})
 		}
 	}
 
-	// IAM Roles Anywhere artifacts
-	if artifact := d.findArtifact(d.AwsSource.Iam.Artifacts, "aws_signing_helper"); artifact != nil {
-		artifacts = append(artifacts, ArtifactInfo{
-			Name:        "aws_signing_helper",
-			URL:         artifact.URI,
-			ChecksumURL: artifact.ChecksumURI,
-			LocalPath:   "iam-ra/aws_signing_helper",
-		})
-	}
-
-	// SSM artifacts
-	installerURL, err := d.getSSMInstallerURL()
-	if err == nil {
-		// Add the main SSM installer
-		artifacts = append(artifacts, ArtifactInfo{
-			Name:      "ssm-setup-cli",
-			URL:       installerURL,
-			LocalPath: "ssm/ssm-setup-cli",
-		})
-		// Add the signature file
-		sigURL := installerURL + ".sig"
-		artifacts = append(artifacts, ArtifactInfo{
-			Name:        "ssm-setup-cli.sig",
-			URL:         sigURL,
-			ChecksumURL: "", // Signature files don't have checksums
-			LocalPath:   "ssm/ssm-setup-cli.sig",
-		})
-	} else {
-		d.Logger.Warn("Failed to get SSM installer URL", zap.Error(err))
-	}
-
 	if len(artifacts) == 0 {
-		return nil, fmt.Errorf("no artifacts found for architecture %s and OS %s", d.Arch, d.OS)
+		return nil, fmt.Errorf("no artifacts found for architectures %s and OS %s", strings.Join(d.Arches, ","), d.OS)
 	}
 
 	return artifacts, nil
 }
 
-func (d *Downloader) findArtifact(artifacts []aws.Artifact, name string) *aws.Artifact {
+func (d *Downloader) findArtifact(artifacts []aws.Artifact, name, arch string) *aws.Artifact {
 	for _, artifact := range artifacts {
-		if artifact.Name == name && artifact.Arch == d.Arch && artifact.OS == d.OS {
+		if artifact.Name == name && artifact.Arch == arch && artifact.OS == d.OS {
 			return &artifact
 		}
 	}
 	return nil
 }
 
-func (d *Downloader) syncArtifactToS3(ctx context.Context, artifact ArtifactInfo) error {
-	d.Logger.Info("Syncing artifact to S3",
-		zap.String("name", artifact.Name),
-		zap.String("url", artifact.URL))
-
-	// Download and upload main artifact
-	s3Key := fmt.Sprintf("%s/%d/%s", strings.TrimSuffix(d.S3Prefix, "/"), d.SyncTimestamp, artifact.LocalPath)
-	if err := d.streamToS3(ctx, d.S3Client, artifact.URL, s3Key); err != nil {
-		return errors.Wrapf(err, "streaming %s to S3", artifact.Name)
-	}
-
-	// Download and upload checksum if available
-	if artifact.ChecksumURL != "" {
-		checksumKey := s3Key + ".sha256"
-		if err := d.streamToS3(ctx, d.S3Client, artifact.ChecksumURL, checksumKey); err != nil {
-			d.Logger.Warn("Failed to sync checksum to S3",
-				zap.String("artifact", artifact.Name),
-				zap.Error(err))
+// destinationPath returns where the artifact is stored in the destination. Incremental
+// syncs keep the path of the artifact in the release, so the same release artifact
+// is always stored at the same path. Otherwise, each sync gets its own directory.
+func (d *Downloader) destinationPath(artifact ArtifactInfo) string {
+	if d.Syncer.Incremental {
+		if artifact.ChecksumURL == "" {
+			// Artifacts without a checksum, like the SSM installer, can't be skipped
+			// and are replaced on every sync.
+			return artifact.LocalPath
+		}
+		if source, err := url.Parse(artifact.URL); err == nil {
+			if releasePath := strings.TrimPrefix(path.Clean(source.Path), "/"); releasePath != "" && releasePath != "." {
+				return releasePath
+			}
 		}
 	}
-
-	return nil
-}
-
-func (d *Downloader) streamToS3(ctx context.Context, svc *s3.Client, url, s3Key string) error {
-	// Download from source URL
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return errors.Wrap(err, "creating request")
-	}
-
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return errors.Wrap(err, "making request")
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("HTTP %d: %s", resp.StatusCode, resp.Status)
-	}
-
-	// Create S3 Manager uploader
-	uploader := manager.NewUploader(svc)
-
-	// Upload using S3 Manager with public-read ACL
-	_, err = uploader.Upload(ctx, &s3.PutObjectInput{
-		Bucket: awsSDKv2.String(d.S3Bucket),
-		Key:    awsSDKv2.String(s3Key),
-		Body:   resp.Body,
-		ACL:    types.ObjectCannedACLPublicRead,
-	})
-
-	return errors.Wrap(err, "uploading to S3 using manager")
-}
-
-func (d *Downloader) getSSMInstallerURL() (string, error) {
-	// Construct the URL directly using the same logic as SSM source
-	variant, err := d.detectPlatformVariant()
-	if err != nil {
-		return "", err
-	}
-
-	dnsSuffix := d.AwsSource.RegionInfo.DnsSuffix
-	if dnsSuffix == "" {
-		partition := aws.GetPartitionFromRegionFallback(d.Region)
-		dnsSuffix = aws.GetPartitionDNSSuffix(partition)
-	}
-
-	platform := fmt.Sprintf("%s_%s", variant, d.Arch)
-	return fmt.Sprintf("https://amazon-ssm-%s.s3.%s.%s/latest/%s/ssm-setup-cli", d.Region, d.Region, dnsSuffix, platform), nil
-}
-
-func (d *Downloader) detectPlatformVariant() (string, error) {
-	// This is a simplified version - in a real implementation you'd want to detect the actual OS
-	switch d.OS {
-	case "linux":
-		return "linux", nil
-	default:
-		return "", fmt.Errorf("unsupported OS: %s", d.OS)
-	}
+	return fmt.Sprintf("%d/%s", d.SyncTimestamp, artifact.LocalPath)
 }
 
+// generateCustomManifest writes a manifest with the synced URIs of every release and
+// architecture, which aws.GetLatestSourceFromManifest reads for any synced version.
 func (d *Downloader) generateCustomManifest(artifacts []ArtifactInfo) error {
-	dnsSuffix := d.AwsSource.RegionInfo.DnsSuffix
-	if dnsSuffix == "" {
-		partition := aws.GetPartitionFromRegionFallback(d.Region)
-		dnsSuffix = aws.GetPartitionDNSSuffix(partition)
-	}
-
-	// Create base S3 URL
-	baseS3URL := fmt.Sprintf("https://%s.s3.%s.%s/%s/%d",
-		d.S3Bucket, d.Region, dnsSuffix, strings.TrimSuffix(d.S3Prefix, "/"), d.SyncTimestamp)
-
-	// Build artifact list with custom S3 URIs
-	var eksArtifacts []aws.Artifact
+	// Build artifact list with the synced URIs
+	eksArtifacts := map[string][]aws.Artifact{}
 	var iamArtifacts []aws.Artifact
 	var ssmArtifacts []aws.Artifact
 
 	for _, artifact := range artifacts {
-		customURI := fmt.Sprintf("%s/%s", baseS3URL, artifact.LocalPath)
-		customChecksumURI := ""
-		if artifact.ChecksumURL != "" {
-			customChecksumURI = fmt.Sprintf("%s/%s.sha256", baseS3URL, artifact.LocalPath)
-		}
-
 		awsArtifact := aws.Artifact{
 			Name:        artifact.Name,
-			Arch:        d.Arch,
+			Arch:        artifact.Arch,
 			OS:          d.OS,
-			URI:         customURI,
-			ChecksumURI: customChecksumURI,
+			URI:         artifact.Synced.URI,
+			ChecksumURI: artifact.Synced.ChecksumURI,
 		}
 
 		// Categorize artifacts
 		if strings.HasPrefix(artifact.LocalPath, "iam-ra/") {
 			iamArtifacts = append(iamArtifacts, awsArtifact)
 		} else if strings.HasPrefix(artifact.LocalPath, "eks/") {
-			eksArtifacts = append(eksArtifacts, awsArtifact)
+			eksArtifacts[artifact.EksVersion] = append(eksArtifacts[artifact.EksVersion], awsArtifact)
 		} else if strings.HasPrefix(artifact.LocalPath, "ssm/") {
 			ssmArtifacts = append(ssmArtifacts, awsArtifact)
 		}
@@ -380,45 +462,59 @@ func (d *Downloader) generateCustomManifest(artifacts []ArtifactInfo) error {
 		}
 	}
 
+	// Group the patch releases by major.minor version
+	var eksReleases []aws.SupportedEksRelease
+	var versions []string
+	for _, source := range d.AwsSources {
+		versions = append(versions, source.Eks.Version)
+		patchRelease := aws.EksPatchRelease{
+			Version:      source.Eks.Version,
+			PatchVersion: source.Eks.PatchVersion,
+			ReleaseDate:  source.Eks.ReleaseDate,
+			Artifacts:    eksArtifacts[source.Eks.Version],
+		}
+		majorMinor := d.extractMajorMinor(source.Eks.Version)
+		i := slices.IndexFunc(eksReleases, func(release aws.SupportedEksRelease) bool {
+			return release.MajorMinorVersion == majorMinor
+		})
+		if i < 0 {
+			eksReleases = append(eksReleases, aws.SupportedEksRelease{MajorMinorVersion: majorMinor})
+			i = len(eksReleases) - 1
+		}
+		release := &eksReleases[i]
+		release.PatchReleases = append(release.PatchReleases, patchRelease)
+		if release.LatestPatchVersion == "" || semver.Compare("v"+source.Eks.Version, "v"+majorMinor+"."+release.LatestPatchVersion) > 0 {
+			release.LatestPatchVersion = source.Eks.PatchVersion
+		}
+	}
+
+	regionInfo := d.AwsSources[0].RegionInfo
 	manifest := aws.Manifest{
 		RegionConfig: aws.RegionConfig{
 			d.Region: aws.RegionData{
-				EcrAccountID: d.AwsSource.RegionInfo.EcrAccountID,
+				EcrAccountID: regionInfo.EcrAccountID,
 				CredProviders: map[string]bool{
-					"iam-ra": d.AwsSource.RegionInfo.CredProviders["iam-ra"],
-					"ssm":    d.AwsSource.RegionInfo.CredProviders["ssm"],
-				},
-			},
-		},
-		SsmReleases: ssmReleases,
-		SupportedEksReleases: []aws.SupportedEksRelease{
-			{
-				MajorMinorVersion:  d.extractMajorMinor(d.AwsSource.Eks.Version),
-				LatestPatchVersion: d.AwsSource.Eks.PatchVersion,
-				PatchReleases: []aws.EksPatchRelease{
-					{
-						Version:      d.AwsSource.Eks.Version,
-						PatchVersion: d.AwsSource.Eks.PatchVersion,
-						ReleaseDate:  d.AwsSource.Eks.ReleaseDate,
-						Artifacts:    eksArtifacts,
-					},
+					"iam-ra": regionInfo.CredProviders["iam-ra"],
+					"ssm":    regionInfo.CredProviders["ssm"],
 				},
 			},
 		},
+		SsmReleases:          ssmReleases,
+		SupportedEksReleases: eksReleases,
 	}
 
 	// Always add IAM releases if we have IAM artifacts
 	if len(iamArtifacts) > 0 {
 		manifest.IamRolesAnywhereReleases = []aws.IamRolesAnywhereRelease{
 			{
-				Version:   d.AwsSource.Iam.Version,
+				Version:   d.AwsSources[0].Iam.Version,
 				Artifacts: iamArtifacts,
 			},
 		}
 	}
 
 	filename := fmt.Sprintf("manifest-%s-%s-%s-%d.yaml",
-		d.AwsSource.Eks.Version, d.Arch, d.OS, d.SyncTimestamp)
+		strings.Join(versions, "_"), strings.Join(d.Arches, "_"), d.OS, d.SyncTimestamp)
 
 	// Marshal to YAML
 	yamlData, err := yaml.Marshal(manifest)
@@ -431,9 +527,9 @@ func (d *Downloader) generateCustomManifest(artifacts []ArtifactInfo) error {
 		return errors.Wrapf(err, "writing manifest file %s", filename)
 	}
 
-	d.Logger.Info("Generated custom manifest with S3 URIs",
+	d.Logger.Info("Generated custom manifest with the synced URIs",
 		zap.String("filename", filename),
-		zap.String("baseS3URL", baseS3URL))
+		zap.Strings("versions", versions))
 
 	return nil
 }
@@ -40,6 +40,9 @@ const uninstallHelpText = `Examples:
   # Uninstall all components and skip pod-validation and node-validation pre-flight validation
   nodeadm uninstall --skip node-validation,pod-validation
 
+  # Show the daemons, packages and artifacts that would be removed without changing the host
+  nodeadm uninstall --dry-run
+
 Documentation:
   https://docs.aws.amazon.com/eks/latest/userguide/hybrid-nodes-nodeadm.html#_uninstall`
 
@@ -51,6 +54,7 @@ func NewCommand() cli.Command {
 	fc.AdditionalHelpAppend = uninstallHelpText
 	fc.StringSlice(&cmd.skipPhases, "s", "skip", "Phases of uninstall to skip. Allowed values: [pod-validation, node-validation].")
 	fc.Bool(&cmd.force, "f", "force", forceWarningText)
+	fc.Bool(&cmd.dryRun, "", "dry-run", "Print the changes uninstall would make to the host without applying them.")
 	cmd.flaggy = fc
 
 	return &cmd
@@ -60,6 +64,7 @@ type command struct {
 	flaggy     *flaggy.Subcommand
 	skipPhases []string
 	force      bool
+	dryRun     bool
 }
 
 func (c *command) Flaggy() *flaggy.Subcommand {
@@ -134,6 +139,19 @@ func (c *command) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
 		CNIUninstall:   cni.Uninstall,
 	}
 
+	if c.dryRun {
+		plan, err := uninstaller.Plan(ctx)
+		if err != nil {
+			return err
+		}
+		if c.force {
+			for _, dir := range cleanup.New(log).Directories() {
+				plan.Add(flows.PlanStepFile, "remove", dir)
+			}
+		}
+		return plan.Write(os.Stdout)
+	}
+
 	if err := uninstaller.Run(ctx); err != nil {
 		return err
 	}
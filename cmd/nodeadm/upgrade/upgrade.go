@@ -13,15 +13,20 @@ import (
 
 	initCmd "github.com/aws/eks-hybrid/cmd/nodeadm/init"
 	"github.com/aws/eks-hybrid/internal/aws"
+	"github.com/aws/eks-hybrid/internal/bundle"
 	"github.com/aws/eks-hybrid/internal/cli"
+	"github.com/aws/eks-hybrid/internal/configprovider"
 	"github.com/aws/eks-hybrid/internal/creds"
 	"github.com/aws/eks-hybrid/internal/daemon"
 	"github.com/aws/eks-hybrid/internal/flows"
 	"github.com/aws/eks-hybrid/internal/kubelet"
 	"github.com/aws/eks-hybrid/internal/logger"
 	"github.com/aws/eks-hybrid/internal/node"
+	"github.com/aws/eks-hybrid/internal/nodeprovider"
 	"github.com/aws/eks-hybrid/internal/packagemanager"
+	"github.com/aws/eks-hybrid/internal/ssm"
 	"github.com/aws/eks-hybrid/internal/tracker"
+	"github.com/aws/eks-hybrid/internal/validation"
 )
 
 const (
@@ -52,6 +57,12 @@ const upgradeHelpText = `Examples:
   # Upgrade all components with a custom timeout
   nodeadm upgrade 1.31 --config-source file:///root/nodeConfig.yaml --timeout 1h23s
 
+  # Show the packages, artifacts, files and daemon operations the upgrade would apply without changing the host
+  nodeadm upgrade 1.31 --config-source file:///root/nodeConfig.yaml --dry-run
+
+  # Upgrade all components from an offline install bundle created with nodeadm bundle create
+  nodeadm upgrade 1.31 --config-source file:///root/nodeConfig.yaml --bundle bundle.tar.zst
+
 Documentation:
   https://docs.aws.amazon.com/eks/latest/userguide/hybrid-nodes-nodeadm.html#_upgrade`
 
@@ -64,11 +75,18 @@ func NewUpgradeCommand() cli.Command {
 	fc.Description = "Upgrade components installed using the install sub-command"
 	fc.AdditionalHelpAppend = upgradeHelpText
 	fc.AddPositionalValue(&cmd.kubernetesVersion, "KUBERNETES_VERSION", 1, true, "The major[.minor[.patch]] version of Kubernetes to install.")
-	fc.String(&cmd.configSource, "c", "config-source", "Source of node configuration. The format is a URI with supported schemes: [file, imds].")
+	fc.String(&cmd.configSource, "c", "config-source", "Source of node configuration. The format is a URI with supported schemes: [file, imds, s3, https].")
+	fc.String(&cmd.configCABundle, "", "config-source-ca-bundle", "Path to a PEM encoded CA bundle used to verify https config sources.")
 	fc.StringSlice(&cmd.skipPhases, "s", "skip", fmt.Sprintf("Phases of the upgrade to skip. Allowed values: [%s].", strings.Join(upgradePhases(), ", ")))
 	fc.String(&cmd.manifestOverride, "m", "manifest-override", "URI to a manifest file containing custom artifact URLs. Supports file:// for local files and https:// for remote files.")
+	fc.String(&cmd.bundle, "b", "bundle", "Path to an offline install bundle created with nodeadm bundle create. All artifacts are read from the bundle and OS packages are skipped.")
 	fc.Bool(&cmd.privateMode, "", "private-mode", "Enable private upgrade mode (skips OS packages, requires --manifest-override).")
 	fc.Duration(&cmd.timeout, "t", "timeout", "Maximum upgrade command duration. Input follows duration format. Example: 1h23s")
+	fc.Bool(&cmd.dryRun, "", "dry-run", "Print the changes the upgrade would make to the host without applying them.")
+	cmd.validationPolicy = cli.RegisterValidationPolicyFlags(fc)
+	cmd.download = cli.RegisterDownloadFlags(fc)
+	cmd.signature = cli.RegisterSignatureFlags(fc)
+	cmd.pin = cli.RegisterPinFlags(fc)
 	cmd.flaggy = fc
 	return &cmd
 }
@@ -76,11 +94,18 @@ func NewUpgradeCommand() cli.Command {
 type command struct {
 	flaggy            *flaggy.Subcommand
 	configSource      string
+	configCABundle    string
 	skipPhases        []string
 	kubernetesVersion string
 	manifestOverride  string
+	bundle            string
 	privateMode       bool
+	dryRun            bool
 	timeout           time.Duration
+	validationPolicy  *cli.ValidationPolicyFlags
+	download          *cli.DownloadFlags
+	signature         *cli.SignatureFlags
+	pin               *cli.PinFlags
 }
 
 func (c *command) Flaggy() *flaggy.Subcommand {
@@ -100,14 +125,42 @@ func (c *command) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
 	}
 
 	if c.configSource == "" {
-		flaggy.ShowHelpAndExit("--config-source is a required flag. The format is a URI with supported schemes: [file, imds]." +
+		flaggy.ShowHelpAndExit("--config-source is a required flag. The format is a URI with supported schemes: [file, imds, s3, https]." +
 			" For example on hybrid nodes --config-source file://nodeConfig.yaml")
 	}
 
+	var ssmInstallerOpts []ssm.SSMInstallerOption
+	if c.bundle != "" {
+		if c.manifestOverride != "" {
+			return fmt.Errorf("--bundle and --manifest-override can't be used together")
+		}
+		bundleOpts, err := c.signature.BundleOpts()
+		if err != nil {
+			return err
+		}
+		log.Info("Extracting bundle", zap.String("bundle", c.bundle))
+		b, err := bundle.Open(c.bundle, bundleOpts...)
+		if err != nil {
+			return err
+		}
+		defer b.Close()
+		c.manifestOverride = b.ManifestURI()
+		c.privateMode = true
+		ssmInstallerOpts = b.SSMInstallerOpts()
+	}
+
 	if c.privateMode && c.manifestOverride == "" {
 		return fmt.Errorf("--private-mode requires --manifest-override to be specified")
 	}
 
+	validationOpts, err := c.validationPolicy.RunnerOpts(node.ValidationNames())
+	if err != nil {
+		return err
+	}
+	// Validation results are included in the node status report.
+	validations := validation.NewReport()
+	validationOpts = append(validationOpts, validation.WithInformers(validations))
+
 	log.Info("Loading installed components")
 	installed, err := tracker.GetInstalledArtifacts()
 	if err != nil && os.IsNotExist(err) {
@@ -127,8 +180,15 @@ func (c *command) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
 		}
 	}
 
-	log.Info("Loading configuration...", zap.String("configSource", c.configSource))
-	nodeProvider, err := node.NewNodeProvider(c.configSource, c.skipPhases, log)
+	// Dry runs send daemon operations to the recorder instead of systemd.
+	recorder := daemon.NewRecordingDaemonManager(nil)
+	var nodeProvider nodeprovider.NodeProvider
+	if c.dryRun {
+		nodeProvider, err = c.newRecordingNodeProvider(ctx, recorder, validationOpts, log)
+	} else {
+		log.Info("Loading configuration...", zap.String("configSource", c.configSource))
+		nodeProvider, err = node.NewNodeProvider(c.configSource, c.skipPhases, validationOpts, log, configprovider.WithCABundle(c.configCABundle))
+	}
 	if err != nil {
 		return err
 	}
@@ -157,11 +217,31 @@ func (c *command) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
 		return fmt.Errorf("upgrade does not support changing credential providers. Please uninstall and install with new credential provider")
 	}
 
+	var manifestOpts []aws.ManifestOption
+	if c.bundle == "" {
+		// Bundles are verified when they are opened, their manifest is generated
+		// when the bundle is created so it has no signature.
+		manifestOpts, err = c.signature.ManifestOpts()
+		if err != nil {
+			return err
+		}
+	}
+	pinOpts, err := c.pin.ManifestOpts()
+	if err != nil {
+		return err
+	}
+	manifestOpts = append(manifestOpts, pinOpts...)
+	fetchers, err := c.download.Fetchers(region)
+	if err != nil {
+		return err
+	}
+	manifestOpts = append(manifestOpts, aws.WithFetchers(fetchers))
+
 	var awsSource aws.Source
 	// Use manifest override if provided, otherwise use default AWS source
 	if c.manifestOverride != "" {
 		log.Info("Using manifest override", zap.String("manifest", c.manifestOverride))
-		awsSource, err = aws.GetLatestSourceFromManifest(ctx, c.kubernetesVersion, region, c.manifestOverride)
+		awsSource, err = aws.GetLatestSourceFromManifest(ctx, c.kubernetesVersion, region, c.manifestOverride, manifestOpts...)
 		if err != nil {
 			return err
 		}
@@ -169,12 +249,15 @@ func (c *command) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
 	} else {
 		log.Info("Validating Kubernetes version", zap.Reflect("kubernetes version", c.kubernetesVersion))
 		// Create a Source for all AWS managed artifacts.
-		awsSource, err = aws.GetLatestSource(ctx, c.kubernetesVersion, region)
+		awsSource, err = aws.GetLatestSource(ctx, c.kubernetesVersion, region, manifestOpts...)
 		if err != nil {
 			return err
 		}
 		log.Info("Using Kubernetes version", zap.Reflect("kubernetes version", awsSource.Eks.Version))
 	}
+	if c.bundle == "" {
+		awsSource.Downloader = c.download.Downloader(log, fetchers)
+	}
 
 	log.Info("Creating daemon manager...")
 	daemonManager, err := daemon.NewDaemonManager()
@@ -228,7 +311,30 @@ func (c *command) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
 		SkipPhases:         c.skipPhases,
 		Logger:             log,
 		PrivateMode:        c.privateMode,
+		SsmInstallerOpts:   ssmInstallerOpts,
+		Validations:        validations,
+	}
+
+	if c.dryRun {
+		plan, err := upgrader.Plan(ctx, recorder)
+		if err != nil {
+			return err
+		}
+		return plan.Write(os.Stdout)
 	}
 
 	return upgrader.Run(ctx)
 }
+
+func (c *command) newRecordingNodeProvider(ctx context.Context, recorder *daemon.RecordingDaemonManager, validationOpts []validation.RunnerOpt, log *zap.Logger) (nodeprovider.NodeProvider, error) {
+	log.Info("Loading configuration...", zap.String("configSource", c.configSource))
+	provider, err := configprovider.BuildConfigProvider(c.configSource, configprovider.WithCABundle(c.configCABundle))
+	if err != nil {
+		return nil, err
+	}
+	nodeConfig, err := provider.Provide()
+	if err != nil {
+		return nil, err
+	}
+	return node.NewRecordingNodeProvider(ctx, nodeConfig, c.skipPhases, validationOpts, recorder, log)
+}
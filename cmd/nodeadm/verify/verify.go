@@ -0,0 +1,106 @@
+package verify
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/integrii/flaggy"
+	"go.uber.org/zap"
+
+	"github.com/aws/eks-hybrid/internal/cli"
+	"github.com/aws/eks-hybrid/internal/errors"
+	"github.com/aws/eks-hybrid/internal/tracker"
+)
+
+const verifyHelpText = `Examples:
+  # Verify the binaries installed by nodeadm haven't changed
+  nodeadm verify
+
+  # Print the verification results as JSON
+  nodeadm verify --output json
+
+Documentation:
+  https://docs.aws.amazon.com/eks/latest/userguide/hybrid-nodes-nodeadm.html`
+
+const (
+	outputText = "text"
+	outputJSON = "json"
+)
+
+func NewCommand() cli.Command {
+	cmd := command{}
+
+	fc := flaggy.NewSubcommand("verify")
+	fc.Description = "Verify the checksums of the binaries installed by nodeadm"
+	fc.AdditionalHelpAppend = verifyHelpText
+	fc.String(&cmd.output, "o", "output", "Output format. One of: [text, json].")
+	cmd.flaggy = fc
+
+	return &cmd
+}
+
+type command struct {
+	flaggy *flaggy.Subcommand
+	output string
+}
+
+func (c *command) Flaggy() *flaggy.Subcommand {
+	return c.flaggy
+}
+
+func (c *command) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
+	if c.output != "" && c.output != outputText && c.output != outputJSON {
+		return fmt.Errorf("invalid --output %q, must be one of: [%s, %s]", c.output, outputText, outputJSON)
+	}
+
+	installed, err := tracker.GetInstalledArtifacts()
+	if err != nil {
+		return fmt.Errorf("reading installed artifacts: %w", err)
+	}
+
+	results, err := installed.Verify()
+	if err != nil {
+		return err
+	}
+
+	if c.output == outputJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(results)
+	} else {
+		err = writeResults(os.Stdout, results)
+	}
+	if err != nil {
+		return err
+	}
+
+	drifted := 0
+	for _, result := range results {
+		if result.State == tracker.FileModified || result.State == tracker.FileMissing {
+			drifted++
+		}
+	}
+	if drifted > 0 {
+		return errors.NewSilent(fmt.Errorf("%d installed files don't match the tracker", drifted))
+	}
+	return nil
+}
+
+func writeResults(w io.Writer, results []tracker.FileVerification) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ARTIFACT\tVERSION\tPATH\tSTATE")
+	for _, result := range results {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", result.Artifact, valueOrNone(result.Version), valueOrNone(result.Path), result.State)
+	}
+	return tw.Flush()
+}
+
+func valueOrNone(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
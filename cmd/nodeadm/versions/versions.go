@@ -0,0 +1,135 @@
+package versions
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/integrii/flaggy"
+	"go.uber.org/zap"
+	"golang.org/x/mod/semver"
+
+	"github.com/aws/eks-hybrid/internal/aws"
+	"github.com/aws/eks-hybrid/internal/cli"
+	"github.com/aws/eks-hybrid/internal/logger"
+)
+
+const versionsHelpText = `Examples:
+  # List the Kubernetes versions available for hybrid nodes
+  nodeadm versions
+
+  # List the Kubernetes versions in a private mirror's manifest, as JSON
+  nodeadm versions --manifest-override https://mirror.example.com/manifest.yaml --output json
+
+Documentation:
+  https://docs.aws.amazon.com/eks/latest/userguide/hybrid-nodes-nodeadm.html`
+
+const (
+	outputText = "text"
+	outputJSON = "json"
+)
+
+func NewCommand() cli.Command {
+	cmd := command{}
+
+	fc := flaggy.NewSubcommand("versions")
+	fc.Description = "List the Kubernetes versions and patch releases in the release manifest"
+	fc.AdditionalHelpAppend = versionsHelpText
+	fc.String(&cmd.manifestOverride, "m", "manifest-override", "URI to a manifest file to read instead of the default release manifest. Supports file://, https://, s3:// and oci:// URIs.")
+	fc.String(&cmd.output, "o", "output", "Output format. One of: [text, json].")
+	cmd.manifest = cli.RegisterManifestFlags(fc)
+	cmd.flaggy = fc
+
+	return &cmd
+}
+
+type command struct {
+	flaggy           *flaggy.Subcommand
+	manifestOverride string
+	output           string
+	manifest         *cli.ManifestFlags
+}
+
+func (c *command) Flaggy() *flaggy.Subcommand {
+	return c.flaggy
+}
+
+type release struct {
+	Version            string         `json:"version"`
+	LatestPatchVersion string         `json:"latestPatchVersion"`
+	PatchReleases      []patchRelease `json:"patchReleases"`
+}
+
+type patchRelease struct {
+	Version     string `json:"version"`
+	ReleaseDate string `json:"releaseDate"`
+}
+
+func (c *command) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
+	ctx := logger.NewContext(context.Background(), log)
+
+	if c.output != "" && c.output != outputText && c.output != outputJSON {
+		return fmt.Errorf("invalid --output %q, must be one of: [%s, %s]", c.output, outputText, outputJSON)
+	}
+
+	manifest, err := c.manifest.GetManifest(ctx, c.manifestOverride)
+	if err != nil {
+		return err
+	}
+
+	releases := newReleases(manifest)
+	if c.output == outputJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(releases)
+	}
+	return writeReleases(os.Stdout, releases)
+}
+
+// newReleases returns the EKS releases in the manifest, newest first, with their patch
+// releases newest first.
+func newReleases(manifest *aws.Manifest) []release {
+	var releases []release
+	for _, supported := range manifest.SupportedEksReleases {
+		r := release{
+			Version:            supported.MajorMinorVersion,
+			LatestPatchVersion: supported.MajorMinorVersion + "." + supported.LatestPatchVersion,
+		}
+		for _, patch := range supported.PatchReleases {
+			r.PatchReleases = append(r.PatchReleases, patchRelease{
+				Version:     supported.MajorMinorVersion + "." + patch.PatchVersion,
+				ReleaseDate: patch.ReleaseDate,
+			})
+		}
+		slices.SortFunc(r.PatchReleases, func(a, b patchRelease) int {
+			return semver.Compare("v"+b.Version, "v"+a.Version)
+		})
+		releases = append(releases, r)
+	}
+	slices.SortFunc(releases, func(a, b release) int {
+		return semver.Compare("v"+b.Version, "v"+a.Version)
+	})
+	return releases
+}
+
+func writeReleases(w io.Writer, releases []release) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "VERSION\tLATEST\tRELEASE DATE\tPATCH RELEASES")
+	for _, r := range releases {
+		var releaseDate string
+		var patches []string
+		for _, patch := range r.PatchReleases {
+			if patch.Version == r.LatestPatchVersion {
+				releaseDate = patch.ReleaseDate
+			}
+			patches = append(patches, patch.Version)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", r.Version, r.LatestPatchVersion, releaseDate, strings.Join(patches, ", "))
+	}
+	return tw.Flush()
+}
@@ -12,13 +12,17 @@ import (
 )
 
 // DecodeNodeConfig unmarshals the given data into an internal NodeConfig object.
-// The data may be JSON or YAML.
+// The data may be JSON or YAML. `${env:NAME}` and `${file:/path}` references are
+// resolved before decoding.
 func DecodeNodeConfig(data []byte) (*internalapi.NodeConfig, error) {
-	scheme := runtime.NewScheme()
-	err := localSchemeBuilder.AddToScheme(scheme)
+	data, err := interpolate(data)
 	if err != nil {
 		return nil, err
 	}
+	scheme := runtime.NewScheme()
+	if err := localSchemeBuilder.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
 	codecs := serializer.NewCodecFactory(scheme)
 	obj, gvk, err := codecs.UniversalDecoder().Decode(data, nil, nil)
 	if err != nil {
@@ -38,7 +42,12 @@ func DecodeNodeConfig(data []byte) (*internalapi.NodeConfig, error) {
 
 // DecodeStrictNodeConfig unmarshals the given data into an internal NodeConfig object.
 // It attempts a struct unmarshalling. Will throw an error if unknown fields are present.
+// `${env:NAME}` and `${file:/path}` references are resolved before decoding.
 func DecodeStrictNodeConfig(data []byte) (*internalapi.NodeConfig, error) {
+	data, err := interpolate(data)
+	if err != nil {
+		return nil, err
+	}
 	var obj internalapi.NodeConfig
 	if err := yaml.UnmarshalStrict(data, &obj); err != nil {
 		return nil, err
@@ -0,0 +1,163 @@
+package bridge
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-hybrid/internal/logger"
+)
+
+const (
+	envReferenceSource  = "env"
+	fileReferenceSource = "file"
+)
+
+// referencePattern matches `${env:NAME}` and `${file:/path/to/file}` references.
+var referencePattern = regexp.MustCompile(`\$\{(env|file):([^}]+)\}`)
+
+// secretFieldPatterns match the paths of the fields holding secrets. Values resolved
+// for these fields are never logged.
+var secretFieldPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`^spec\.hybrid\.ssm\.activationCode$`),
+	regexp.MustCompile(`^spec\.containerd\.registries\..+\.auth\.(password|token)$`),
+}
+
+// UnresolvedReference is a reference in a NodeConfig field that could not be resolved.
+type UnresolvedReference struct {
+	// Path is the path of the field holding the reference, e.g. spec.hybrid.ssm.activationCode.
+	Path string
+	// Reference is the raw reference, e.g. ${env:SSM_ACTIVATION_CODE}.
+	Reference string
+	Err       error
+}
+
+// UnresolvedReferencesError is returned when decoding a NodeConfig with references
+// that can't be resolved.
+type UnresolvedReferencesError struct {
+	References []UnresolvedReference
+}
+
+func (e *UnresolvedReferencesError) Error() string {
+	messages := make([]string, 0, len(e.References))
+	for _, ref := range e.References {
+		messages = append(messages, fmt.Sprintf("%s: %s: %s", ref.Path, ref.Reference, ref.Err))
+	}
+	return fmt.Sprintf("unresolved references in NodeConfig: [%s]", strings.Join(messages, ", "))
+}
+
+// interpolate resolves `${env:NAME}` and `${file:/path}` references in every string
+// value of the given document. Values resolved for secret fields are registered with
+// the logger so they are never written to the logs. If the document doesn't contain
+// references, it's returned unchanged.
+func interpolate(data []byte) ([]byte, error) {
+	if !referencePattern.Match(data) {
+		return data, nil
+	}
+
+	jsonData, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(jsonData))
+	// Keep numbers as they are, we only want to modify strings.
+	decoder.UseNumber()
+	var document interface{}
+	if err := decoder.Decode(&document); err != nil {
+		return nil, err
+	}
+
+	var unresolved []UnresolvedReference
+	document = interpolateValue(document, "", &unresolved)
+	if len(unresolved) > 0 {
+		return nil, &UnresolvedReferencesError{References: unresolved}
+	}
+
+	return json.Marshal(document)
+}
+
+func interpolateValue(value interface{}, path string, unresolved *[]UnresolvedReference) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		// Sort keys so unresolved references are reported in a stable order.
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			v[key] = interpolateValue(v[key], joinFieldPath(path, key), unresolved)
+		}
+		return v
+	case []interface{}:
+		for i := range v {
+			v[i] = interpolateValue(v[i], fmt.Sprintf("%s[%d]", path, i), unresolved)
+		}
+		return v
+	case string:
+		return interpolateString(v, path, unresolved)
+	default:
+		return v
+	}
+}
+
+func interpolateString(value, path string, unresolved *[]UnresolvedReference) string {
+	return referencePattern.ReplaceAllStringFunc(value, func(reference string) string {
+		match := referencePattern.FindStringSubmatch(reference)
+		resolved, err := resolveReference(match[1], match[2])
+		if err != nil {
+			*unresolved = append(*unresolved, UnresolvedReference{
+				Path:      path,
+				Reference: reference,
+				Err:       err,
+			})
+			return reference
+		}
+		if isSecretField(path) {
+			logger.RegisterSecret(resolved)
+		}
+		return resolved
+	})
+}
+
+func resolveReference(source, name string) (string, error) {
+	switch source {
+	case envReferenceSource:
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			return "", fmt.Errorf("environment variable %s is not set", name)
+		}
+		return value, nil
+	case fileReferenceSource:
+		content, err := os.ReadFile(name)
+		if err != nil {
+			return "", err
+		}
+		// Secret files are usually written with a trailing new line.
+		return strings.TrimRight(string(content), "\r\n"), nil
+	default:
+		return "", fmt.Errorf("unsupported reference source %s", source)
+	}
+}
+
+func isSecretField(path string) bool {
+	for _, pattern := range secretFieldPatterns {
+		if pattern.MatchString(path) {
+			return true
+		}
+	}
+	return false
+}
+
+func joinFieldPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
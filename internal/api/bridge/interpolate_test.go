@@ -0,0 +1,100 @@
+package bridge
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-hybrid/internal/logger"
+)
+
+func TestDecodeNodeConfigWithReferences(t *testing.T) {
+	g := NewWithT(t)
+	secretPath := filepath.Join(t.TempDir(), "ssm-code")
+	g.Expect(os.WriteFile(secretPath, []byte("my-activation-code-12345\n"), 0o600)).To(Succeed())
+	t.Setenv("CLUSTER_NAME", "my-cluster")
+	t.Setenv("SITE", "dc-1")
+
+	data := []byte(`
+apiVersion: node.eks.aws/v1alpha1
+kind: NodeConfig
+spec:
+  cluster:
+    name: ${env:CLUSTER_NAME}
+    region: us-west-2
+  kubelet:
+    config:
+      maxPods: 110
+    flags:
+      - --node-labels=site=${env:SITE}
+  hybrid:
+    ssm:
+      activationCode: ${file:` + secretPath + `}
+      activationId: id
+`)
+
+	for name, decode := range map[string]func([]byte) error{
+		"DecodeNodeConfig": func(data []byte) error {
+			config, err := DecodeNodeConfig(data)
+			if err != nil {
+				return err
+			}
+			g.Expect(config.Spec.Cluster.Name).To(Equal("my-cluster"))
+			g.Expect(config.Spec.Kubelet.Flags).To(ConsistOf("--node-labels=site=dc-1"))
+			g.Expect(config.Spec.Hybrid.SSM.ActivationCode).To(Equal("my-activation-code-12345"))
+			g.Expect(string(config.Spec.Kubelet.Config["maxPods"].Raw)).To(Equal("110"))
+			return nil
+		},
+		"DecodeStrictNodeConfig": func(data []byte) error {
+			config, err := DecodeStrictNodeConfig(data)
+			if err != nil {
+				return err
+			}
+			g.Expect(config.Spec.Cluster.Name).To(Equal("my-cluster"))
+			g.Expect(config.Spec.Hybrid.SSM.ActivationCode).To(Equal("my-activation-code-12345"))
+			return nil
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			g.Expect(decode(data)).To(Succeed())
+		})
+	}
+
+	g.Expect(logger.Redact("code my-activation-code-12345")).To(Equal("code [REDACTED]"))
+	// Values of fields that aren't secrets are still logged.
+	g.Expect(logger.Redact("cluster my-cluster in dc-1")).To(Equal("cluster my-cluster in dc-1"))
+}
+
+func TestDecodeNodeConfigUnresolvedReferences(t *testing.T) {
+	g := NewWithT(t)
+	data := []byte(`
+apiVersion: node.eks.aws/v1alpha1
+kind: NodeConfig
+spec:
+  cluster:
+    name: ${env:NODEADM_TEST_MISSING_ENV}
+  hybrid:
+    ssm:
+      activationCode: ${file:/nodeadm/test/missing}
+`)
+
+	_, err := DecodeStrictNodeConfig(data)
+	unresolved := &UnresolvedReferencesError{}
+	g.Expect(errors.As(err, &unresolved)).To(BeTrue())
+	g.Expect(unresolved.References).To(HaveLen(2))
+	g.Expect(unresolved.References[0].Path).To(Equal("spec.cluster.name"))
+	g.Expect(unresolved.References[0].Reference).To(Equal("${env:NODEADM_TEST_MISSING_ENV}"))
+	g.Expect(unresolved.References[1].Path).To(Equal("spec.hybrid.ssm.activationCode"))
+	g.Expect(unresolved.References[1].Err).To(MatchError(os.ErrNotExist))
+}
+
+func TestDecodeNodeConfigWithoutReferences(t *testing.T) {
+	g := NewWithT(t)
+	data := []byte("apiVersion: node.eks.aws/v1alpha1\nkind: NodeConfig\nspec:\n  cluster:\n    name: $cluster\n")
+	interpolated, err := interpolate(data)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(interpolated).To(Equal(data))
+}
@@ -122,6 +122,96 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.PodNetworkOptions)(nil), (*api.PodNetworkOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_PodNetworkOptions_To_api_PodNetworkOptions(a.(*v1alpha1.PodNetworkOptions), b.(*api.PodNetworkOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.PodNetworkOptions)(nil), (*v1alpha1.PodNetworkOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_PodNetworkOptions_To_v1alpha1_PodNetworkOptions(a.(*api.PodNetworkOptions), b.(*v1alpha1.PodNetworkOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.RegistryAuth)(nil), (*api.RegistryAuth)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_RegistryAuth_To_api_RegistryAuth(a.(*v1alpha1.RegistryAuth), b.(*api.RegistryAuth), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.RegistryAuth)(nil), (*v1alpha1.RegistryAuth)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_RegistryAuth_To_v1alpha1_RegistryAuth(a.(*api.RegistryAuth), b.(*v1alpha1.RegistryAuth), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.RegistryMirror)(nil), (*api.RegistryMirror)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_RegistryMirror_To_api_RegistryMirror(a.(*v1alpha1.RegistryMirror), b.(*api.RegistryMirror), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.RegistryMirror)(nil), (*v1alpha1.RegistryMirror)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_RegistryMirror_To_v1alpha1_RegistryMirror(a.(*api.RegistryMirror), b.(*v1alpha1.RegistryMirror), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.RegistryOptions)(nil), (*api.RegistryOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_RegistryOptions_To_api_RegistryOptions(a.(*v1alpha1.RegistryOptions), b.(*api.RegistryOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.RegistryOptions)(nil), (*v1alpha1.RegistryOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_RegistryOptions_To_v1alpha1_RegistryOptions(a.(*api.RegistryOptions), b.(*v1alpha1.RegistryOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.RegistryTLS)(nil), (*api.RegistryTLS)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_RegistryTLS_To_api_RegistryTLS(a.(*v1alpha1.RegistryTLS), b.(*api.RegistryTLS), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.RegistryTLS)(nil), (*v1alpha1.RegistryTLS)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_RegistryTLS_To_v1alpha1_RegistryTLS(a.(*api.RegistryTLS), b.(*v1alpha1.RegistryTLS), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.ReservationPolicy)(nil), (*api.ReservationPolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ReservationPolicy_To_api_ReservationPolicy(a.(*v1alpha1.ReservationPolicy), b.(*api.ReservationPolicy), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.ReservationPolicy)(nil), (*v1alpha1.ReservationPolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_ReservationPolicy_To_v1alpha1_ReservationPolicy(a.(*api.ReservationPolicy), b.(*v1alpha1.ReservationPolicy), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.ReservationTier)(nil), (*api.ReservationTier)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ReservationTier_To_api_ReservationTier(a.(*v1alpha1.ReservationTier), b.(*api.ReservationTier), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.ReservationTier)(nil), (*v1alpha1.ReservationTier)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_ReservationTier_To_v1alpha1_ReservationTier(a.(*api.ReservationTier), b.(*v1alpha1.ReservationTier), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.ResourceReservation)(nil), (*api.ResourceReservation)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ResourceReservation_To_api_ResourceReservation(a.(*v1alpha1.ResourceReservation), b.(*api.ResourceReservation), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.ResourceReservation)(nil), (*v1alpha1.ResourceReservation)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_ResourceReservation_To_v1alpha1_ResourceReservation(a.(*api.ResourceReservation), b.(*v1alpha1.ResourceReservation), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.RuntimeOptions)(nil), (*api.RuntimeOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_RuntimeOptions_To_api_RuntimeOptions(a.(*v1alpha1.RuntimeOptions), b.(*api.RuntimeOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.RuntimeOptions)(nil), (*v1alpha1.RuntimeOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_RuntimeOptions_To_v1alpha1_RuntimeOptions(a.(*api.RuntimeOptions), b.(*v1alpha1.RuntimeOptions), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1alpha1.SSM)(nil), (*api.SSM)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_SSM_To_api_SSM(a.(*v1alpha1.SSM), b.(*api.SSM), scope)
 	}); err != nil {
@@ -169,6 +259,8 @@ func Convert_api_ClusterDetails_To_v1alpha1_ClusterDetails(in *api.ClusterDetail
 
 func autoConvert_v1alpha1_ContainerdOptions_To_api_ContainerdOptions(in *v1alpha1.ContainerdOptions, out *api.ContainerdOptions, s conversion.Scope) error {
 	out.Config = in.Config
+	out.Registries = *(*map[string]api.RegistryOptions)(unsafe.Pointer(&in.Registries))
+	out.Runtimes = *(*map[string]api.RuntimeOptions)(unsafe.Pointer(&in.Runtimes))
 	return nil
 }
 
@@ -179,6 +271,8 @@ func Convert_v1alpha1_ContainerdOptions_To_api_ContainerdOptions(in *v1alpha1.Co
 
 func autoConvert_api_ContainerdOptions_To_v1alpha1_ContainerdOptions(in *api.ContainerdOptions, out *v1alpha1.ContainerdOptions, s conversion.Scope) error {
 	out.Config = in.Config
+	out.Registries = *(*map[string]v1alpha1.RegistryOptions)(unsafe.Pointer(&in.Registries))
+	out.Runtimes = *(*map[string]v1alpha1.RuntimeOptions)(unsafe.Pointer(&in.Runtimes))
 	return nil
 }
 
@@ -191,6 +285,7 @@ func autoConvert_v1alpha1_HybridOptions_To_api_HybridOptions(in *v1alpha1.Hybrid
 	out.EnableCredentialsFile = in.EnableCredentialsFile
 	out.IAMRolesAnywhere = (*api.IAMRolesAnywhere)(unsafe.Pointer(in.IAMRolesAnywhere))
 	out.SSM = (*api.SSM)(unsafe.Pointer(in.SSM))
+	out.PodNetwork = (*api.PodNetworkOptions)(unsafe.Pointer(in.PodNetwork))
 	return nil
 }
 
@@ -203,6 +298,7 @@ func autoConvert_api_HybridOptions_To_v1alpha1_HybridOptions(in *api.HybridOptio
 	out.EnableCredentialsFile = in.EnableCredentialsFile
 	out.IAMRolesAnywhere = (*v1alpha1.IAMRolesAnywhere)(unsafe.Pointer(in.IAMRolesAnywhere))
 	out.SSM = (*v1alpha1.SSM)(unsafe.Pointer(in.SSM))
+	out.PodNetwork = (*v1alpha1.PodNetworkOptions)(unsafe.Pointer(in.PodNetwork))
 	return nil
 }
 
@@ -270,6 +366,7 @@ func Convert_api_InstanceOptions_To_v1alpha1_InstanceOptions(in *api.InstanceOpt
 func autoConvert_v1alpha1_KubeletOptions_To_api_KubeletOptions(in *v1alpha1.KubeletOptions, out *api.KubeletOptions, s conversion.Scope) error {
 	out.Config = *(*api.InlineDocument)(unsafe.Pointer(&in.Config))
 	out.Flags = *(*[]string)(unsafe.Pointer(&in.Flags))
+	out.Reservation = (*api.ReservationPolicy)(unsafe.Pointer(in.Reservation))
 	return nil
 }
 
@@ -281,6 +378,7 @@ func Convert_v1alpha1_KubeletOptions_To_api_KubeletOptions(in *v1alpha1.KubeletO
 func autoConvert_api_KubeletOptions_To_v1alpha1_KubeletOptions(in *api.KubeletOptions, out *v1alpha1.KubeletOptions, s conversion.Scope) error {
 	out.Config = *(*map[string]runtime.RawExtension)(unsafe.Pointer(&in.Config))
 	out.Flags = *(*[]string)(unsafe.Pointer(&in.Flags))
+	out.Reservation = (*v1alpha1.ReservationPolicy)(unsafe.Pointer(in.Reservation))
 	return nil
 }
 
@@ -422,6 +520,246 @@ func Convert_api_NodeConfigSpec_To_v1alpha1_NodeConfigSpec(in *api.NodeConfigSpe
 	return autoConvert_api_NodeConfigSpec_To_v1alpha1_NodeConfigSpec(in, out, s)
 }
 
+func autoConvert_v1alpha1_PodNetworkOptions_To_api_PodNetworkOptions(in *v1alpha1.PodNetworkOptions, out *api.PodNetworkOptions, s conversion.Scope) error {
+	out.CNI = api.CNIType(in.CNI)
+	out.NodeCIDRMaskSize = in.NodeCIDRMaskSize
+	return nil
+}
+
+// Convert_v1alpha1_PodNetworkOptions_To_api_PodNetworkOptions is an autogenerated conversion function.
+func Convert_v1alpha1_PodNetworkOptions_To_api_PodNetworkOptions(in *v1alpha1.PodNetworkOptions, out *api.PodNetworkOptions, s conversion.Scope) error {
+	return autoConvert_v1alpha1_PodNetworkOptions_To_api_PodNetworkOptions(in, out, s)
+}
+
+func autoConvert_api_PodNetworkOptions_To_v1alpha1_PodNetworkOptions(in *api.PodNetworkOptions, out *v1alpha1.PodNetworkOptions, s conversion.Scope) error {
+	out.CNI = v1alpha1.CNIType(in.CNI)
+	out.NodeCIDRMaskSize = in.NodeCIDRMaskSize
+	return nil
+}
+
+// Convert_api_PodNetworkOptions_To_v1alpha1_PodNetworkOptions is an autogenerated conversion function.
+func Convert_api_PodNetworkOptions_To_v1alpha1_PodNetworkOptions(in *api.PodNetworkOptions, out *v1alpha1.PodNetworkOptions, s conversion.Scope) error {
+	return autoConvert_api_PodNetworkOptions_To_v1alpha1_PodNetworkOptions(in, out, s)
+}
+
+func autoConvert_v1alpha1_RegistryAuth_To_api_RegistryAuth(in *v1alpha1.RegistryAuth, out *api.RegistryAuth, s conversion.Scope) error {
+	out.Username = in.Username
+	out.Password = in.Password
+	out.Token = in.Token
+	return nil
+}
+
+// Convert_v1alpha1_RegistryAuth_To_api_RegistryAuth is an autogenerated conversion function.
+func Convert_v1alpha1_RegistryAuth_To_api_RegistryAuth(in *v1alpha1.RegistryAuth, out *api.RegistryAuth, s conversion.Scope) error {
+	return autoConvert_v1alpha1_RegistryAuth_To_api_RegistryAuth(in, out, s)
+}
+
+func autoConvert_api_RegistryAuth_To_v1alpha1_RegistryAuth(in *api.RegistryAuth, out *v1alpha1.RegistryAuth, s conversion.Scope) error {
+	out.Username = in.Username
+	out.Password = in.Password
+	out.Token = in.Token
+	return nil
+}
+
+// Convert_api_RegistryAuth_To_v1alpha1_RegistryAuth is an autogenerated conversion function.
+func Convert_api_RegistryAuth_To_v1alpha1_RegistryAuth(in *api.RegistryAuth, out *v1alpha1.RegistryAuth, s conversion.Scope) error {
+	return autoConvert_api_RegistryAuth_To_v1alpha1_RegistryAuth(in, out, s)
+}
+
+func autoConvert_v1alpha1_RegistryMirror_To_api_RegistryMirror(in *v1alpha1.RegistryMirror, out *api.RegistryMirror, s conversion.Scope) error {
+	out.Endpoint = in.Endpoint
+	out.Capabilities = *(*[]string)(unsafe.Pointer(&in.Capabilities))
+	out.OverridePath = in.OverridePath
+	if err := Convert_v1alpha1_RegistryTLS_To_api_RegistryTLS(&in.TLS, &out.TLS, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_RegistryAuth_To_api_RegistryAuth(&in.Auth, &out.Auth, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha1_RegistryMirror_To_api_RegistryMirror is an autogenerated conversion function.
+func Convert_v1alpha1_RegistryMirror_To_api_RegistryMirror(in *v1alpha1.RegistryMirror, out *api.RegistryMirror, s conversion.Scope) error {
+	return autoConvert_v1alpha1_RegistryMirror_To_api_RegistryMirror(in, out, s)
+}
+
+func autoConvert_api_RegistryMirror_To_v1alpha1_RegistryMirror(in *api.RegistryMirror, out *v1alpha1.RegistryMirror, s conversion.Scope) error {
+	out.Endpoint = in.Endpoint
+	out.Capabilities = *(*[]string)(unsafe.Pointer(&in.Capabilities))
+	out.OverridePath = in.OverridePath
+	if err := Convert_api_RegistryTLS_To_v1alpha1_RegistryTLS(&in.TLS, &out.TLS, s); err != nil {
+		return err
+	}
+	if err := Convert_api_RegistryAuth_To_v1alpha1_RegistryAuth(&in.Auth, &out.Auth, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_api_RegistryMirror_To_v1alpha1_RegistryMirror is an autogenerated conversion function.
+func Convert_api_RegistryMirror_To_v1alpha1_RegistryMirror(in *api.RegistryMirror, out *v1alpha1.RegistryMirror, s conversion.Scope) error {
+	return autoConvert_api_RegistryMirror_To_v1alpha1_RegistryMirror(in, out, s)
+}
+
+func autoConvert_v1alpha1_RegistryOptions_To_api_RegistryOptions(in *v1alpha1.RegistryOptions, out *api.RegistryOptions, s conversion.Scope) error {
+	out.Server = in.Server
+	out.Mirrors = *(*[]api.RegistryMirror)(unsafe.Pointer(&in.Mirrors))
+	if err := Convert_v1alpha1_RegistryTLS_To_api_RegistryTLS(&in.TLS, &out.TLS, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_RegistryAuth_To_api_RegistryAuth(&in.Auth, &out.Auth, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha1_RegistryOptions_To_api_RegistryOptions is an autogenerated conversion function.
+func Convert_v1alpha1_RegistryOptions_To_api_RegistryOptions(in *v1alpha1.RegistryOptions, out *api.RegistryOptions, s conversion.Scope) error {
+	return autoConvert_v1alpha1_RegistryOptions_To_api_RegistryOptions(in, out, s)
+}
+
+func autoConvert_api_RegistryOptions_To_v1alpha1_RegistryOptions(in *api.RegistryOptions, out *v1alpha1.RegistryOptions, s conversion.Scope) error {
+	out.Server = in.Server
+	out.Mirrors = *(*[]v1alpha1.RegistryMirror)(unsafe.Pointer(&in.Mirrors))
+	if err := Convert_api_RegistryTLS_To_v1alpha1_RegistryTLS(&in.TLS, &out.TLS, s); err != nil {
+		return err
+	}
+	if err := Convert_api_RegistryAuth_To_v1alpha1_RegistryAuth(&in.Auth, &out.Auth, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_api_RegistryOptions_To_v1alpha1_RegistryOptions is an autogenerated conversion function.
+func Convert_api_RegistryOptions_To_v1alpha1_RegistryOptions(in *api.RegistryOptions, out *v1alpha1.RegistryOptions, s conversion.Scope) error {
+	return autoConvert_api_RegistryOptions_To_v1alpha1_RegistryOptions(in, out, s)
+}
+
+func autoConvert_v1alpha1_RegistryTLS_To_api_RegistryTLS(in *v1alpha1.RegistryTLS, out *api.RegistryTLS, s conversion.Scope) error {
+	out.CABundle = in.CABundle
+	out.ClientCertificatePath = in.ClientCertificatePath
+	out.ClientKeyPath = in.ClientKeyPath
+	out.InsecureSkipVerify = in.InsecureSkipVerify
+	return nil
+}
+
+// Convert_v1alpha1_RegistryTLS_To_api_RegistryTLS is an autogenerated conversion function.
+func Convert_v1alpha1_RegistryTLS_To_api_RegistryTLS(in *v1alpha1.RegistryTLS, out *api.RegistryTLS, s conversion.Scope) error {
+	return autoConvert_v1alpha1_RegistryTLS_To_api_RegistryTLS(in, out, s)
+}
+
+func autoConvert_api_RegistryTLS_To_v1alpha1_RegistryTLS(in *api.RegistryTLS, out *v1alpha1.RegistryTLS, s conversion.Scope) error {
+	out.CABundle = in.CABundle
+	out.ClientCertificatePath = in.ClientCertificatePath
+	out.ClientKeyPath = in.ClientKeyPath
+	out.InsecureSkipVerify = in.InsecureSkipVerify
+	return nil
+}
+
+// Convert_api_RegistryTLS_To_v1alpha1_RegistryTLS is an autogenerated conversion function.
+func Convert_api_RegistryTLS_To_v1alpha1_RegistryTLS(in *api.RegistryTLS, out *v1alpha1.RegistryTLS, s conversion.Scope) error {
+	return autoConvert_api_RegistryTLS_To_v1alpha1_RegistryTLS(in, out, s)
+}
+
+func autoConvert_v1alpha1_ReservationPolicy_To_api_ReservationPolicy(in *v1alpha1.ReservationPolicy, out *api.ReservationPolicy, s conversion.Scope) error {
+	out.Profile = api.ReservationProfile(in.Profile)
+	out.KubeReserved = (*api.ResourceReservation)(unsafe.Pointer(in.KubeReserved))
+	out.SystemReserved = (*api.ResourceReservation)(unsafe.Pointer(in.SystemReserved))
+	out.EvictionHard = *(*map[string]string)(unsafe.Pointer(&in.EvictionHard))
+	return nil
+}
+
+// Convert_v1alpha1_ReservationPolicy_To_api_ReservationPolicy is an autogenerated conversion function.
+func Convert_v1alpha1_ReservationPolicy_To_api_ReservationPolicy(in *v1alpha1.ReservationPolicy, out *api.ReservationPolicy, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ReservationPolicy_To_api_ReservationPolicy(in, out, s)
+}
+
+func autoConvert_api_ReservationPolicy_To_v1alpha1_ReservationPolicy(in *api.ReservationPolicy, out *v1alpha1.ReservationPolicy, s conversion.Scope) error {
+	out.Profile = v1alpha1.ReservationProfile(in.Profile)
+	out.KubeReserved = (*v1alpha1.ResourceReservation)(unsafe.Pointer(in.KubeReserved))
+	out.SystemReserved = (*v1alpha1.ResourceReservation)(unsafe.Pointer(in.SystemReserved))
+	out.EvictionHard = *(*map[string]string)(unsafe.Pointer(&in.EvictionHard))
+	return nil
+}
+
+// Convert_api_ReservationPolicy_To_v1alpha1_ReservationPolicy is an autogenerated conversion function.
+func Convert_api_ReservationPolicy_To_v1alpha1_ReservationPolicy(in *api.ReservationPolicy, out *v1alpha1.ReservationPolicy, s conversion.Scope) error {
+	return autoConvert_api_ReservationPolicy_To_v1alpha1_ReservationPolicy(in, out, s)
+}
+
+func autoConvert_v1alpha1_ReservationTier_To_api_ReservationTier(in *v1alpha1.ReservationTier, out *api.ReservationTier, s conversion.Scope) error {
+	out.UpTo = in.UpTo
+	out.Percent = in.Percent
+	return nil
+}
+
+// Convert_v1alpha1_ReservationTier_To_api_ReservationTier is an autogenerated conversion function.
+func Convert_v1alpha1_ReservationTier_To_api_ReservationTier(in *v1alpha1.ReservationTier, out *api.ReservationTier, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ReservationTier_To_api_ReservationTier(in, out, s)
+}
+
+func autoConvert_api_ReservationTier_To_v1alpha1_ReservationTier(in *api.ReservationTier, out *v1alpha1.ReservationTier, s conversion.Scope) error {
+	out.UpTo = in.UpTo
+	out.Percent = in.Percent
+	return nil
+}
+
+// Convert_api_ReservationTier_To_v1alpha1_ReservationTier is an autogenerated conversion function.
+func Convert_api_ReservationTier_To_v1alpha1_ReservationTier(in *api.ReservationTier, out *v1alpha1.ReservationTier, s conversion.Scope) error {
+	return autoConvert_api_ReservationTier_To_v1alpha1_ReservationTier(in, out, s)
+}
+
+func autoConvert_v1alpha1_ResourceReservation_To_api_ResourceReservation(in *v1alpha1.ResourceReservation, out *api.ResourceReservation, s conversion.Scope) error {
+	out.CPU = *(*[]api.ReservationTier)(unsafe.Pointer(&in.CPU))
+	out.Memory = *(*[]api.ReservationTier)(unsafe.Pointer(&in.Memory))
+	out.EphemeralStorage = in.EphemeralStorage
+	return nil
+}
+
+// Convert_v1alpha1_ResourceReservation_To_api_ResourceReservation is an autogenerated conversion function.
+func Convert_v1alpha1_ResourceReservation_To_api_ResourceReservation(in *v1alpha1.ResourceReservation, out *api.ResourceReservation, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ResourceReservation_To_api_ResourceReservation(in, out, s)
+}
+
+func autoConvert_api_ResourceReservation_To_v1alpha1_ResourceReservation(in *api.ResourceReservation, out *v1alpha1.ResourceReservation, s conversion.Scope) error {
+	out.CPU = *(*[]v1alpha1.ReservationTier)(unsafe.Pointer(&in.CPU))
+	out.Memory = *(*[]v1alpha1.ReservationTier)(unsafe.Pointer(&in.Memory))
+	out.EphemeralStorage = in.EphemeralStorage
+	return nil
+}
+
+// Convert_api_ResourceReservation_To_v1alpha1_ResourceReservation is an autogenerated conversion function.
+func Convert_api_ResourceReservation_To_v1alpha1_ResourceReservation(in *api.ResourceReservation, out *v1alpha1.ResourceReservation, s conversion.Scope) error {
+	return autoConvert_api_ResourceReservation_To_v1alpha1_ResourceReservation(in, out, s)
+}
+
+func autoConvert_v1alpha1_RuntimeOptions_To_api_RuntimeOptions(in *v1alpha1.RuntimeOptions, out *api.RuntimeOptions, s conversion.Scope) error {
+	out.Type = in.Type
+	out.BinaryPath = in.BinaryPath
+	out.ConfigPath = in.ConfigPath
+	out.Options = *(*map[string]string)(unsafe.Pointer(&in.Options))
+	return nil
+}
+
+// Convert_v1alpha1_RuntimeOptions_To_api_RuntimeOptions is an autogenerated conversion function.
+func Convert_v1alpha1_RuntimeOptions_To_api_RuntimeOptions(in *v1alpha1.RuntimeOptions, out *api.RuntimeOptions, s conversion.Scope) error {
+	return autoConvert_v1alpha1_RuntimeOptions_To_api_RuntimeOptions(in, out, s)
+}
+
+func autoConvert_api_RuntimeOptions_To_v1alpha1_RuntimeOptions(in *api.RuntimeOptions, out *v1alpha1.RuntimeOptions, s conversion.Scope) error {
+	out.Type = in.Type
+	out.BinaryPath = in.BinaryPath
+	out.ConfigPath = in.ConfigPath
+	out.Options = *(*map[string]string)(unsafe.Pointer(&in.Options))
+	return nil
+}
+
+// Convert_api_RuntimeOptions_To_v1alpha1_RuntimeOptions is an autogenerated conversion function.
+func Convert_api_RuntimeOptions_To_v1alpha1_RuntimeOptions(in *api.RuntimeOptions, out *v1alpha1.RuntimeOptions, s conversion.Scope) error {
+	return autoConvert_api_RuntimeOptions_To_v1alpha1_RuntimeOptions(in, out, s)
+}
+
 func autoConvert_v1alpha1_SSM_To_api_SSM(in *v1alpha1.SSM, out *api.SSM, s conversion.Scope) error {
 	out.ActivationCode = in.ActivationCode
 	out.ActivationID = in.ActivationID
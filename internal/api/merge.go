@@ -16,8 +16,9 @@ func (dst *NodeConfig) Merge(src *NodeConfig) error {
 }
 
 const (
-	kubeletFlagsName  = "Flags"
-	kubeletConfigName = "Config"
+	kubeletFlagsName       = "Flags"
+	kubeletConfigName      = "Config"
+	kubeletReservationName = "Reservation"
 )
 
 type kubeletTransformer struct{}
@@ -37,6 +38,11 @@ func (k kubeletTransformer) Transformer(typ reflect.Type) func(dst, src reflect.
 				return err
 			}
 
+			k.transformReservation(
+				dst.FieldByName(kubeletReservationName),
+				src.FieldByName(kubeletReservationName),
+			)
+
 			return nil
 		}
 	}
@@ -79,6 +85,14 @@ func (k kubeletTransformer) transformConfig(dst, src reflect.Value) error {
 	return nil
 }
 
+func (k kubeletTransformer) transformReservation(dst, src reflect.Value) {
+	// mergo doesn't descend into a type once its transformer runs, so the
+	// reservation policy is replaced as a whole by the latter one.
+	if dst.CanSet() && !src.IsNil() {
+		dst.Set(src)
+	}
+}
+
 func toInlineDocument(m map[string]interface{}) (InlineDocument, error) {
 	rawMap := make(InlineDocument)
 	for key, value := range m {
@@ -111,6 +111,71 @@ func TestMerge(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "reservation from patch",
+			baseSpec: NodeConfigSpec{
+				Kubelet: KubeletOptions{
+					Flags: []string{"--node-labels=nodegroup=example"},
+				},
+			},
+			patchSpec: NodeConfigSpec{
+				Kubelet: KubeletOptions{
+					Reservation: &ReservationPolicy{
+						Profile:      ReservationProfileEdge,
+						EvictionHard: map[string]string{"memory.available": "200Mi"},
+					},
+				},
+			},
+			expectedSpec: NodeConfigSpec{
+				Kubelet: KubeletOptions{
+					Flags: []string{"--node-labels=nodegroup=example"},
+					Reservation: &ReservationPolicy{
+						Profile:      ReservationProfileEdge,
+						EvictionHard: map[string]string{"memory.available": "200Mi"},
+					},
+				},
+			},
+		},
+		{
+			name: "reservation overridden by patch",
+			baseSpec: NodeConfigSpec{
+				Kubelet: KubeletOptions{
+					Reservation: &ReservationPolicy{
+						Profile:      ReservationProfileDefault,
+						EvictionHard: map[string]string{"memory.available": "100Mi"},
+					},
+				},
+			},
+			patchSpec: NodeConfigSpec{
+				Kubelet: KubeletOptions{
+					Reservation: &ReservationPolicy{Profile: ReservationProfileMinimal},
+				},
+			},
+			expectedSpec: NodeConfigSpec{
+				Kubelet: KubeletOptions{
+					Reservation: &ReservationPolicy{Profile: ReservationProfileMinimal},
+				},
+			},
+		},
+		{
+			name: "reservation kept without patch reservation",
+			baseSpec: NodeConfigSpec{
+				Kubelet: KubeletOptions{
+					Reservation: &ReservationPolicy{Profile: ReservationProfileEdge},
+				},
+			},
+			patchSpec: NodeConfigSpec{
+				Kubelet: KubeletOptions{
+					Flags: []string{"--node-labels=nodegroup=user-set"},
+				},
+			},
+			expectedSpec: NodeConfigSpec{
+				Kubelet: KubeletOptions{
+					Flags:       []string{"--node-labels=nodegroup=user-set"},
+					Reservation: &ReservationPolicy{Profile: ReservationProfileEdge},
+				},
+			},
+		},
 	}
 
 	for _, test := range tests {
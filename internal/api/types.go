@@ -39,6 +39,7 @@ type NodeConfigStatus struct {
 	Instance InstanceDetails `json:"instance,omitempty"`
 	Hybrid   HybridDetails   `json:"hybrid,omitempty"`
 	Defaults DefaultOptions  `json:"default,omitempty"`
+	Kubelet  KubeletDetails  `json:"kubelet,omitempty"`
 }
 
 type InstanceDetails struct {
@@ -52,12 +53,24 @@ type InstanceDetails struct {
 
 type HybridDetails struct {
 	NodeName string `json:"nodeName,omitempty"`
+	// RemotePodNetworks are the CIDRs of the cluster's remote pod networks. They are only
+	// set when nodeadm describes the cluster, which it skips when the config sets the
+	// cluster's endpoint, certificate authority and service CIDR.
+	RemotePodNetworks []string `json:"remotePodNetworks,omitempty"`
 }
 
 type DefaultOptions struct {
 	SandboxImage string `json:"sandboxImage,omitempty"`
 }
 
+// KubeletDetails are the values computed for the kubelet configuration.
+type KubeletDetails struct {
+	MaxPods        int32             `json:"maxPods,omitempty"`
+	KubeReserved   map[string]string `json:"kubeReserved,omitempty"`
+	SystemReserved map[string]string `json:"systemReserved,omitempty"`
+	EvictionHard   map[string]string `json:"evictionHard,omitempty"`
+}
+
 type ClusterDetails struct {
 	Name                 string `json:"name,omitempty"`
 	Region               string `json:"region,omitempty"`
@@ -77,6 +90,35 @@ type KubeletOptions struct {
 	// amended to the generated defaults, and therefore will act as overrides
 	// https://kubernetes.io/docs/reference/command-line-tools-reference/kubelet/
 	Flags []string `json:"flags,omitempty"`
+	// Reservation is the policy used to compute kubeReserved, systemReserved and
+	// evictionHard. Values in Config take precedence.
+	Reservation *ReservationPolicy `json:"reservation,omitempty"`
+}
+
+type ReservationProfile string
+
+const (
+	ReservationProfileDefault ReservationProfile = "default"
+	ReservationProfileMinimal ReservationProfile = "minimal"
+	ReservationProfileEdge    ReservationProfile = "edge"
+)
+
+type ReservationPolicy struct {
+	Profile        ReservationProfile   `json:"profile,omitempty"`
+	KubeReserved   *ResourceReservation `json:"kubeReserved,omitempty"`
+	SystemReserved *ResourceReservation `json:"systemReserved,omitempty"`
+	EvictionHard   map[string]string    `json:"evictionHard,omitempty"`
+}
+
+type ResourceReservation struct {
+	CPU              []ReservationTier `json:"cpu,omitempty"`
+	Memory           []ReservationTier `json:"memory,omitempty"`
+	EphemeralStorage string            `json:"ephemeralStorage,omitempty"`
+}
+
+type ReservationTier struct {
+	UpTo    string `json:"upTo,omitempty"`
+	Percent string `json:"percent"`
 }
 
 // InlineDocument is an alias to a dynamically typed map. This allows using
@@ -88,6 +130,47 @@ type ContainerdOptions struct {
 	// by the user to override default generated configurations
 	// https://github.com/containerd/containerd/blob/main/docs/man/containerd-config.toml.5.md
 	Config string `json:"config,omitempty"`
+	// Registries configures how images are pulled from each registry, keyed by
+	// registry host. Each one is written to /etc/containerd/certs.d/<host>/hosts.toml
+	// https://github.com/containerd/containerd/blob/main/docs/hosts.md
+	Registries map[string]RegistryOptions `json:"registries,omitempty"`
+	// Runtimes are additional runtime handlers keyed by handler name.
+	Runtimes map[string]RuntimeOptions `json:"runtimes,omitempty"`
+}
+
+type RegistryOptions struct {
+	Server  string           `json:"server,omitempty"`
+	Mirrors []RegistryMirror `json:"mirrors,omitempty"`
+	TLS     RegistryTLS      `json:"tls,omitempty"`
+	Auth    RegistryAuth     `json:"auth,omitempty"`
+}
+
+type RegistryMirror struct {
+	Endpoint     string       `json:"endpoint"`
+	Capabilities []string     `json:"capabilities,omitempty"`
+	OverridePath bool         `json:"overridePath,omitempty"`
+	TLS          RegistryTLS  `json:"tls,omitempty"`
+	Auth         RegistryAuth `json:"auth,omitempty"`
+}
+
+type RegistryTLS struct {
+	CABundle              string `json:"caBundle,omitempty"`
+	ClientCertificatePath string `json:"clientCertificatePath,omitempty"`
+	ClientKeyPath         string `json:"clientKeyPath,omitempty"`
+	InsecureSkipVerify    bool   `json:"insecureSkipVerify,omitempty"`
+}
+
+type RegistryAuth struct {
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+	Token    string `json:"token,omitempty"`
+}
+
+type RuntimeOptions struct {
+	Type       string            `json:"type"`
+	BinaryPath string            `json:"binaryPath,omitempty"`
+	ConfigPath string            `json:"configPath,omitempty"`
+	Options    map[string]string `json:"options,omitempty"`
 }
 
 type IPFamily string
@@ -122,9 +205,22 @@ const (
 )
 
 type HybridOptions struct {
-	EnableCredentialsFile bool              `json:"enableCredentialsFile,omitempty"`
-	IAMRolesAnywhere      *IAMRolesAnywhere `json:"iamRolesAnywhere,omitempty"`
-	SSM                   *SSM              `json:"ssm,omitempty"`
+	EnableCredentialsFile bool               `json:"enableCredentialsFile,omitempty"`
+	IAMRolesAnywhere      *IAMRolesAnywhere  `json:"iamRolesAnywhere,omitempty"`
+	SSM                   *SSM               `json:"ssm,omitempty"`
+	PodNetwork            *PodNetworkOptions `json:"podNetwork,omitempty"`
+}
+
+type CNIType string
+
+const (
+	CNITypeCilium CNIType = "cilium"
+	CNITypeCalico CNIType = "calico"
+)
+
+type PodNetworkOptions struct {
+	CNI              CNIType `json:"cni,omitempty"`
+	NodeCIDRMaskSize int32   `json:"nodeCIDRMaskSize,omitempty"`
 }
 
 func (nc NodeConfig) IsHybridNode() bool {
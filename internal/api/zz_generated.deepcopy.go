@@ -36,6 +36,20 @@ func (in *ClusterDetails) DeepCopy() *ClusterDetails {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ContainerdOptions) DeepCopyInto(out *ContainerdOptions) {
 	*out = *in
+	if in.Registries != nil {
+		in, out := &in.Registries, &out.Registries
+		*out = make(map[string]RegistryOptions, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.Runtimes != nil {
+		in, out := &in.Runtimes, &out.Runtimes
+		*out = make(map[string]RuntimeOptions, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerdOptions.
@@ -66,6 +80,11 @@ func (in *DefaultOptions) DeepCopy() *DefaultOptions {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HybridDetails) DeepCopyInto(out *HybridDetails) {
 	*out = *in
+	if in.RemotePodNetworks != nil {
+		in, out := &in.RemotePodNetworks, &out.RemotePodNetworks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HybridDetails.
@@ -91,6 +110,11 @@ func (in *HybridOptions) DeepCopyInto(out *HybridOptions) {
 		*out = new(SSM)
 		**out = **in
 	}
+	if in.PodNetwork != nil {
+		in, out := &in.PodNetwork, &out.PodNetwork
+		*out = new(PodNetworkOptions)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HybridOptions.
@@ -170,6 +194,42 @@ func (in *InstanceOptions) DeepCopy() *InstanceOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeletDetails) DeepCopyInto(out *KubeletDetails) {
+	*out = *in
+	if in.KubeReserved != nil {
+		in, out := &in.KubeReserved, &out.KubeReserved
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.SystemReserved != nil {
+		in, out := &in.SystemReserved, &out.SystemReserved
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.EvictionHard != nil {
+		in, out := &in.EvictionHard, &out.EvictionHard
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeletDetails.
+func (in *KubeletDetails) DeepCopy() *KubeletDetails {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeletDetails)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KubeletOptions) DeepCopyInto(out *KubeletOptions) {
 	*out = *in
@@ -185,6 +245,11 @@ func (in *KubeletOptions) DeepCopyInto(out *KubeletOptions) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Reservation != nil {
+		in, out := &in.Reservation, &out.Reservation
+		*out = new(ReservationPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeletOptions.
@@ -218,7 +283,7 @@ func (in *NodeConfig) DeepCopyInto(out *NodeConfig) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeConfig.
@@ -275,7 +340,7 @@ func (in *NodeConfigList) DeepCopyObject() runtime.Object {
 func (in *NodeConfigSpec) DeepCopyInto(out *NodeConfigSpec) {
 	*out = *in
 	in.Cluster.DeepCopyInto(&out.Cluster)
-	out.Containerd = in.Containerd
+	in.Containerd.DeepCopyInto(&out.Containerd)
 	out.Instance = in.Instance
 	in.Kubelet.DeepCopyInto(&out.Kubelet)
 	if in.Hybrid != nil {
@@ -299,8 +364,9 @@ func (in *NodeConfigSpec) DeepCopy() *NodeConfigSpec {
 func (in *NodeConfigStatus) DeepCopyInto(out *NodeConfigStatus) {
 	*out = *in
 	out.Instance = in.Instance
-	out.Hybrid = in.Hybrid
+	in.Hybrid.DeepCopyInto(&out.Hybrid)
 	out.Defaults = in.Defaults
+	in.Kubelet.DeepCopyInto(&out.Kubelet)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeConfigStatus.
@@ -313,6 +379,191 @@ func (in *NodeConfigStatus) DeepCopy() *NodeConfigStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodNetworkOptions) DeepCopyInto(out *PodNetworkOptions) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodNetworkOptions.
+func (in *PodNetworkOptions) DeepCopy() *PodNetworkOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(PodNetworkOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RegistryAuth) DeepCopyInto(out *RegistryAuth) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegistryAuth.
+func (in *RegistryAuth) DeepCopy() *RegistryAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(RegistryAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RegistryMirror) DeepCopyInto(out *RegistryMirror) {
+	*out = *in
+	if in.Capabilities != nil {
+		in, out := &in.Capabilities, &out.Capabilities
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	out.TLS = in.TLS
+	out.Auth = in.Auth
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegistryMirror.
+func (in *RegistryMirror) DeepCopy() *RegistryMirror {
+	if in == nil {
+		return nil
+	}
+	out := new(RegistryMirror)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RegistryOptions) DeepCopyInto(out *RegistryOptions) {
+	*out = *in
+	if in.Mirrors != nil {
+		in, out := &in.Mirrors, &out.Mirrors
+		*out = make([]RegistryMirror, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	out.TLS = in.TLS
+	out.Auth = in.Auth
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegistryOptions.
+func (in *RegistryOptions) DeepCopy() *RegistryOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(RegistryOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RegistryTLS) DeepCopyInto(out *RegistryTLS) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegistryTLS.
+func (in *RegistryTLS) DeepCopy() *RegistryTLS {
+	if in == nil {
+		return nil
+	}
+	out := new(RegistryTLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReservationPolicy) DeepCopyInto(out *ReservationPolicy) {
+	*out = *in
+	if in.KubeReserved != nil {
+		in, out := &in.KubeReserved, &out.KubeReserved
+		*out = new(ResourceReservation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SystemReserved != nil {
+		in, out := &in.SystemReserved, &out.SystemReserved
+		*out = new(ResourceReservation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EvictionHard != nil {
+		in, out := &in.EvictionHard, &out.EvictionHard
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReservationPolicy.
+func (in *ReservationPolicy) DeepCopy() *ReservationPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ReservationPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReservationTier) DeepCopyInto(out *ReservationTier) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReservationTier.
+func (in *ReservationTier) DeepCopy() *ReservationTier {
+	if in == nil {
+		return nil
+	}
+	out := new(ReservationTier)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceReservation) DeepCopyInto(out *ResourceReservation) {
+	*out = *in
+	if in.CPU != nil {
+		in, out := &in.CPU, &out.CPU
+		*out = make([]ReservationTier, len(*in))
+		copy(*out, *in)
+	}
+	if in.Memory != nil {
+		in, out := &in.Memory, &out.Memory
+		*out = make([]ReservationTier, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceReservation.
+func (in *ResourceReservation) DeepCopy() *ResourceReservation {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceReservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RuntimeOptions) DeepCopyInto(out *RuntimeOptions) {
+	*out = *in
+	if in.Options != nil {
+		in, out := &in.Options, &out.Options
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuntimeOptions.
+func (in *RuntimeOptions) DeepCopy() *RuntimeOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(RuntimeOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SSM) DeepCopyInto(out *SSM) {
 	*out = *in
@@ -0,0 +1,383 @@
+package artifact
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// DefaultCacheDir is where the Downloader caches artifacts, by checksum.
+	DefaultCacheDir = "/opt/nodeadm/cache"
+	// DefaultDownloadWorkers is the default number of artifacts downloaded concurrently.
+	DefaultDownloadWorkers = 4
+
+	defaultDownloadAttempts = 3
+	defaultDownloadBackoff  = 2 * time.Second
+	defaultProgressInterval = 10 * time.Second
+
+	cachedDir  = "sha256"
+	partialDir = "partial"
+)
+
+// Download is an artifact to download and verify.
+type Download struct {
+	// Name identifies the artifact in logs.
+	Name string
+	URL  string
+	// Gzipped is true if URL serves the artifact gzip compressed. Checksum is
+	// always the checksum of the decompressed artifact.
+	Gzipped bool
+	// Checksum is the expected SHA256 of the artifact.
+	Checksum []byte
+}
+
+// Downloader downloads artifacts to a content-addressed cache on disk. Interrupted
+// downloads are resumed with HTTP range requests, so downloads can be retried and
+// re-run without starting over, and artifacts that are already cached are not
+// downloaded again.
+type Downloader struct {
+	cacheDir         string
+	workers          int
+	attempts         int
+	backoff          time.Duration
+	progressInterval time.Duration
+	fetchers         Fetchers
+	logger           *zap.Logger
+
+	// locks serializes downloads of the same artifact.
+	locks sync.Map
+}
+
+// DownloaderOpt configures a Downloader.
+type DownloaderOpt func(*Downloader)
+
+// WithCacheDir sets the directory the artifacts are cached in.
+func WithCacheDir(dir string) DownloaderOpt {
+	return func(d *Downloader) {
+		d.cacheDir = dir
+	}
+}
+
+// WithDownloadWorkers sets how many artifacts GetAll downloads concurrently.
+func WithDownloadWorkers(workers int) DownloaderOpt {
+	return func(d *Downloader) {
+		d.workers = max(workers, 1)
+	}
+}
+
+// WithDownloadAttempts sets how many times a download is attempted, resuming from
+// where the previous attempt stopped, and the wait between attempts.
+func WithDownloadAttempts(attempts int, backoff time.Duration) DownloaderOpt {
+	return func(d *Downloader) {
+		d.attempts = max(attempts, 1)
+		d.backoff = backoff
+	}
+}
+
+// WithProgressInterval sets how often the progress of a download is logged.
+func WithProgressInterval(interval time.Duration) DownloaderOpt {
+	return func(d *Downloader) {
+		d.progressInterval = interval
+	}
+}
+
+// WithFetchers sets the fetchers used to download artifacts, by URI scheme. Downloads
+// are only resumed for fetchers that implement RangeFetcher.
+func WithFetchers(fetchers Fetchers) DownloaderOpt {
+	return func(d *Downloader) {
+		d.fetchers = fetchers
+	}
+}
+
+func NewDownloader(logger *zap.Logger, opts ...DownloaderOpt) *Downloader {
+	d := &Downloader{
+		cacheDir:         DefaultCacheDir,
+		workers:          DefaultDownloadWorkers,
+		attempts:         defaultDownloadAttempts,
+		backoff:          defaultDownloadBackoff,
+		progressInterval: defaultProgressInterval,
+		fetchers:         DefaultFetchers(),
+		logger:           logger,
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
+}
+
+// GetAll downloads the artifacts to the cache, running up to the configured number
+// of workers concurrently. It returns the errors of all failed downloads.
+func (d *Downloader) GetAll(ctx context.Context, downloads ...Download) error {
+	errs := make([]error, len(downloads))
+	sem := make(chan struct{}, d.workers)
+	var wg sync.WaitGroup
+	for i, download := range downloads {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				errs[i] = ctx.Err()
+				return
+			}
+			_, errs[i] = d.Get(ctx, download)
+		}()
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// Get returns the path to the verified artifact in the cache, downloading it if
+// it isn't cached yet.
+func (d *Downloader) Get(ctx context.Context, download Download) (string, error) {
+	key := hex.EncodeToString(download.Checksum)
+	lock, _ := d.locks.LoadOrStore(key, &sync.Mutex{})
+	lock.(*sync.Mutex).Lock()
+	defer lock.(*sync.Mutex).Unlock()
+
+	cached := filepath.Join(d.cacheDir, cachedDir, key)
+	switch checksum, err := fileChecksum(cached); {
+	case err == nil && bytes.Equal(checksum, download.Checksum):
+		d.logger.Info("Using cached artifact", zap.String("artifact", download.Name), zap.String("path", cached))
+		return cached, nil
+	case err == nil:
+		d.logger.Warn("Cached artifact is corrupted, downloading it again", zap.String("artifact", download.Name))
+		if err := os.Remove(cached); err != nil {
+			return "", err
+		}
+	case !errors.Is(err, fs.ErrNotExist):
+		return "", err
+	}
+
+	partial := filepath.Join(d.cacheDir, partialDir, key)
+	if download.Gzipped {
+		partial += ".gz"
+	}
+	if err := os.MkdirAll(filepath.Dir(partial), DefaultDirPerms); err != nil {
+		return "", err
+	}
+
+	var err error
+	for attempt := 1; attempt <= d.attempts; attempt++ {
+		if attempt > 1 {
+			d.logger.Warn("Downloading artifact failed. Retrying...", zap.String("artifact", download.Name), zap.Int("attempt", attempt), zap.Error(err))
+			select {
+			case <-time.After(d.backoff):
+			case <-ctx.Done():
+				return "", ctx.Err()
+			}
+		}
+		if err = d.download(ctx, download, partial); err != nil {
+			continue
+		}
+		if err = d.store(download, partial, cached); err != nil {
+			// The partial download is corrupted, start over in the next attempt.
+			os.Remove(partial)
+			continue
+		}
+		return cached, nil
+	}
+	return "", fmt.Errorf("downloading %s: %w", download.Name, err)
+}
+
+// Open returns the cached artifact as a Source, downloading it if needed. The
+// Source verifies the checksum of the artifact as it's read.
+func (d *Downloader) Open(ctx context.Context, download Download) (Source, error) {
+	path, err := d.Get(ctx, download)
+	if err != nil {
+		return nil, err
+	}
+	fh, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	digest := sha256.New()
+	return struct {
+		io.Reader
+		io.Closer
+		ChecksumVerifier
+	}{
+		Reader:           io.TeeReader(fh, digest),
+		Closer:           fh,
+		ChecksumVerifier: checksumVerifier{expect: download.Checksum, digest: digest},
+	}, nil
+}
+
+// Prune removes the cached artifacts and partial downloads that don't match any of
+// the checksums in keep, so the cache doesn't keep the artifacts of every release
+// that was installed. It keeps going on failures and returns all errors.
+func (d *Downloader) Prune(keep ...[]byte) error {
+	kept := make(map[string]bool, len(keep))
+	for _, checksum := range keep {
+		kept[hex.EncodeToString(checksum)] = true
+	}
+
+	var errs []error
+	for _, dir := range []string{cachedDir, partialDir} {
+		entries, err := os.ReadDir(filepath.Join(d.cacheDir, dir))
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			if kept[strings.TrimSuffix(entry.Name(), ".gz")] {
+				continue
+			}
+			path := filepath.Join(d.cacheDir, dir, entry.Name())
+			d.logger.Info("Removing unused artifact from cache", zap.String("path", path))
+			if err := os.RemoveAll(path); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// download downloads the artifact to partial, resuming from its current size if the
+// fetcher for its URL supports it.
+func (d *Downloader) download(ctx context.Context, download Download, partial string) error {
+	var offset int64
+	if info, err := os.Stat(partial); err == nil {
+		offset = info.Size()
+	}
+
+	fetcher, uri, err := d.fetchers.For(download.URL)
+	if err != nil {
+		return err
+	}
+	var body io.ReadCloser
+	resumed := false
+	if rangeFetcher, ok := fetcher.(RangeFetcher); ok {
+		body, resumed, err = rangeFetcher.FetchRange(ctx, uri, offset)
+	} else {
+		body, err = fetcher.Fetch(ctx, uri)
+	}
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	flags := os.O_CREATE | os.O_WRONLY
+	if resumed && offset > 0 {
+		d.logger.Info("Resuming artifact download", zap.String("artifact", download.Name), zap.Int64("offset", offset))
+		flags |= os.O_APPEND
+	} else {
+		offset = 0
+		flags |= os.O_TRUNC
+	}
+
+	fh, err := os.OpenFile(partial, flags, 0o644)
+	if err != nil {
+		return err
+	}
+	defer fh.Close()
+
+	total := int64(-1)
+	if sized, ok := body.(interface{ Size() int64 }); ok {
+		total = offset + sized.Size()
+	}
+	progress := &progressReader{
+		reader:   body,
+		name:     download.Name,
+		read:     offset,
+		total:    total,
+		interval: d.progressInterval,
+		next:     time.Now().Add(d.progressInterval),
+		logger:   d.logger,
+	}
+	start := time.Now()
+	if _, err := io.Copy(fh, progress); err != nil {
+		return err
+	}
+	d.logger.Info("Downloaded artifact", zap.String("artifact", download.Name), zap.Int64("bytes", progress.read), zap.Duration("duration", time.Since(start).Round(time.Millisecond)))
+	return nil
+}
+
+// store verifies the checksum of the partial download, decompressing it if needed,
+// and moves it to cached.
+func (d *Downloader) store(download Download, partial, cached string) error {
+	in, err := os.Open(partial)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	var reader io.Reader = in
+	if download.Gzipped {
+		gzipReader, err := gzip.NewReader(in)
+		if err != nil {
+			return fmt.Errorf("getting gzip reader: %w", err)
+		}
+		defer gzipReader.Close()
+		reader = gzipReader
+	}
+
+	if err := os.MkdirAll(filepath.Dir(cached), DefaultDirPerms); err != nil {
+		return err
+	}
+	tmp := cached + ".tmp"
+	out, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp)
+
+	digest := sha256.New()
+	_, err = io.Copy(io.MultiWriter(out, digest), reader)
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	if actual := digest.Sum(nil); !bytes.Equal(actual, download.Checksum) {
+		return ChecksumError{Expect: download.Checksum, Actual: actual}
+	}
+
+	if err := os.Rename(tmp, cached); err != nil {
+		return err
+	}
+	return os.Remove(partial)
+}
+
+// progressReader logs the progress of a download periodically as it's read.
+type progressReader struct {
+	reader   io.Reader
+	name     string
+	read     int64
+	total    int64
+	interval time.Duration
+	next     time.Time
+	logger   *zap.Logger
+}
+
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.reader.Read(b)
+	p.read += int64(n)
+	if now := time.Now(); now.After(p.next) {
+		p.next = now.Add(p.interval)
+		fields := []zap.Field{zap.String("artifact", p.name), zap.Int64("bytes", p.read)}
+		if p.total > 0 {
+			fields = append(fields, zap.Int64("total", p.total), zap.String("progress", fmt.Sprintf("%d%%", p.read*100/p.total)))
+		}
+		p.logger.Info("Downloading artifact...", fields...)
+	}
+	return n, err
+}
@@ -0,0 +1,179 @@
+package artifact_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+
+	"github.com/aws/eks-hybrid/internal/artifact"
+)
+
+type artifactServer struct {
+	*httptest.Server
+	requests atomic.Int32
+
+	mu     sync.Mutex
+	ranges []string
+}
+
+func newArtifactServer(t *testing.T, files map[string][]byte) *artifactServer {
+	s := &artifactServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.requests.Add(1)
+		s.mu.Lock()
+		s.ranges = append(s.ranges, r.Header.Get("Range"))
+		s.mu.Unlock()
+		data, ok := files[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		http.ServeContent(w, r, r.URL.Path, time.Time{}, bytes.NewReader(data))
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func checksum(data []byte) []byte {
+	sum := sha256.Sum256(data)
+	return sum[:]
+}
+
+func hexChecksum(data []byte) string {
+	return hex.EncodeToString(checksum(data))
+}
+
+func TestDownloaderGet(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	data := []byte("kubelet binary")
+	server := newArtifactServer(t, map[string][]byte{"/kubelet": data})
+	downloader := artifact.NewDownloader(zap.NewNop(), artifact.WithCacheDir(t.TempDir()))
+	download := artifact.Download{Name: "kubelet", URL: server.URL + "/kubelet", Checksum: checksum(data)}
+
+	path, err := downloader.Get(ctx, download)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(os.ReadFile(path)).To(Equal(data))
+
+	// Cached artifacts are not downloaded again.
+	path, err = downloader.Get(ctx, download)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(os.ReadFile(path)).To(Equal(data))
+	g.Expect(server.requests.Load()).To(Equal(int32(1)))
+}
+
+func TestDownloaderGetResumesPartialDownload(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	data := []byte("0123456789abcdefghij")
+	server := newArtifactServer(t, map[string][]byte{"/kubectl": data})
+	cacheDir := t.TempDir()
+	downloader := artifact.NewDownloader(zap.NewNop(), artifact.WithCacheDir(cacheDir))
+	download := artifact.Download{Name: "kubectl", URL: server.URL + "/kubectl", Checksum: checksum(data)}
+
+	partial := filepath.Join(cacheDir, "partial", hexChecksum(data))
+	g.Expect(os.MkdirAll(filepath.Dir(partial), 0o755)).To(Succeed())
+	g.Expect(os.WriteFile(partial, data[:8], 0o644)).To(Succeed())
+
+	path, err := downloader.Get(ctx, download)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(os.ReadFile(path)).To(Equal(data))
+	g.Expect(server.ranges).To(Equal([]string{"bytes=8-"}))
+	g.Expect(partial).NotTo(BeAnExistingFile())
+}
+
+func TestDownloaderGetGzipped(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	data := []byte("cni plugins")
+	var gzipped bytes.Buffer
+	gzipWriter := gzip.NewWriter(&gzipped)
+	_, err := gzipWriter.Write(data)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(gzipWriter.Close()).To(Succeed())
+	server := newArtifactServer(t, map[string][]byte{"/cni.gz": gzipped.Bytes()})
+	downloader := artifact.NewDownloader(zap.NewNop(), artifact.WithCacheDir(t.TempDir()))
+
+	source, err := downloader.Open(ctx, artifact.Download{Name: "cni-plugins", URL: server.URL + "/cni.gz", Gzipped: true, Checksum: checksum(data)})
+	g.Expect(err).NotTo(HaveOccurred())
+	defer source.Close()
+	g.Expect(io.ReadAll(source)).To(Equal(data))
+	g.Expect(source.VerifyChecksum()).To(BeTrue())
+}
+
+func TestDownloaderGetChecksumMismatch(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	server := newArtifactServer(t, map[string][]byte{"/kubelet": []byte("tampered")})
+	cacheDir := t.TempDir()
+	downloader := artifact.NewDownloader(zap.NewNop(), artifact.WithCacheDir(cacheDir), artifact.WithDownloadAttempts(2, time.Millisecond))
+	expected := []byte("kubelet binary")
+
+	_, err := downloader.Get(ctx, artifact.Download{Name: "kubelet", URL: server.URL + "/kubelet", Checksum: checksum(expected)})
+	g.Expect(err).To(MatchError(artifact.ChecksumError{}))
+	g.Expect(server.requests.Load()).To(Equal(int32(2)))
+	g.Expect(filepath.Join(cacheDir, "sha256", hexChecksum(expected))).NotTo(BeAnExistingFile())
+}
+
+func TestDownloaderPrune(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	files := map[string][]byte{
+		"/kubelet-1.30": []byte("kubelet 1.30"),
+		"/kubelet-1.31": []byte("kubelet 1.31"),
+	}
+	server := newArtifactServer(t, files)
+	cacheDir := t.TempDir()
+	downloader := artifact.NewDownloader(zap.NewNop(), artifact.WithCacheDir(cacheDir))
+
+	for path, data := range files {
+		_, err := downloader.Get(ctx, artifact.Download{Name: path, URL: server.URL + path, Checksum: checksum(data)})
+		g.Expect(err).NotTo(HaveOccurred())
+	}
+	partial := filepath.Join(cacheDir, "partial", hexChecksum([]byte("kubectl"))+".gz")
+	g.Expect(os.WriteFile(partial, []byte("kub"), 0o644)).To(Succeed())
+
+	g.Expect(downloader.Prune(checksum(files["/kubelet-1.31"]))).To(Succeed())
+	g.Expect(filepath.Join(cacheDir, "sha256", hexChecksum(files["/kubelet-1.31"]))).To(BeAnExistingFile())
+	g.Expect(filepath.Join(cacheDir, "sha256", hexChecksum(files["/kubelet-1.30"]))).NotTo(BeAnExistingFile())
+	g.Expect(partial).NotTo(BeAnExistingFile())
+
+	// Pruning an empty cache is a no-op.
+	g.Expect(artifact.NewDownloader(zap.NewNop(), artifact.WithCacheDir(t.TempDir())).Prune()).To(Succeed())
+}
+
+func TestDownloaderGetAll(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	files := map[string][]byte{
+		"/kubelet": []byte("kubelet"),
+		"/kubectl": []byte("kubectl"),
+		"/cni":     []byte("cni"),
+	}
+	server := newArtifactServer(t, files)
+	downloader := artifact.NewDownloader(zap.NewNop(), artifact.WithCacheDir(t.TempDir()), artifact.WithDownloadWorkers(2),
+		artifact.WithDownloadAttempts(1, 0))
+
+	var downloads []artifact.Download
+	for path, data := range files {
+		downloads = append(downloads, artifact.Download{Name: path, URL: server.URL + path, Checksum: checksum(data)})
+	}
+	g.Expect(downloader.GetAll(ctx, downloads...)).To(Succeed())
+
+	err := downloader.GetAll(ctx, artifact.Download{Name: "missing", URL: server.URL + "/missing", Checksum: checksum([]byte("missing"))})
+	g.Expect(err).To(MatchError(ContainSubstring("unexpected status code 404")))
+}
@@ -0,0 +1,266 @@
+package artifact
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// Fetcher opens artifacts from a kind of storage, identified by the scheme of their URI.
+type Fetcher interface {
+	Fetch(ctx context.Context, uri *url.URL) (io.ReadCloser, error)
+}
+
+// RangeFetcher is a Fetcher that can resume reading an artifact from an offset.
+type RangeFetcher interface {
+	Fetcher
+	// FetchRange opens the artifact from offset. If the storage ignores the offset,
+	// it returns the whole artifact and resumed is false. If offset is the size of
+	// the artifact, it returns an empty reader.
+	FetchRange(ctx context.Context, uri *url.URL, offset int64) (body io.ReadCloser, resumed bool, err error)
+}
+
+// FetcherFunc adapts a function to a Fetcher.
+type FetcherFunc func(ctx context.Context, uri *url.URL) (io.ReadCloser, error)
+
+func (f FetcherFunc) Fetch(ctx context.Context, uri *url.URL) (io.ReadCloser, error) {
+	return f(ctx, uri)
+}
+
+// Fetchers selects the Fetcher for an artifact URI by its scheme.
+type Fetchers map[string]Fetcher
+
+// DefaultFetchers returns the fetchers for file:// URIs and http(s):// URIs
+// with the default HTTP client.
+func DefaultFetchers() Fetchers {
+	httpFetcher := NewHTTPFetcher()
+	return Fetchers{
+		"file":  FetcherFunc(fetchFile),
+		"http":  httpFetcher,
+		"https": httpFetcher,
+	}
+}
+
+// For returns the Fetcher for the scheme of uri.
+func (f Fetchers) For(uri string) (Fetcher, *url.URL, error) {
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		return nil, nil, fmt.Errorf("parsing artifact uri %s: %w", uri, err)
+	}
+	fetcher, ok := f[parsed.Scheme]
+	if !ok {
+		return nil, nil, fmt.Errorf("unsupported scheme %q in artifact uri %s", parsed.Scheme, uri)
+	}
+	return fetcher, parsed, nil
+}
+
+// Open opens the artifact at uri with the Fetcher for its scheme.
+func (f Fetchers) Open(ctx context.Context, uri string) (io.ReadCloser, error) {
+	fetcher, parsed, err := f.For(uri)
+	if err != nil {
+		return nil, err
+	}
+	return fetcher.Fetch(ctx, parsed)
+}
+
+// Read reads the artifact at uri with the Fetcher for its scheme.
+func (f Fetchers) Read(ctx context.Context, uri string) ([]byte, error) {
+	reader, err := f.Open(ctx, uri)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", uri, err)
+	}
+	return data, nil
+}
+
+func fetchFile(_ context.Context, uri *url.URL) (io.ReadCloser, error) {
+	return os.Open(uri.Host + uri.Path)
+}
+
+const (
+	defaultFetchAttempts = 3
+	defaultFetchBackoff  = 2 * time.Second
+)
+
+// HTTPFetcher fetches artifacts over HTTP. Requests that fail or get a server
+// error are retried.
+type HTTPFetcher struct {
+	client    *http.Client
+	userAgent string
+	auth      func(*http.Request)
+	authHosts []string
+	attempts  int
+	backoff   time.Duration
+}
+
+// HTTPFetcherOpt configures an HTTPFetcher.
+type HTTPFetcherOpt func(*HTTPFetcher)
+
+// WithClient sets the client used to send the requests.
+func WithClient(client *http.Client) HTTPFetcherOpt {
+	return func(f *HTTPFetcher) {
+		f.client = client
+	}
+}
+
+// WithBasicAuth authenticates requests with a username and password.
+func WithBasicAuth(username, password string) HTTPFetcherOpt {
+	return func(f *HTTPFetcher) {
+		f.auth = func(r *http.Request) {
+			r.SetBasicAuth(username, password)
+		}
+	}
+}
+
+// WithBearerToken authenticates requests with a bearer token.
+func WithBearerToken(token string) HTTPFetcherOpt {
+	return func(f *HTTPFetcher) {
+		f.auth = func(r *http.Request) {
+			r.Header.Set("Authorization", "Bearer "+token)
+		}
+	}
+}
+
+// WithAuthHosts restricts the credentials set with WithBasicAuth or WithBearerToken
+// to requests to hosts, given as host or host:port. Without it, no request is
+// authenticated, so credentials for a mirror aren't sent to other servers.
+func WithAuthHosts(hosts ...string) HTTPFetcherOpt {
+	return func(f *HTTPFetcher) {
+		f.authHosts = hosts
+	}
+}
+
+// WithHTTPUserAgent sets the User-Agent header of the requests.
+func WithHTTPUserAgent(userAgent string) HTTPFetcherOpt {
+	return func(f *HTTPFetcher) {
+		f.userAgent = userAgent
+	}
+}
+
+// WithFetchAttempts sets how many times Fetch sends a request before failing and
+// the wait between attempts.
+func WithFetchAttempts(attempts int, backoff time.Duration) HTTPFetcherOpt {
+	return func(f *HTTPFetcher) {
+		f.attempts = max(attempts, 1)
+		f.backoff = backoff
+	}
+}
+
+func NewHTTPFetcher(opts ...HTTPFetcherOpt) *HTTPFetcher {
+	f := &HTTPFetcher{
+		client:   http.DefaultClient,
+		attempts: defaultFetchAttempts,
+		backoff:  defaultFetchBackoff,
+	}
+	for _, opt := range opts {
+		opt(f)
+	}
+	return f
+}
+
+// Client returns the HTTP client used by the fetcher.
+func (f *HTTPFetcher) Client() *http.Client {
+	return f.client
+}
+
+func (f *HTTPFetcher) Fetch(ctx context.Context, uri *url.URL) (io.ReadCloser, error) {
+	var err error
+	for attempt := 1; attempt <= f.attempts; attempt++ {
+		if attempt > 1 {
+			select {
+			case <-time.After(f.backoff):
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+		var resp *http.Response
+		resp, err = f.do(ctx, uri, 0)
+		if err != nil {
+			continue
+		}
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			err = fmt.Errorf("unexpected status code %d downloading %s", resp.StatusCode, uri.Redacted())
+			if resp.StatusCode < http.StatusInternalServerError && resp.StatusCode != http.StatusTooManyRequests {
+				// Client errors, like a missing artifact or bad credentials, won't go away on retry.
+				return nil, err
+			}
+			continue
+		}
+		return withSize(resp), nil
+	}
+	return nil, err
+}
+
+func (f *HTTPFetcher) FetchRange(ctx context.Context, uri *url.URL, offset int64) (io.ReadCloser, bool, error) {
+	resp, err := f.do(ctx, uri, offset)
+	if err != nil {
+		return nil, false, err
+	}
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+		return withSize(resp), true, nil
+	case http.StatusOK:
+		// The server doesn't support ranges or there was nothing to resume.
+		return withSize(resp), false, nil
+	case http.StatusRequestedRangeNotSatisfiable:
+		// The offset is already at the end of the artifact.
+		resp.Body.Close()
+		return http.NoBody, true, nil
+	default:
+		resp.Body.Close()
+		return nil, false, fmt.Errorf("unexpected status code %d downloading %s", resp.StatusCode, uri.Redacted())
+	}
+}
+
+func (f *HTTPFetcher) do(ctx context.Context, uri *url.URL, offset int64) (*http.Response, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, uri.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	if offset > 0 {
+		request.Header.Set("Range", "bytes="+strconv.FormatInt(offset, 10)+"-")
+	}
+	return f.Do(request)
+}
+
+// Do sends request with the fetcher's client, user agent and credentials. Unlike
+// Fetch, it doesn't retry or check the status code of the response.
+func (f *HTTPFetcher) Do(request *http.Request) (*http.Response, error) {
+	if f.userAgent != "" {
+		request.Header.Set("User-Agent", f.userAgent)
+	}
+	if f.auth != nil && slices.ContainsFunc(f.authHosts, func(host string) bool {
+		return host == request.URL.Host || host == request.URL.Hostname()
+	}) {
+		f.auth(request)
+	}
+	return f.client.Do(request)
+}
+
+// sizedReadCloser is a body with a known size, used to log the progress of downloads.
+type sizedReadCloser struct {
+	io.ReadCloser
+	size int64
+}
+
+func (s sizedReadCloser) Size() int64 {
+	return s.size
+}
+
+func withSize(resp *http.Response) io.ReadCloser {
+	if resp.ContentLength < 0 {
+		return resp.Body
+	}
+	return sizedReadCloser{ReadCloser: resp.Body, size: resp.ContentLength}
+}
@@ -0,0 +1,113 @@
+package artifact_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-hybrid/internal/artifact"
+)
+
+func TestFetchersOpen(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "kubelet")
+	g.Expect(os.WriteFile(path, []byte("kubelet"), 0o644)).To(Succeed())
+
+	fetchers := artifact.DefaultFetchers()
+	g.Expect(fetchers.Read(ctx, "file://"+path)).To(Equal([]byte("kubelet")))
+
+	_, err := fetchers.Open(ctx, "ftp://example.com/kubelet")
+	g.Expect(err).To(MatchError(ContainSubstring(`unsupported scheme "ftp"`)))
+}
+
+func TestHTTPFetcherAuth(t *testing.T) {
+	tests := []struct {
+		name       string
+		opts       []artifact.HTTPFetcherOpt
+		wantHeader string
+	}{
+		{
+			name:       "basic auth",
+			opts:       []artifact.HTTPFetcherOpt{artifact.WithBasicAuth("user", "pass")},
+			wantHeader: "Basic dXNlcjpwYXNz",
+		},
+		{
+			name:       "bearer token",
+			opts:       []artifact.HTTPFetcherOpt{artifact.WithBearerToken("token")},
+			wantHeader: "Bearer token",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := context.Background()
+			server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get("Authorization") != tc.wantHeader {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				w.Write([]byte("kubelet"))
+			}))
+			defer server.Close()
+
+			serverURL, err := url.Parse(server.URL)
+			g.Expect(err).NotTo(HaveOccurred())
+
+			opts := append([]artifact.HTTPFetcherOpt{artifact.WithClient(server.Client()), artifact.WithFetchAttempts(1, 0)}, tc.opts...)
+			fetchers := artifact.Fetchers{"https": artifact.NewHTTPFetcher(append(opts, artifact.WithAuthHosts(serverURL.Host))...)}
+			g.Expect(fetchers.Read(ctx, server.URL+"/kubelet")).To(Equal([]byte("kubelet")))
+
+			// credentials aren't sent to other hosts
+			fetchers = artifact.Fetchers{"https": artifact.NewHTTPFetcher(append(opts, artifact.WithAuthHosts("mirror.example.com"))...)}
+			_, err = fetchers.Read(ctx, server.URL+"/kubelet")
+			g.Expect(err).To(MatchError(ContainSubstring("unexpected status code 401")))
+
+			fetchers = artifact.Fetchers{"https": artifact.NewHTTPFetcher(artifact.WithClient(server.Client()), artifact.WithFetchAttempts(1, 0))}
+			_, err = fetchers.Read(ctx, server.URL+"/kubelet")
+			g.Expect(err).To(MatchError(ContainSubstring("unexpected status code 401")))
+		})
+	}
+}
+
+func TestHTTPFetcherRetries(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte("kubelet"))
+	}))
+	defer server.Close()
+
+	fetchers := artifact.Fetchers{"http": artifact.NewHTTPFetcher(artifact.WithFetchAttempts(2, time.Millisecond))}
+	g.Expect(fetchers.Read(ctx, server.URL+"/kubelet")).To(Equal([]byte("kubelet")))
+	g.Expect(requests).To(Equal(2))
+}
+
+func TestHTTPFetcherDoesNotRetryClientErrors(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	fetchers := artifact.Fetchers{"http": artifact.NewHTTPFetcher(artifact.WithFetchAttempts(3, time.Millisecond))}
+	_, err := fetchers.Read(ctx, server.URL+"/kubelet")
+	g.Expect(err).To(MatchError(ContainSubstring("unexpected status code 404")))
+	g.Expect(requests).To(Equal(1))
+}
@@ -38,22 +38,25 @@ func InstallFile(dst string, src io.Reader, perms fs.FileMode) error {
 	return err
 }
 
-// InstallTarGz untars the src file into the dst directory and deletes the src tgz file
-func InstallTarGz(dst, src string) error {
+// InstallTarGz untars the src file into the dst directory and deletes the src tgz file.
+// It returns the paths of the files it installed.
+func InstallTarGz(dst, src string) ([]string, error) {
 	if err := os.MkdirAll(dst, DefaultDirPerms); err != nil {
-		return err
+		return nil, err
 	}
 	reader, err := os.Open(src)
 	if err != nil {
-		return errors.Wrap(err, "opening source file")
+		return nil, errors.Wrap(err, "opening source file")
 	}
 	defer reader.Close()
 	gzr, err := gzip.NewReader(reader)
 	if err != nil {
-		return errors.Wrap(err, "creating gzip reader")
+		return nil, errors.Wrap(err, "creating gzip reader")
 	}
 	defer gzr.Close()
 
+	var installed []string
+
 	tr := tar.NewReader(gzr)
 	for {
 		header, err := tr.Next()
@@ -61,40 +64,41 @@ func InstallTarGz(dst, src string) error {
 			// no more files
 			break
 		} else if err != nil {
-			return errors.Wrap(err, "reading tar file")
+			return nil, errors.Wrap(err, "reading tar file")
 		} else if header == nil {
 			continue
 		}
 
 		if !validRelPath(header.Name) {
-			return fmt.Errorf("tar contained invalid name error %q", header.Name)
+			return nil, fmt.Errorf("tar contained invalid name error %q", header.Name)
 		}
 
 		target := filepath.Join(dst, header.Name)
 		info := header.FileInfo()
 		if info.IsDir() {
 			if err := os.MkdirAll(target, info.Mode()); err != nil {
-				return errors.Wrap(err, "creating directory")
+				return nil, errors.Wrap(err, "creating directory")
 			}
 			continue
 		}
 
 		f, err := os.OpenFile(target, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, info.Mode())
 		if err != nil {
-			return errors.Wrap(err, "creating file")
+			return nil, errors.Wrap(err, "creating file")
 		}
 		defer f.Close()
 
 		if _, err := io.Copy(f, tr); err != nil {
-			return errors.Wrap(err, "copying file contents")
+			return nil, errors.Wrap(err, "copying file contents")
 		}
+		installed = append(installed, target)
 	}
 
 	// Remove the tgz file
 	if err := os.Remove(src); err != nil {
-		return errors.Wrap(err, "removing source file")
+		return nil, errors.Wrap(err, "removing source file")
 	}
-	return nil
+	return installed, nil
 }
 
 func validRelPath(p string) bool {
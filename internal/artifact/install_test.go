@@ -283,7 +283,7 @@ func TestInstallTarGz(t *testing.T) {
 				tc.setup(g, dst)
 			}
 
-			err := artifact.InstallTarGz(dst, src)
+			installed, err := artifact.InstallTarGz(dst, src)
 			if tc.wantErr != "" {
 				g.Expect(err).To(HaveOccurred())
 				g.Expect(err).To(MatchError(ContainSubstring(tc.wantErr)))
@@ -303,8 +303,10 @@ func TestInstallTarGz(t *testing.T) {
 			g.Expect(os.Stat(src)).Error().To(MatchError(ContainSubstring("no such file or directory")), "source file not removed after extraction")
 
 			// Generic verification: check all files and their parent directories
+			g.Expect(installed).To(HaveLen(len(tc.files)))
 			for p, file := range tc.files {
 				fullPath := filepath.Join(dst, p)
+				g.Expect(installed).To(ContainElement(fullPath))
 				content, err := os.ReadFile(fullPath)
 				g.Expect(err).NotTo(HaveOccurred(), "%s not extracted", p)
 				g.Expect(string(content)).To(Equal(file.content), "%s content mismatch", p)
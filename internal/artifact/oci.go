@@ -0,0 +1,297 @@
+package artifact
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+const ociManifestMediaTypes = "application/vnd.oci.image.manifest.v1+json, application/vnd.docker.distribution.manifest.v2+json"
+
+// RegistryCredentials returns the username and password to authenticate to a registry.
+// An empty username means anonymous access.
+type RegistryCredentials func(ctx context.Context, registry string) (username, password string, err error)
+
+// OCIFetcher fetches artifacts stored as blobs in an OCI registry, with URIs of the form
+// oci://<registry>/<repository>@sha256:<digest> or oci://<registry>/<repository>:<tag>.
+// A tag must point to an image manifest with a single layer, which is the artifact.
+// It also pushes artifacts to registries, see PushArtifact.
+type OCIFetcher struct {
+	client      *http.Client
+	userAgent   string
+	credentials RegistryCredentials
+
+	// authorizations caches the Authorization header for each repository.
+	authorizations sync.Map
+}
+
+// OCIFetcherOpt configures an OCIFetcher.
+type OCIFetcherOpt func(*OCIFetcher)
+
+// WithOCIClient sets the client used to send requests to registries.
+func WithOCIClient(client *http.Client) OCIFetcherOpt {
+	return func(f *OCIFetcher) {
+		f.client = client
+	}
+}
+
+// WithRegistryCredentials sets the credentials used when a registry requires authentication.
+func WithRegistryCredentials(credentials RegistryCredentials) OCIFetcherOpt {
+	return func(f *OCIFetcher) {
+		f.credentials = credentials
+	}
+}
+
+// WithOCIUserAgent sets the User-Agent header of the requests.
+func WithOCIUserAgent(userAgent string) OCIFetcherOpt {
+	return func(f *OCIFetcher) {
+		f.userAgent = userAgent
+	}
+}
+
+func NewOCIFetcher(opts ...OCIFetcherOpt) *OCIFetcher {
+	f := &OCIFetcher{
+		client: http.DefaultClient,
+		credentials: func(context.Context, string) (string, string, error) {
+			return "", "", nil
+		},
+	}
+	for _, opt := range opts {
+		opt(f)
+	}
+	return f
+}
+
+type ociReference struct {
+	registry   string
+	repository string
+	tag        string
+	digest     string
+}
+
+func parseOCIReference(uri *url.URL) (ociReference, error) {
+	ref := ociReference{registry: uri.Host}
+	path := strings.TrimPrefix(uri.Path, "/")
+	if repository, digest, found := strings.Cut(path, "@"); found {
+		ref.repository, ref.digest = repository, digest
+	} else if i := strings.LastIndex(path, ":"); i > strings.LastIndex(path, "/") {
+		ref.repository, ref.tag = path[:i], path[i+1:]
+	}
+	if ref.registry == "" || ref.repository == "" || (ref.tag == "" && ref.digest == "") {
+		return ociReference{}, fmt.Errorf("invalid oci artifact uri %s, expected oci://<registry>/<repository>@<digest> or oci://<registry>/<repository>:<tag>", uri)
+	}
+	return ref, nil
+}
+
+func (r ociReference) endpoint(path string) string {
+	return fmt.Sprintf("https://%s/v2/%s/%s", r.registry, r.repository, path)
+}
+
+func (f *OCIFetcher) Fetch(ctx context.Context, uri *url.URL) (io.ReadCloser, error) {
+	ref, err := parseOCIReference(uri)
+	if err != nil {
+		return nil, err
+	}
+	digest := ref.digest
+	if digest == "" {
+		if digest, err = f.resolveTag(ctx, ref); err != nil {
+			return nil, err
+		}
+	}
+	resp, err := f.get(ctx, ref, "blobs/"+digest, "")
+	if err != nil {
+		return nil, err
+	}
+	return withSize(resp), nil
+}
+
+// resolveTag returns the digest of the single layer of the manifest the tag points to.
+func (f *OCIFetcher) resolveTag(ctx context.Context, ref ociReference) (string, error) {
+	resp, err := f.get(ctx, ref, "manifests/"+ref.tag, ociManifestMediaTypes)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var manifest struct {
+		Layers []struct {
+			Digest string `json:"digest"`
+		} `json:"layers"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&manifest); err != nil {
+		return "", fmt.Errorf("decoding manifest of %s/%s:%s: %w", ref.registry, ref.repository, ref.tag, err)
+	}
+	if len(manifest.Layers) != 1 {
+		return "", fmt.Errorf("manifest of %s/%s:%s has %d layers, expected a single layer with the artifact", ref.registry, ref.repository, ref.tag, len(manifest.Layers))
+	}
+	return manifest.Layers[0].Digest, nil
+}
+
+// get sends a GET request to the registry API for the repository and fails if it
+// doesn't succeed.
+func (f *OCIFetcher) get(ctx context.Context, ref ociReference, path, accept string) (*http.Response, error) {
+	endpoint := ref.endpoint(path)
+	header := http.Header{}
+	if accept != "" {
+		header.Set("Accept", accept)
+	}
+	resp, err := f.do(ctx, ref, http.MethodGet, endpoint, header, nil)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("unexpected status code %d fetching %s", resp.StatusCode, endpoint)
+	}
+	return resp, nil
+}
+
+// do sends a request to the registry API for the repository, authenticating
+// if the registry challenges the request.
+func (f *OCIFetcher) do(ctx context.Context, ref ociReference, method, endpoint string, header http.Header, body io.ReadSeeker) (*http.Response, error) {
+	key := ref.registry + "/" + ref.repository
+	cached, _ := f.authorizations.Load(key)
+	authorization, _ := cached.(string)
+	resp, err := f.send(ctx, method, endpoint, header, body, authorization)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		challenge := resp.Header.Get("WWW-Authenticate")
+		resp.Body.Close()
+		newAuthorization, err := f.authenticate(ctx, ref, challenge)
+		if err != nil {
+			return nil, fmt.Errorf("authenticating to %s: %w", ref.registry, err)
+		}
+		f.authorizations.Store(key, newAuthorization)
+		if resp, err = f.send(ctx, method, endpoint, header, body, newAuthorization); err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
+}
+
+// send sends a request to the registry. body is sent from the start, so the same
+// body can be sent again after authenticating.
+func (f *OCIFetcher) send(ctx context.Context, method, endpoint string, header http.Header, body io.ReadSeeker, authorization string) (*http.Response, error) {
+	request, err := http.NewRequestWithContext(ctx, method, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		if request.ContentLength, err = body.Seek(0, io.SeekEnd); err != nil {
+			return nil, err
+		}
+		if _, err := body.Seek(0, io.SeekStart); err != nil {
+			return nil, err
+		}
+		request.Body = io.NopCloser(body)
+	}
+	for name, values := range header {
+		request.Header[name] = values
+	}
+	if f.userAgent != "" {
+		request.Header.Set("User-Agent", f.userAgent)
+	}
+	if authorization != "" {
+		request.Header.Set("Authorization", authorization)
+	}
+	return f.client.Do(request)
+}
+
+var challengeParam = regexp.MustCompile(`(\w+)="([^"]*)"`)
+
+// authenticate returns the Authorization header that satisfies the registry's
+// WWW-Authenticate challenge, requesting a token for Bearer challenges.
+func (f *OCIFetcher) authenticate(ctx context.Context, ref ociReference, challenge string) (string, error) {
+	scheme, params, _ := strings.Cut(challenge, " ")
+	username, password, err := f.credentials(ctx, ref.registry)
+	if err != nil {
+		return "", err
+	}
+
+	switch strings.ToLower(scheme) {
+	case "basic":
+		if username == "" {
+			return "", fmt.Errorf("registry requires credentials")
+		}
+		return "Basic " + base64.StdEncoding.EncodeToString([]byte(username+":"+password)), nil
+	case "bearer":
+		values := map[string]string{}
+		for _, match := range challengeParam.FindAllStringSubmatch(params, -1) {
+			values[match[1]] = match[2]
+		}
+		token, err := f.requestToken(ctx, ref, values, username, password)
+		if err != nil {
+			return "", err
+		}
+		return "Bearer " + token, nil
+	default:
+		return "", fmt.Errorf("unsupported authentication challenge %q", challenge)
+	}
+}
+
+func (f *OCIFetcher) requestToken(ctx context.Context, ref ociReference, challenge map[string]string, username, password string) (string, error) {
+	realm, err := url.Parse(challenge["realm"])
+	if err != nil || realm.Host == "" {
+		return "", fmt.Errorf("invalid token realm %q", challenge["realm"])
+	}
+	if realm.Scheme != "https" {
+		return "", fmt.Errorf("token realm %q must use https", challenge["realm"])
+	}
+	// The realm comes from the registry, so credentials are only sent to a realm on
+	// another host when they're configured for that host too.
+	if realm.Host != ref.registry {
+		if username, password, err = f.credentials(ctx, realm.Host); err != nil {
+			return "", err
+		}
+	}
+	query := realm.Query()
+	if service := challenge["service"]; service != "" {
+		query.Set("service", service)
+	}
+	scope := challenge["scope"]
+	if scope == "" {
+		scope = "repository:" + ref.repository + ":pull"
+	}
+	query.Set("scope", scope)
+	realm.RawQuery = query.Encode()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, realm.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	if username != "" {
+		request.SetBasicAuth(username, password)
+	}
+	resp, err := f.client.Do(request)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status code %d requesting token from %s", resp.StatusCode, realm.Host)
+	}
+
+	var token struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return "", fmt.Errorf("decoding token: %w", err)
+	}
+	if token.Token != "" {
+		return token.Token, nil
+	}
+	if token.AccessToken != "" {
+		return token.AccessToken, nil
+	}
+	return "", fmt.Errorf("token response from %s has no token", realm.Host)
+}
@@ -0,0 +1,196 @@
+package artifact
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	ociManifestMediaType = "application/vnd.oci.image.manifest.v1+json"
+	ociEmptyMediaType    = "application/vnd.oci.empty.v1+json"
+	// ArtifactMediaType is the artifactType of the manifests pushed by PushArtifact.
+	ArtifactMediaType = "application/vnd.aws.eks.hybrid.artifact.v1"
+)
+
+// ociEmptyConfig is the empty JSON object used as config of artifact manifests.
+var ociEmptyConfig = []byte("{}")
+
+type ociDescriptor struct {
+	MediaType   string            `json:"mediaType"`
+	Digest      string            `json:"digest"`
+	Size        int64             `json:"size"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+type ociManifest struct {
+	SchemaVersion int             `json:"schemaVersion"`
+	MediaType     string          `json:"mediaType"`
+	ArtifactType  string          `json:"artifactType"`
+	Config        ociDescriptor   `json:"config"`
+	Layers        []ociDescriptor `json:"layers"`
+}
+
+// OCIRepository is a repository in an OCI registry.
+type OCIRepository struct {
+	Registry   string
+	Repository string
+}
+
+// ParseOCIRepository parses a repository URI of the form oci://<registry>/<repository>.
+func ParseOCIRepository(uri string) (OCIRepository, error) {
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		return OCIRepository{}, fmt.Errorf("parsing oci repository uri %s: %w", uri, err)
+	}
+	repo := OCIRepository{Registry: parsed.Host, Repository: strings.Trim(parsed.Path, "/")}
+	if parsed.Scheme != "oci" || repo.Registry == "" || repo.Repository == "" || strings.ContainsAny(repo.Repository, "@:") {
+		return OCIRepository{}, fmt.Errorf("invalid oci repository uri %s, expected oci://<registry>/<repository>", uri)
+	}
+	return repo, nil
+}
+
+// BlobURI returns the URI of the blob with digest in the repository.
+func (r OCIRepository) BlobURI(digest string) string {
+	return "oci://" + r.Registry + "/" + r.Repository + "@" + digest
+}
+
+func (r OCIRepository) String() string {
+	return r.Registry + "/" + r.Repository
+}
+
+func (r OCIRepository) reference() ociReference {
+	return ociReference{registry: r.Registry, repository: r.Repository}
+}
+
+// BlobExists returns true if the repository has the blob with digest.
+func (f *OCIFetcher) BlobExists(ctx context.Context, repo OCIRepository, digest string) (bool, error) {
+	return f.exists(ctx, repo, "blobs/"+digest, "")
+}
+
+// ManifestExists returns true if the repository has a manifest for reference, a tag or a digest.
+func (f *OCIFetcher) ManifestExists(ctx context.Context, repo OCIRepository, reference string) (bool, error) {
+	return f.exists(ctx, repo, "manifests/"+reference, ociManifestMediaTypes)
+}
+
+func (f *OCIFetcher) exists(ctx context.Context, repo OCIRepository, path, accept string) (bool, error) {
+	ref := repo.reference()
+	header := http.Header{}
+	if accept != "" {
+		header.Set("Accept", accept)
+	}
+	resp, err := f.do(ctx, ref, http.MethodHead, ref.endpoint(path), header, nil)
+	if err != nil {
+		return false, err
+	}
+	resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, fmt.Errorf("unexpected status code %d checking %s", resp.StatusCode, ref.endpoint(path))
+	}
+}
+
+// PushBlob uploads content as the blob with digest in a single request.
+func (f *OCIFetcher) PushBlob(ctx context.Context, repo OCIRepository, digest string, size int64, content io.ReadSeeker) error {
+	ref := repo.reference()
+	uploads := ref.endpoint("blobs/uploads/")
+	resp, err := f.do(ctx, ref, http.MethodPost, uploads, nil, nil)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusAccepted {
+		return fmt.Errorf("unexpected status code %d starting upload to %s", resp.StatusCode, uploads)
+	}
+	location, err := resp.Request.URL.Parse(resp.Header.Get("Location"))
+	if err != nil || resp.Header.Get("Location") == "" {
+		return fmt.Errorf("invalid upload location %q from %s", resp.Header.Get("Location"), repo.Registry)
+	}
+	query := location.Query()
+	query.Set("digest", digest)
+	location.RawQuery = query.Encode()
+
+	header := http.Header{}
+	header.Set("Content-Type", "application/octet-stream")
+	resp, err = f.do(ctx, ref, http.MethodPut, location.String(), header, content)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		return fmt.Errorf("unexpected status code %d uploading blob %s to %s", resp.StatusCode, digest, repo)
+	}
+	return nil
+}
+
+// PushArtifact uploads content as the single layer of an artifact manifest tagged
+// with tag, so registries don't garbage collect the blob. title is the file name
+// recorded in the layer annotations. Blobs already in the repository aren't uploaded again.
+func (f *OCIFetcher) PushArtifact(ctx context.Context, repo OCIRepository, tag, title, digest string, size int64, content io.ReadSeeker) error {
+	configChecksum := sha256.Sum256(ociEmptyConfig)
+	configDigest := "sha256:" + hex.EncodeToString(configChecksum[:])
+	blobs := []struct {
+		digest  string
+		size    int64
+		content io.ReadSeeker
+	}{
+		{digest: digest, size: size, content: content},
+		{digest: configDigest, size: int64(len(ociEmptyConfig)), content: bytes.NewReader(ociEmptyConfig)},
+	}
+	for _, blob := range blobs {
+		exists, err := f.BlobExists(ctx, repo, blob.digest)
+		if err != nil {
+			return err
+		}
+		if exists {
+			continue
+		}
+		if err := f.PushBlob(ctx, repo, blob.digest, blob.size, blob.content); err != nil {
+			return err
+		}
+	}
+
+	manifest, err := json.Marshal(ociManifest{
+		SchemaVersion: 2,
+		MediaType:     ociManifestMediaType,
+		ArtifactType:  ArtifactMediaType,
+		Config: ociDescriptor{
+			MediaType: ociEmptyMediaType,
+			Digest:    configDigest,
+			Size:      int64(len(ociEmptyConfig)),
+		},
+		Layers: []ociDescriptor{{
+			MediaType:   "application/octet-stream",
+			Digest:      digest,
+			Size:        size,
+			Annotations: map[string]string{"org.opencontainers.image.title": title},
+		}},
+	})
+	if err != nil {
+		return err
+	}
+	header := http.Header{}
+	header.Set("Content-Type", ociManifestMediaType)
+	ref := repo.reference()
+	endpoint := ref.endpoint("manifests/" + url.PathEscape(tag))
+	resp, err := f.do(ctx, ref, http.MethodPut, endpoint, header, bytes.NewReader(manifest))
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		return fmt.Errorf("unexpected status code %d pushing manifest %s:%s", resp.StatusCode, repo, tag)
+	}
+	return nil
+}
@@ -0,0 +1,292 @@
+package artifact_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-hybrid/internal/artifact"
+)
+
+func certificatePEM(server *httptest.Server) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+}
+
+// newRegistry returns a registry serving blob with a bearer token flow. The manifest
+// for tag latest points to the blob.
+func newRegistry(t *testing.T, blob []byte) *httptest.Server {
+	digest := "sha256:" + hexChecksum(blob)
+	var server *httptest.Server
+	server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/token":
+			username, password, _ := r.BasicAuth()
+			if username != "user" || password != "pass" || r.URL.Query().Get("scope") != "repository:eks/kubelet:pull" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			fmt.Fprint(w, `{"token":"registry-token"}`)
+		case r.Header.Get("Authorization") != "Bearer registry-token":
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="registry",scope="repository:eks/kubelet:pull"`, server.URL))
+			w.WriteHeader(http.StatusUnauthorized)
+		case r.URL.Path == "/v2/eks/kubelet/manifests/latest":
+			fmt.Fprintf(w, `{"layers":[{"digest":%q}]}`, digest)
+		case r.URL.Path == "/v2/eks/kubelet/blobs/"+digest:
+			w.Write(blob)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestOCIFetcher(t *testing.T) {
+	blob := []byte("kubelet binary")
+	server := newRegistry(t, blob)
+	registry := strings.TrimPrefix(server.URL, "https://")
+	credentials := func(_ context.Context, host string) (string, string, error) {
+		if host != registry {
+			return "", "", fmt.Errorf("unexpected registry %s", host)
+		}
+		return "user", "pass", nil
+	}
+
+	tests := []struct {
+		name    string
+		uri     string
+		wantErr string
+	}{
+		{
+			name: "digest",
+			uri:  "oci://" + registry + "/eks/kubelet@sha256:" + hexChecksum(blob),
+		},
+		{
+			name: "tag",
+			uri:  "oci://" + registry + "/eks/kubelet:latest",
+		},
+		{
+			name:    "missing tag",
+			uri:     "oci://" + registry + "/eks/kubelet:missing",
+			wantErr: "unexpected status code 404",
+		},
+		{
+			name:    "no tag or digest",
+			uri:     "oci://" + registry + "/eks/kubelet",
+			wantErr: "invalid oci artifact uri",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			fetchers := artifact.Fetchers{
+				"oci": artifact.NewOCIFetcher(artifact.WithOCIClient(server.Client()), artifact.WithRegistryCredentials(credentials)),
+			}
+			data, err := fetchers.Read(context.Background(), tc.uri)
+			if tc.wantErr != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tc.wantErr)))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(data).To(Equal(blob))
+		})
+	}
+}
+
+func TestOCIFetcherWithoutCredentials(t *testing.T) {
+	g := NewWithT(t)
+	blob := []byte("kubelet binary")
+	server := newRegistry(t, blob)
+	registry := strings.TrimPrefix(server.URL, "https://")
+	fetchers := artifact.Fetchers{"oci": artifact.NewOCIFetcher(artifact.WithOCIClient(server.Client()))}
+
+	_, err := fetchers.Read(context.Background(), "oci://"+registry+"/eks/kubelet:latest")
+	g.Expect(err).To(MatchError(ContainSubstring("unexpected status code 403 requesting token")))
+}
+
+// newRealmRegistry returns a registry serving blob with tag latest that sends
+// clients to realm for a bearer token and accepts any token.
+func newRealmRegistry(t *testing.T, blob []byte, realm string) *httptest.Server {
+	digest := "sha256:" + hexChecksum(blob)
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Header.Get("Authorization") != "Bearer realm-token":
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s",service="registry"`, realm))
+			w.WriteHeader(http.StatusUnauthorized)
+		case r.URL.Path == "/v2/eks/kubelet/manifests/latest":
+			fmt.Fprintf(w, `{"layers":[{"digest":%q}]}`, digest)
+		case r.URL.Path == "/v2/eks/kubelet/blobs/"+digest:
+			w.Write(blob)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestOCIFetcherTokenRealm(t *testing.T) {
+	blob := []byte("kubelet binary")
+	var lock sync.Mutex
+	var realmAuthorizations []string
+	realm := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		realmAuthorizations = append(realmAuthorizations, r.Header.Get("Authorization"))
+		lock.Unlock()
+		fmt.Fprint(w, `{"token":"realm-token"}`)
+	}))
+	t.Cleanup(realm.Close)
+	realmHost := strings.TrimPrefix(realm.URL, "https://")
+
+	tests := []struct {
+		name               string
+		realm              string
+		realmCredentials   bool
+		wantErr            string
+		wantAuthorizations []string
+	}{
+		{
+			name:               "other host without credentials",
+			realm:              realm.URL + "/token",
+			wantAuthorizations: []string{""},
+		},
+		{
+			name:               "other host with credentials",
+			realm:              realm.URL + "/token",
+			realmCredentials:   true,
+			wantAuthorizations: []string{"Basic " + base64.StdEncoding.EncodeToString([]byte("realm-user:realm-pass"))},
+		},
+		{
+			name:    "plain http",
+			realm:   "http://" + realmHost + "/token",
+			wantErr: "must use https",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			realmAuthorizations = nil
+			server := newRealmRegistry(t, blob, tc.realm)
+			registry := strings.TrimPrefix(server.URL, "https://")
+			credentials := func(_ context.Context, host string) (string, string, error) {
+				switch {
+				case host == registry:
+					return "user", "pass", nil
+				case host == realmHost && tc.realmCredentials:
+					return "realm-user", "realm-pass", nil
+				}
+				return "", "", nil
+			}
+			fetchers := artifact.Fetchers{
+				"oci": artifact.NewOCIFetcher(artifact.WithOCIClient(server.Client()), artifact.WithRegistryCredentials(credentials)),
+			}
+
+			data, err := fetchers.Read(context.Background(), "oci://"+registry+"/eks/kubelet:latest")
+			if tc.wantErr != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tc.wantErr)))
+				g.Expect(realmAuthorizations).To(BeEmpty())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(data).To(Equal(blob))
+			g.Expect(realmAuthorizations).To(Equal(tc.wantAuthorizations))
+		})
+	}
+}
+
+// newPushRegistry returns a registry that stores pushed blobs and manifests in
+// memory and requires basic auth for every request.
+func newPushRegistry(t *testing.T) (*httptest.Server, map[string][]byte) {
+	var lock sync.Mutex
+	stored := map[string][]byte{}
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+		if username, password, _ := r.BasicAuth(); username != "user" || password != "pass" {
+			w.Header().Set("WWW-Authenticate", `Basic realm="registry"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v2/eks/artifacts/blobs/uploads/":
+			w.Header().Set("Location", "/upload/1?state=abc")
+			w.WriteHeader(http.StatusAccepted)
+		case r.Method == http.MethodPut && r.URL.Path == "/upload/1":
+			body, _ := io.ReadAll(r.Body)
+			digest := r.URL.Query().Get("digest")
+			if r.URL.Query().Get("state") != "abc" || digest != "sha256:"+hexChecksum(body) || r.ContentLength != int64(len(body)) {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			stored["/v2/eks/artifacts/blobs/"+digest] = body
+			w.WriteHeader(http.StatusCreated)
+		case r.Method == http.MethodPut:
+			body, _ := io.ReadAll(r.Body)
+			stored[r.URL.Path] = body
+			w.WriteHeader(http.StatusCreated)
+		case r.Method == http.MethodHead || r.Method == http.MethodGet:
+			body, ok := stored[r.URL.Path]
+			if !ok {
+				http.NotFound(w, r)
+				return
+			}
+			w.Write(body)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server, stored
+}
+
+func TestOCIFetcherPushArtifact(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	server, stored := newPushRegistry(t)
+	repo := artifact.OCIRepository{Registry: strings.TrimPrefix(server.URL, "https://"), Repository: "eks/artifacts"}
+	client := artifact.NewOCIFetcher(
+		artifact.WithOCIClient(server.Client()),
+		artifact.WithRegistryCredentials(func(context.Context, string) (string, string, error) {
+			return "user", "pass", nil
+		}),
+	)
+	blob := []byte("kubelet binary")
+	digest := "sha256:" + hexChecksum(blob)
+
+	exists, err := client.BlobExists(ctx, repo, digest)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(exists).To(BeFalse())
+
+	g.Expect(client.PushArtifact(ctx, repo, "kubelet-1.31", "kubelet", digest, int64(len(blob)), bytes.NewReader(blob))).To(Succeed())
+	g.Expect(stored).To(HaveKey("/v2/eks/artifacts/blobs/" + digest))
+	g.Expect(stored).To(HaveKey("/v2/eks/artifacts/blobs/sha256:" + hexChecksum([]byte("{}"))))
+	g.Expect(string(stored["/v2/eks/artifacts/manifests/kubelet-1.31"])).To(ContainSubstring(`"artifactType":"` + artifact.ArtifactMediaType + `"`))
+
+	exists, err = client.ManifestExists(ctx, repo, "kubelet-1.31")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(exists).To(BeTrue())
+
+	fetchers := artifact.Fetchers{"oci": client}
+	g.Expect(fetchers.Read(ctx, repo.BlobURI(digest))).To(Equal(blob))
+	g.Expect(fetchers.Read(ctx, "oci://"+repo.String()+":kubelet-1.31")).To(Equal(blob))
+}
+
+func TestParseOCIRepository(t *testing.T) {
+	g := NewWithT(t)
+	repo, err := artifact.ParseOCIRepository("oci://harbor.example.com/eks/artifacts")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(repo).To(Equal(artifact.OCIRepository{Registry: "harbor.example.com", Repository: "eks/artifacts"}))
+
+	_, err = artifact.ParseOCIRepository("oci://harbor.example.com/eks/artifacts:latest")
+	g.Expect(err).To(MatchError(ContainSubstring("invalid oci repository uri")))
+}
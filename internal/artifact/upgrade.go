@@ -15,17 +15,39 @@ import (
 // checksumMatch compares the checksum of the installed artifact with the expected checksum
 // A mismatch of checksum indicates installed artifacts are due for an upgrade
 func checksumMatch(installedArtifactPath string, src Source) (bool, error) {
-	fh, err := os.Open(installedArtifactPath)
+	actual, err := fileChecksum(installedArtifactPath)
 	if err != nil {
-		return false, errors.Wrap(err, "checking for checksum match")
+		return false, err
+	}
+	return bytes.Equal(actual, src.ExpectedChecksum()), nil
+}
+
+// ChecksumMatches returns true if the checksum of the installed artifact matches the
+// expected checksum, in GNU checksum format. Upgrade skips artifacts whose checksum matches.
+func ChecksumMatches(installedArtifactPath string, expectedChecksum []byte) (bool, error) {
+	expected, err := ParseGNUChecksum(expectedChecksum)
+	if err != nil {
+		return false, fmt.Errorf("parsing expected checksum: %w", err)
+	}
+	actual, err := fileChecksum(installedArtifactPath)
+	if err != nil {
+		return false, err
+	}
+	return bytes.Equal(actual, expected), nil
+}
+
+func fileChecksum(path string) ([]byte, error) {
+	fh, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "checking for checksum match")
 	}
 	defer fh.Close()
 
 	digest := sha256.New()
 	if _, err = io.Copy(digest, fh); err != nil {
-		return false, errors.Wrapf(err, "calculating sha256 for %s", installedArtifactPath)
+		return nil, errors.Wrapf(err, "calculating sha256 for %s", path)
 	}
-	return bytes.Equal(digest.Sum(nil), src.ExpectedChecksum()), nil
+	return digest.Sum(nil), nil
 }
 
 // Upgrade upgrades an artifact from the source only if the expected checksum doesn't match with the
@@ -118,3 +118,19 @@ func TestUpgrade(t *testing.T) {
 		})
 	}
 }
+
+func TestChecksumMatches(t *testing.T) {
+	g := NewWithT(t)
+	dummyFilePath := "testdata/dummyfile"
+
+	match, err := ChecksumMatches(dummyFilePath, []byte("b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9  internal/artifact/testdata/dummyfile"))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(match).To(BeTrue())
+
+	match, err = ChecksumMatches(dummyFilePath, []byte("b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7acabcdcde9 randomfile/path"))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(match).To(BeFalse())
+
+	_, err = ChecksumMatches(dummyFilePath, []byte("invalid"))
+	g.Expect(err).To(MatchError(ContainSubstring("parsing expected checksum")))
+}
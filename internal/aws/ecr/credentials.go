@@ -0,0 +1,53 @@
+package ecr
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/config"
+)
+
+var registryHost = regexp.MustCompile(`^\d{12}\.dkr\.ecr(?:-fips)?\.([a-z0-9-]+)\.`)
+
+// RegistryRegion returns the region of an ECR registry host and whether the host
+// is an ECR registry.
+func RegistryRegion(registry string) (string, bool) {
+	match := registryHost.FindStringSubmatch(registry)
+	if match == nil {
+		return "", false
+	}
+	return match[1], true
+}
+
+// RegistryCredentials returns the username and password to pull from an ECR registry
+// with the default AWS credential chain. Registries outside ECR get empty credentials.
+func RegistryCredentials(ctx context.Context, registry string) (string, string, error) {
+	region, ok := RegistryRegion(registry)
+	if !ok {
+		return "", "", nil
+	}
+	awsConfig, err := config.LoadDefaultConfig(ctx, config.WithRegion(region))
+	if err != nil {
+		return "", "", fmt.Errorf("loading AWS config for %s: %w", registry, err)
+	}
+	token, err := GetAuthorizationToken(&awsConfig)
+	if err != nil {
+		return "", "", fmt.Errorf("getting ECR authorization token for %s: %w", registry, err)
+	}
+	return decodeAuthorizationToken(token)
+}
+
+func decodeAuthorizationToken(token string) (string, string, error) {
+	decoded, err := base64.StdEncoding.DecodeString(token)
+	if err != nil {
+		return "", "", fmt.Errorf("decoding ECR authorization token: %w", err)
+	}
+	username, password, found := strings.Cut(string(decoded), ":")
+	if !found {
+		return "", "", fmt.Errorf("invalid ECR authorization token")
+	}
+	return username, password, nil
+}
@@ -176,3 +176,39 @@ func TestGetEKSRegistryCoordinates(t *testing.T) {
 		})
 	}
 }
+
+func TestRegistryRegion(t *testing.T) {
+	tests := []struct {
+		registry     string
+		expectRegion string
+		expectECR    bool
+	}{
+		{registry: "123456789012.dkr.ecr.us-west-2.amazonaws.com", expectRegion: "us-west-2", expectECR: true},
+		{registry: "123456789012.dkr.ecr-fips.us-gov-west-1.amazonaws.com", expectRegion: "us-gov-west-1", expectECR: true},
+		{registry: "123456789012.dkr.ecr.cn-north-1.amazonaws.com.cn", expectRegion: "cn-north-1", expectECR: true},
+		{registry: "harbor.example.com", expectECR: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.registry, func(t *testing.T) {
+			region, ok := RegistryRegion(tt.registry)
+			if ok != tt.expectECR || region != tt.expectRegion {
+				t.Errorf("RegistryRegion(%q) = %q, %v; want %q, %v", tt.registry, region, ok, tt.expectRegion, tt.expectECR)
+			}
+		})
+	}
+}
+
+func TestDecodeAuthorizationToken(t *testing.T) {
+	username, password, err := decodeAuthorizationToken("QVdTOnNlY3JldDpwYXJ0")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if username != "AWS" || password != "secret:part" {
+		t.Errorf("got %q, %q; want %q, %q", username, password, "AWS", "secret:part")
+	}
+
+	if _, _, err := decodeAuthorizationToken("bm9jb2xvbg=="); err == nil {
+		t.Error("expected error for token without separator")
+	}
+}
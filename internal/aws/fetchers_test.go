@@ -0,0 +1,59 @@
+package aws
+
+import (
+	"context"
+	"io"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/aws/eks-hybrid/internal/artifact"
+)
+
+func TestManifestAndSourceWithFetchers(t *testing.T) {
+	ctx := context.Background()
+	objects := map[string]string{
+		"/manifest.yaml": `supported_eks_releases:
+- major_minor_version: "1.31"
+  latest_patch_version: "1.31.2"
+  patch_releases:
+  - version: "1.31.2"
+    artifacts:
+    - name: kubelet
+      os: linux
+      arch: amd64
+      uri: mirror://artifacts/kubelet
+      checksum_uri: mirror://artifacts/kubelet.sha256
+`,
+		"/kubelet.sha256": "0123456789abcdef  kubelet\n",
+	}
+	var fetched []string
+	fetchers := artifact.Fetchers{
+		"mirror": artifact.FetcherFunc(func(_ context.Context, uri *url.URL) (io.ReadCloser, error) {
+			fetched = append(fetched, uri.String())
+			return io.NopCloser(strings.NewReader(objects[uri.Path])), nil
+		}),
+	}
+
+	manifest, err := GetManifest(ctx, "us-west-2", "mirror://artifacts/manifest.yaml", WithFetchers(fetchers))
+	if err != nil {
+		t.Fatalf("Failed to read manifest with fetchers: %v", err)
+	}
+	if len(manifest.SupportedEksReleases) != 1 {
+		t.Fatalf("Expected 1 EKS release, got %d", len(manifest.SupportedEksReleases))
+	}
+
+	source := Source{Fetchers: fetchers}
+	checksum, err := source.Checksum(ctx, manifest.SupportedEksReleases[0].PatchReleases[0].Artifacts[0])
+	if err != nil {
+		t.Fatalf("Failed to read checksum with fetchers: %v", err)
+	}
+	if string(checksum) != objects["/kubelet.sha256"] {
+		t.Errorf("Expected checksum %q, got %q", objects["/kubelet.sha256"], checksum)
+	}
+
+	want := []string{"mirror://artifacts/manifest.yaml", "mirror://artifacts/kubelet.sha256"}
+	if strings.Join(fetched, ",") != strings.Join(want, ",") {
+		t.Errorf("Expected fetched URIs %v, got %v", want, fetched)
+	}
+}
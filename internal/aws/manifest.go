@@ -8,6 +8,8 @@ import (
 	"github.com/pkg/errors"
 	"sigs.k8s.io/yaml"
 
+	"github.com/aws/eks-hybrid/internal/artifact"
+	"github.com/aws/eks-hybrid/internal/signature"
 	"github.com/aws/eks-hybrid/internal/util"
 )
 
@@ -85,14 +87,61 @@ type Artifact struct {
 	GzipURI     string `json:"gzip_uri,omitempty"`
 }
 
+// ManifestOption configures how the release manifest is read.
+type ManifestOption func(*manifestOptions)
+
+type manifestOptions struct {
+	verifier       *signature.Verifier
+	fetchers       artifact.Fetchers
+	pinnedVersions map[string]string
+}
+
+// WithSignatureVerifier requires the release manifest and the checksum file of every
+// artifact to have a valid detached signature by a key trusted by verifier. The
+// signature of a file is read from its URI with the signature.Suffix appended.
+func WithSignatureVerifier(verifier *signature.Verifier) ManifestOption {
+	return func(o *manifestOptions) {
+		o.verifier = verifier
+	}
+}
+
+// WithFetchers reads the release manifest, and the Source created from it reads the
+// artifacts, with fetchers selected by URI scheme, to support mirrors that aren't
+// served over plain HTTPS.
+func WithFetchers(fetchers artifact.Fetchers) ManifestOption {
+	return func(o *manifestOptions) {
+		o.fetchers = fetchers
+	}
+}
+
+func newManifestOptions(opts []ManifestOption) manifestOptions {
+	var o manifestOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// GetManifest reads the release manifest from manifestURI or, if it's empty, from the
+// default manifest for the region's partition.
+func GetManifest(ctx context.Context, region, manifestURI string, opts ...ManifestOption) (*Manifest, error) {
+	if manifestURI != "" {
+		return getReleaseManifestFromURI(ctx, manifestURI, opts...)
+	}
+	return getReleaseManifest(ctx, region, opts...)
+}
+
 // Read from the manifest file on s3 and parse into Manifest struct
 // region is used to determine the appropriate manifest URL for different partitions (e.g., aws-cn)
-func getReleaseManifest(ctx context.Context, region string) (*Manifest, error) {
+func getReleaseManifest(ctx context.Context, region string, opts ...ManifestOption) (*Manifest, error) {
 	manifestURL := getManifestURL(region)
 	yamlFileData, err := util.GetHttpFile(ctx, manifestURL)
 	if err != nil {
 		return nil, err
 	}
+	if err := verifyManifest(ctx, yamlFileData, manifestURL, newManifestOptions(opts)); err != nil {
+		return nil, err
+	}
 	var manifest Manifest
 	err = yaml.Unmarshal(yamlFileData, &manifest)
 	if err != nil {
@@ -102,10 +151,36 @@ func getReleaseManifest(ctx context.Context, region string) (*Manifest, error) {
 }
 
 // getReleaseManifestFromURI reads from a URI (file:// or https://) and parses into Manifest struct
-func getReleaseManifestFromURI(ctx context.Context, manifestURI string) (*Manifest, error) {
+func getReleaseManifestFromURI(ctx context.Context, manifestURI string, opts ...ManifestOption) (*Manifest, error) {
+	o := newManifestOptions(opts)
+	yamlFileData, err := readManifestURI(ctx, manifestURI, o)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifyManifest(ctx, yamlFileData, manifestURI, o); err != nil {
+		return nil, err
+	}
+
+	var manifest Manifest
+	err = yaml.Unmarshal(yamlFileData, &manifest)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid yaml data in release manifest")
+	}
+	return &manifest, nil
+}
+
+func readManifestURI(ctx context.Context, manifestURI string, o manifestOptions) ([]byte, error) {
 	var yamlFileData []byte
 	var err error
 
+	if o.fetchers != nil && strings.Contains(manifestURI, "://") {
+		yamlFileData, err = o.fetchers.Read(ctx, manifestURI)
+		if err != nil {
+			return nil, errors.Wrapf(err, "reading manifest file from URI: %s", manifestURI)
+		}
+		return yamlFileData, nil
+	}
+
 	// Check if the URI uses file:// protocol
 	if strings.HasPrefix(manifestURI, "file://") {
 		// Strip file:// prefix and read from local file
@@ -127,11 +202,20 @@ func getReleaseManifestFromURI(ctx context.Context, manifestURI string) (*Manife
 			return nil, errors.Wrapf(err, "reading manifest file: %s (hint: use file:// or https:// prefix)", manifestURI)
 		}
 	}
+	return yamlFileData, nil
+}
 
-	var manifest Manifest
-	err = yaml.Unmarshal(yamlFileData, &manifest)
+// verifyManifest verifies the detached signature of the manifest, if a verifier is configured.
+func verifyManifest(ctx context.Context, data []byte, manifestURI string, o manifestOptions) error {
+	if o.verifier == nil {
+		return nil
+	}
+	sig, err := readManifestURI(ctx, manifestURI+signature.Suffix, o)
 	if err != nil {
-		return nil, errors.Wrap(err, "invalid yaml data in release manifest")
+		return errors.Wrap(err, "reading release manifest signature")
 	}
-	return &manifest, nil
+	if err := o.verifier.Verify(data, sig); err != nil {
+		return errors.Wrapf(err, "verifying signature of release manifest %s", manifestURI)
+	}
+	return nil
 }
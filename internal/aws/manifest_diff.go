@@ -0,0 +1,84 @@
+package aws
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ManifestChange is a field that differs between two manifests. Old is empty if the
+// field was added and New is empty if it was removed.
+type ManifestChange struct {
+	// Field is the path of the field, like
+	// supported_eks_releases/1.31/1.31.2/artifacts/linux/amd64/kubelet/uri.
+	Field string `json:"field"`
+	Old   string `json:"old,omitempty"`
+	New   string `json:"new,omitempty"`
+}
+
+// DiffManifests returns the fields that differ between the old and new manifests,
+// sorted by field. Releases are matched by version, artifacts by os, arch and name
+// and regions by name, so reordering them is not a change.
+func DiffManifests(old, new *Manifest) []ManifestChange {
+	oldFields, newFields := old.fields(), new.fields()
+	var changes []ManifestChange
+	for field, value := range oldFields {
+		if newFields[field] != value {
+			changes = append(changes, ManifestChange{Field: field, Old: value, New: newFields[field]})
+		}
+	}
+	for field, value := range newFields {
+		if _, ok := oldFields[field]; !ok {
+			changes = append(changes, ManifestChange{Field: field, New: value})
+		}
+	}
+	slices.SortFunc(changes, func(a, b ManifestChange) int {
+		return strings.Compare(a.Field, b.Field)
+	})
+	return changes
+}
+
+// fields flattens the manifest into a map of field paths to their values.
+func (m *Manifest) fields() map[string]string {
+	fields := map[string]string{}
+	set := func(field, value string) {
+		if value != "" {
+			fields[field] = value
+		}
+	}
+	setArtifacts := func(prefix string, artifacts []Artifact) {
+		for _, artifact := range artifacts {
+			field := prefix + "/artifacts/" + artifact.OS + "/" + artifact.Arch + "/" + artifact.Name
+			set(field+"/uri", artifact.URI)
+			set(field+"/checksum_uri", artifact.ChecksumURI)
+			set(field+"/gzip_uri", artifact.GzipURI)
+		}
+	}
+
+	for _, release := range m.SupportedEksReleases {
+		prefix := "supported_eks_releases/" + release.MajorMinorVersion
+		set(prefix+"/latest_patch_version", release.LatestPatchVersion)
+		for _, patch := range release.PatchReleases {
+			patchPrefix := prefix + "/" + release.MajorMinorVersion + "." + patch.PatchVersion
+			set(patchPrefix+"/version", patch.Version)
+			set(patchPrefix+"/release_date", patch.ReleaseDate)
+			setArtifacts(patchPrefix, patch.Artifacts)
+		}
+	}
+	for _, release := range m.IamRolesAnywhereReleases {
+		setArtifacts("iam_roles_anywhere_releases/"+release.Version, release.Artifacts)
+	}
+	for _, release := range m.SsmReleases {
+		setArtifacts("ssm_releases/"+release.Version, release.Artifacts)
+	}
+	for region, data := range m.RegionConfig {
+		prefix := "region_config/" + region
+		set(prefix+"/ecr_account_id", data.EcrAccountID)
+		set(prefix+"/partition", data.Partition)
+		set(prefix+"/dns_suffix", data.DnsSuffix)
+		for provider, supported := range data.CredProviders {
+			set(prefix+"/cred_providers/"+provider, strconv.FormatBool(supported))
+		}
+	}
+	return fields
+}
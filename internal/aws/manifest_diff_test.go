@@ -0,0 +1,64 @@
+package aws
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffManifests(t *testing.T) {
+	kubelet := func(uri string) Artifact {
+		return Artifact{Name: "kubelet", OS: "linux", Arch: "amd64", URI: uri, ChecksumURI: uri + ".sha256"}
+	}
+	kubectl := Artifact{Name: "kubectl", OS: "linux", Arch: "amd64", URI: "https://example.com/kubectl"}
+	old := &Manifest{
+		SupportedEksReleases: []SupportedEksRelease{
+			{
+				MajorMinorVersion:  "1.31",
+				LatestPatchVersion: "1",
+				PatchReleases: []EksPatchRelease{
+					{Version: "1.31.1", PatchVersion: "1", ReleaseDate: "2025-01-01", Artifacts: []Artifact{kubectl, kubelet("https://example.com/1.31.1/kubelet")}},
+				},
+			},
+		},
+		RegionConfig: RegionConfig{
+			"us-west-2": {EcrAccountID: "602401143452", CredProviders: map[string]bool{"ssm": true, "iam-ra": true}},
+			"us-east-1": {EcrAccountID: "602401143452", CredProviders: map[string]bool{"ssm": true}},
+		},
+	}
+	new := &Manifest{
+		SupportedEksReleases: []SupportedEksRelease{
+			{
+				MajorMinorVersion:  "1.31",
+				LatestPatchVersion: "2",
+				PatchReleases: []EksPatchRelease{
+					{Version: "1.31.2", PatchVersion: "2", ReleaseDate: "2025-02-01", Artifacts: []Artifact{kubelet("https://example.com/1.31.2/kubelet")}},
+					// Reordered artifacts are not a change.
+					{Version: "1.31.1", PatchVersion: "1", ReleaseDate: "2025-01-01", Artifacts: []Artifact{kubelet("https://mirror.example.com/1.31.1/kubelet"), kubectl}},
+				},
+			},
+		},
+		RegionConfig: RegionConfig{
+			"us-west-2": {EcrAccountID: "602401143452", CredProviders: map[string]bool{"ssm": true, "iam-ra": false}},
+		},
+	}
+
+	want := []ManifestChange{
+		{Field: "region_config/us-east-1/cred_providers/ssm", Old: "true"},
+		{Field: "region_config/us-east-1/ecr_account_id", Old: "602401143452"},
+		{Field: "region_config/us-west-2/cred_providers/iam-ra", Old: "true", New: "false"},
+		{Field: "supported_eks_releases/1.31/1.31.1/artifacts/linux/amd64/kubelet/checksum_uri", Old: "https://example.com/1.31.1/kubelet.sha256", New: "https://mirror.example.com/1.31.1/kubelet.sha256"},
+		{Field: "supported_eks_releases/1.31/1.31.1/artifacts/linux/amd64/kubelet/uri", Old: "https://example.com/1.31.1/kubelet", New: "https://mirror.example.com/1.31.1/kubelet"},
+		{Field: "supported_eks_releases/1.31/1.31.2/artifacts/linux/amd64/kubelet/checksum_uri", New: "https://example.com/1.31.2/kubelet.sha256"},
+		{Field: "supported_eks_releases/1.31/1.31.2/artifacts/linux/amd64/kubelet/uri", New: "https://example.com/1.31.2/kubelet"},
+		{Field: "supported_eks_releases/1.31/1.31.2/release_date", New: "2025-02-01"},
+		{Field: "supported_eks_releases/1.31/1.31.2/version", New: "1.31.2"},
+		{Field: "supported_eks_releases/1.31/latest_patch_version", Old: "1", New: "2"},
+	}
+	if got := DiffManifests(old, new); !reflect.DeepEqual(got, want) {
+		t.Errorf("DiffManifests() = %+v, want %+v", got, want)
+	}
+
+	if got := DiffManifests(new, new); len(got) != 0 {
+		t.Errorf("DiffManifests() of the same manifest = %+v, want no changes", got)
+	}
+}
@@ -0,0 +1,104 @@
+package aws
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+const signingHelperArtifactName = "aws_signing_helper"
+
+// PinnableArtifacts are the artifacts that can be installed from a different release
+// than the latest one for the Kubernetes version. kubelet and kubectl always match the
+// Kubernetes version.
+var PinnableArtifacts = []string{signingHelperArtifactName, "ecr-credential-provider", "aws-iam-authenticator", "cni-plugins"}
+
+// WithPinnedVersions installs the artifacts in versions, keyed by artifact name, from
+// the given release instead of the latest one. aws_signing_helper is pinned to an IAM
+// Roles Anywhere release version. The rest are pinned to the major.minor.patch version
+// of an EKS release, or to major.minor for its latest patch.
+func WithPinnedVersions(versions map[string]string) ManifestOption {
+	return func(o *manifestOptions) {
+		o.pinnedVersions = versions
+	}
+}
+
+// EksArtifactVersion returns the version of the EKS release the artifact is installed
+// from, which is the Kubernetes version unless the artifact is pinned.
+func (as Source) EksArtifactVersion(artifactName string) string {
+	if version, ok := as.PinnedVersions[artifactName]; ok {
+		return version
+	}
+	return as.Eks.Version
+}
+
+// pinArtifacts replaces the artifacts of the source with the ones from the pinned
+// releases. The pinned releases must have the artifact for every platform the
+// source has it for.
+func pinArtifacts(source *Source, manifest *Manifest, versions map[string]string) error {
+	for _, name := range slices.Sorted(maps.Keys(versions)) {
+		version := versions[name]
+		switch {
+		case name == signingHelperArtifactName:
+			release, err := findIamRolesAnywhereRelease(manifest, version)
+			if err != nil {
+				return err
+			}
+			if _, err := pinnedArtifacts(name, source.Iam.Artifacts, release.Artifacts, "IAM Roles Anywhere release "+release.Version); err != nil {
+				return err
+			}
+			source.Iam = release
+		case slices.Contains(PinnableArtifacts, name):
+			release, err := getLatestEksSource(version, manifest)
+			if err != nil {
+				return fmt.Errorf("pinning %s to %s: %w", name, version, err)
+			}
+			artifacts, err := pinnedArtifacts(name, source.Eks.Artifacts, release.Artifacts, "EKS release "+release.Version)
+			if err != nil {
+				return err
+			}
+			source.Eks.Artifacts = artifacts
+			if source.PinnedVersions == nil {
+				source.PinnedVersions = map[string]string{}
+			}
+			source.PinnedVersions[name] = release.Version
+		default:
+			return fmt.Errorf("artifact %s can't be pinned, artifacts that can be pinned are: %s", name, strings.Join(PinnableArtifacts, ", "))
+		}
+	}
+	return nil
+}
+
+func findIamRolesAnywhereRelease(manifest *Manifest, version string) (IamRolesAnywhereRelease, error) {
+	for _, release := range manifest.IamRolesAnywhereReleases {
+		if strings.TrimPrefix(release.Version, "v") == strings.TrimPrefix(version, "v") {
+			return release, nil
+		}
+	}
+	return IamRolesAnywhereRelease{}, fmt.Errorf("IAM Roles Anywhere release %s not found in manifest", version)
+}
+
+// pinnedArtifacts returns a copy of artifacts with the artifact called name replaced
+// by the one for the same platform in pinned.
+func pinnedArtifacts(name string, artifacts, pinned []Artifact, release string) ([]Artifact, error) {
+	result := slices.Clone(artifacts)
+	replaced := false
+	for i, current := range result {
+		if current.Name != name {
+			continue
+		}
+		j := slices.IndexFunc(pinned, func(a Artifact) bool {
+			return a.Name == name && a.Arch == current.Arch && a.OS == current.OS
+		})
+		if j < 0 {
+			return nil, fmt.Errorf("%s has no %s artifact for %s/%s", release, name, current.OS, current.Arch)
+		}
+		result[i] = pinned[j]
+		replaced = true
+	}
+	if !replaced {
+		return nil, fmt.Errorf("no %s artifact to pin to %s", name, release)
+	}
+	return result, nil
+}
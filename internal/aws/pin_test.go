@@ -0,0 +1,97 @@
+package aws
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewSourceFromManifestWithPinnedVersions(t *testing.T) {
+	artifacts := func(version string, names ...string) []Artifact {
+		var artifacts []Artifact
+		for _, name := range names {
+			for _, arch := range []string{"amd64", "arm64"} {
+				artifacts = append(artifacts, Artifact{Name: name, OS: "linux", Arch: arch, URI: "https://example.com/" + version + "/" + arch + "/" + name})
+			}
+		}
+		return artifacts
+	}
+	manifest := &Manifest{
+		SupportedEksReleases: []SupportedEksRelease{
+			{
+				MajorMinorVersion:  "1.31",
+				LatestPatchVersion: "2",
+				PatchReleases: []EksPatchRelease{
+					{Version: "1.31.1", PatchVersion: "1", ReleaseDate: "2025-01-01", Artifacts: artifacts("1.31.1", "kubelet", "ecr-credential-provider")},
+					{Version: "1.31.2", PatchVersion: "2", ReleaseDate: "2025-02-01", Artifacts: artifacts("1.31.2", "kubelet", "ecr-credential-provider")},
+				},
+			},
+			{
+				MajorMinorVersion:  "1.32",
+				LatestPatchVersion: "1",
+				PatchReleases: []EksPatchRelease{
+					{Version: "1.32.1", PatchVersion: "1", ReleaseDate: "2025-02-01", Artifacts: append(artifacts("1.32.1", "kubelet"), Artifact{Name: "ecr-credential-provider", OS: "linux", Arch: "amd64"})},
+				},
+			},
+		},
+		IamRolesAnywhereReleases: []IamRolesAnywhereRelease{
+			{Version: "v1.6.0", Artifacts: artifacts("v1.6.0", "aws_signing_helper")},
+			{Version: "v1.4.0", Artifacts: artifacts("v1.4.0", "aws_signing_helper")},
+		},
+		RegionConfig: RegionConfig{"us-west-2": {}},
+	}
+
+	t.Run("pins signing helper and EKS artifacts", func(t *testing.T) {
+		source, err := NewSourceFromManifest("1.31", "us-west-2", manifest, WithPinnedVersions(map[string]string{
+			"aws_signing_helper":      "1.4.0",
+			"ecr-credential-provider": "1.31.1",
+		}))
+		if err != nil {
+			t.Fatalf("NewSourceFromManifest() error = %v", err)
+		}
+		if source.Eks.Version != "1.31.2" {
+			t.Errorf("Eks.Version = %s, want 1.31.2", source.Eks.Version)
+		}
+		if source.Iam.Version != "v1.4.0" {
+			t.Errorf("Iam.Version = %s, want v1.4.0", source.Iam.Version)
+		}
+		for _, artifact := range source.Eks.Artifacts {
+			want := "https://example.com/1.31.2/"
+			if artifact.Name == "ecr-credential-provider" {
+				want = "https://example.com/1.31.1/"
+			}
+			if !strings.HasPrefix(artifact.URI, want) {
+				t.Errorf("%s %s URI = %s, want it from %s", artifact.Name, artifact.Arch, artifact.URI, want)
+			}
+		}
+		if got := source.EksArtifactVersion("ecr-credential-provider"); got != "1.31.1" {
+			t.Errorf("EksArtifactVersion(ecr-credential-provider) = %s, want 1.31.1", got)
+		}
+		if got := source.EksArtifactVersion("kubelet"); got != "1.31.2" {
+			t.Errorf("EksArtifactVersion(kubelet) = %s, want 1.31.2", got)
+		}
+		// The manifest releases must not be modified.
+		if uri := manifest.SupportedEksReleases[0].PatchReleases[1].Artifacts[2].URI; !strings.HasPrefix(uri, "https://example.com/1.31.2/") {
+			t.Errorf("manifest artifact URI changed to %s", uri)
+		}
+	})
+
+	tests := []struct {
+		name    string
+		pins    map[string]string
+		wantErr string
+	}{
+		{name: "kubelet", pins: map[string]string{"kubelet": "1.31.1"}, wantErr: "artifact kubelet can't be pinned"},
+		{name: "unknown IAM Roles Anywhere release", pins: map[string]string{"aws_signing_helper": "1.5.0"}, wantErr: "IAM Roles Anywhere release 1.5.0 not found"},
+		{name: "unknown EKS release", pins: map[string]string{"ecr-credential-provider": "1.31.9"}, wantErr: "pinning ecr-credential-provider to 1.31.9"},
+		{name: "missing platform", pins: map[string]string{"ecr-credential-provider": "1.32"}, wantErr: "EKS release 1.32.1 has no ecr-credential-provider artifact for linux/arm64"},
+		{name: "missing artifact", pins: map[string]string{"cni-plugins": "1.31.1"}, wantErr: "no cni-plugins artifact to pin"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewSourceFromManifest("1.31", "us-west-2", manifest, WithPinnedVersions(tt.pins))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("NewSourceFromManifest() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
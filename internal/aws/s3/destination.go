@@ -0,0 +1,78 @@
+package s3
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
+	s3_sdk "github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// sha256MetadataKey is the object metadata with the hex sha256 digest of synced artifacts.
+const sha256MetadataKey = "sha256"
+
+// Destination stores synced artifacts as public-read objects in an S3 bucket under
+// a key prefix. The sha256 digest of each object is stored in its metadata.
+type Destination struct {
+	client   *s3_sdk.Client
+	uploader *manager.Uploader
+	bucket   string
+	prefix   string
+	baseURI  string
+}
+
+// NewDestination returns a destination that uploads artifacts to bucket under prefix.
+// Nodes read them from baseURI.
+func NewDestination(client *s3_sdk.Client, bucket, prefix, baseURI string) *Destination {
+	return &Destination{
+		client:   client,
+		uploader: manager.NewUploader(client),
+		bucket:   bucket,
+		prefix:   strings.Trim(prefix, "/"),
+		baseURI:  strings.TrimSuffix(baseURI, "/"),
+	}
+}
+
+func (d *Destination) Has(ctx context.Context, artifactPath string, digest []byte) (bool, error) {
+	out, err := d.client.HeadObject(ctx, &s3_sdk.HeadObjectInput{
+		Bucket: aws.String(d.bucket),
+		Key:    aws.String(d.key(artifactPath)),
+	})
+	var notFound *types.NotFound
+	if errors.As(err, &notFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("reading s3://%s/%s: %w", d.bucket, d.key(artifactPath), err)
+	}
+	return out.Metadata[sha256MetadataKey] == hex.EncodeToString(digest), nil
+}
+
+func (d *Destination) Put(ctx context.Context, artifactPath string, content io.ReadSeeker, _ int64, digest []byte) error {
+	_, err := d.uploader.Upload(ctx, &s3_sdk.PutObjectInput{
+		Bucket:   aws.String(d.bucket),
+		Key:      aws.String(d.key(artifactPath)),
+		Body:     content,
+		ACL:      types.ObjectCannedACLPublicRead,
+		Metadata: map[string]string{sha256MetadataKey: hex.EncodeToString(digest)},
+	})
+	if err != nil {
+		return fmt.Errorf("uploading s3://%s/%s: %w", d.bucket, d.key(artifactPath), err)
+	}
+	return nil
+}
+
+func (d *Destination) URI(artifactPath string, _ []byte) string {
+	return d.baseURI + "/" + artifactPath
+}
+
+func (d *Destination) key(artifactPath string) string {
+	return path.Join(d.prefix, artifactPath)
+}
@@ -0,0 +1,125 @@
+package s3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	s3_sdk "github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/smithy-go"
+)
+
+// GetObjectAPI is the subset of the S3 client needed to read artifacts.
+type GetObjectAPI interface {
+	GetObject(ctx context.Context, params *s3_sdk.GetObjectInput, optFns ...func(*s3_sdk.Options)) (*s3_sdk.GetObjectOutput, error)
+}
+
+// Fetcher fetches artifacts from S3 objects with URIs of the form s3://bucket/key.
+// The region of the bucket can be set with ?region=<region>, otherwise the region
+// of the fetcher is used. Credentials are resolved through the default AWS
+// credential chain.
+type Fetcher struct {
+	region      string
+	configOpts  []func(*config.LoadOptions) error
+	newClient   func(ctx context.Context, region string) (GetObjectAPI, error)
+	clientsLock sync.Mutex
+	clients     map[string]GetObjectAPI
+}
+
+// NewFetcher returns a Fetcher for buckets in region. configOpts are applied when
+// loading the AWS config, after the region.
+func NewFetcher(region string, configOpts ...func(*config.LoadOptions) error) *Fetcher {
+	f := &Fetcher{
+		region:     region,
+		configOpts: configOpts,
+		clients:    map[string]GetObjectAPI{},
+	}
+	f.newClient = f.defaultClient
+	return f
+}
+
+// NewFetcherWithClient returns a Fetcher that reads every object with client.
+func NewFetcherWithClient(client GetObjectAPI) *Fetcher {
+	f := NewFetcher("")
+	f.newClient = func(context.Context, string) (GetObjectAPI, error) {
+		return client, nil
+	}
+	return f
+}
+
+func (f *Fetcher) Fetch(ctx context.Context, uri *url.URL) (io.ReadCloser, error) {
+	body, _, err := f.FetchRange(ctx, uri, 0)
+	return body, err
+}
+
+func (f *Fetcher) FetchRange(ctx context.Context, uri *url.URL, offset int64) (io.ReadCloser, bool, error) {
+	bucket := uri.Host
+	key := strings.TrimPrefix(uri.Path, "/")
+	if bucket == "" || key == "" {
+		return nil, false, fmt.Errorf("invalid s3 artifact uri %s, expected format s3://bucket/key", uri)
+	}
+	region := uri.Query().Get("region")
+	if region == "" {
+		region = f.region
+	}
+
+	client, err := f.client(ctx, region)
+	if err != nil {
+		return nil, false, err
+	}
+	input := &s3_sdk.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	}
+	if offset > 0 {
+		input.Range = aws.String("bytes=" + strconv.FormatInt(offset, 10) + "-")
+	}
+	out, err := client.GetObject(ctx, input)
+	if err != nil {
+		var apiErr smithy.APIError
+		if offset > 0 && errors.As(err, &apiErr) && apiErr.ErrorCode() == "InvalidRange" {
+			// The offset is already at the end of the object.
+			return http.NoBody, true, nil
+		}
+		return nil, false, fmt.Errorf("reading s3://%s/%s: %w", bucket, key, err)
+	}
+	return out.Body, offset > 0, nil
+}
+
+func (f *Fetcher) client(ctx context.Context, region string) (GetObjectAPI, error) {
+	f.clientsLock.Lock()
+	defer f.clientsLock.Unlock()
+	if client, ok := f.clients[region]; ok {
+		return client, nil
+	}
+	client, err := f.newClient(ctx, region)
+	if err != nil {
+		return nil, err
+	}
+	f.clients[region] = client
+	return client, nil
+}
+
+func (f *Fetcher) defaultClient(ctx context.Context, region string) (GetObjectAPI, error) {
+	var opts []func(*config.LoadOptions) error
+	if region != "" {
+		opts = append(opts, config.WithRegion(region))
+	}
+	opts = append(opts, f.configOpts...)
+	awsConfig, err := config.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("loading AWS config for s3 artifacts: %w", err)
+	}
+	if awsConfig.Region == "" {
+		return nil, fmt.Errorf("no region configured for s3 artifacts, set it with s3://<bucket>/<key>?region=<region>")
+	}
+	return s3_sdk.NewFromConfig(awsConfig), nil
+}
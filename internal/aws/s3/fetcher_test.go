@@ -0,0 +1,64 @@
+package s3_test
+
+import (
+	"context"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	s3_sdk "github.com/aws/aws-sdk-go-v2/service/s3"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-hybrid/internal/aws/s3"
+)
+
+type fakeS3 struct {
+	objects map[string]string
+	inputs  []*s3_sdk.GetObjectInput
+}
+
+func (f *fakeS3) GetObject(_ context.Context, input *s3_sdk.GetObjectInput, _ ...func(*s3_sdk.Options)) (*s3_sdk.GetObjectOutput, error) {
+	f.inputs = append(f.inputs, input)
+	data := f.objects[aws.ToString(input.Bucket)+"/"+aws.ToString(input.Key)]
+	if r := aws.ToString(input.Range); r != "" {
+		offset, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(r, "bytes="), "-"))
+		if err != nil {
+			return nil, err
+		}
+		data = data[offset:]
+	}
+	return &s3_sdk.GetObjectOutput{Body: io.NopCloser(strings.NewReader(data))}, nil
+}
+
+func TestFetcherFetchRange(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	client := &fakeS3{objects: map[string]string{"artifacts/eks/kubelet": "kubelet binary"}}
+	fetcher := s3.NewFetcherWithClient(client)
+
+	uri, err := url.Parse("s3://artifacts/eks/kubelet")
+	g.Expect(err).NotTo(HaveOccurred())
+	body, err := fetcher.Fetch(ctx, uri)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(io.ReadAll(body)).To(Equal([]byte("kubelet binary")))
+	g.Expect(client.inputs[0].Range).To(BeNil())
+
+	body, resumed, err := fetcher.FetchRange(ctx, uri, 4)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(resumed).To(BeTrue())
+	g.Expect(io.ReadAll(body)).To(Equal([]byte("let binary")))
+	g.Expect(aws.ToString(client.inputs[1].Range)).To(Equal("bytes=4-"))
+}
+
+func TestFetcherInvalidURI(t *testing.T) {
+	g := NewWithT(t)
+	fetcher := s3.NewFetcherWithClient(&fakeS3{})
+	uri, err := url.Parse("s3://artifacts")
+	g.Expect(err).NotTo(HaveOccurred())
+
+	_, err = fetcher.Fetch(context.Background(), uri)
+	g.Expect(err).To(MatchError(ContainSubstring("invalid s3 artifact uri")))
+}
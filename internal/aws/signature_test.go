@@ -0,0 +1,127 @@
+package aws
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ProtonMail/gopenpgp/v3/crypto"
+
+	"github.com/aws/eks-hybrid/internal/signature"
+)
+
+func newTestSigner(t *testing.T) (*signature.Verifier, func(data []byte) []byte) {
+	t.Helper()
+	pgp := crypto.PGP()
+	key, err := pgp.KeyGeneration().AddUserId("test", "test@example.com").New().GenerateKey()
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	publicKey, err := key.GetArmoredPublicKey()
+	if err != nil {
+		t.Fatalf("Failed to armor public key: %v", err)
+	}
+	verifier, err := signature.NewVerifier(publicKey)
+	if err != nil {
+		t.Fatalf("Failed to create verifier: %v", err)
+	}
+	signer, err := pgp.Sign().SigningKey(key).Detached().New()
+	if err != nil {
+		t.Fatalf("Failed to create signer: %v", err)
+	}
+	return verifier, func(data []byte) []byte {
+		sig, err := signer.Sign(data, crypto.Bytes)
+		if err != nil {
+			t.Fatalf("Failed to sign: %v", err)
+		}
+		return sig
+	}
+}
+
+func writeTestFile(t *testing.T, path string, data []byte) {
+	t.Helper()
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatalf("Failed to write %s: %v", path, err)
+	}
+}
+
+func TestGetReleaseManifestFromURIWithSignatureVerifier(t *testing.T) {
+	ctx := context.Background()
+	verifier, sign := newTestSigner(t)
+	_, untrustedSign := newTestSigner(t)
+	manifestData := []byte("supported_eks_releases:\n- major_minor_version: \"1.31\"\n")
+
+	tests := []struct {
+		name      string
+		signature []byte
+		wantErr   string
+	}{
+		{
+			name:      "ValidSignature",
+			signature: sign(manifestData),
+		},
+		{
+			name:    "MissingSignature",
+			wantErr: "reading release manifest signature",
+		},
+		{
+			name:      "UntrustedSignature",
+			signature: untrustedSign(manifestData),
+			wantErr:   "verifying signature of release manifest",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			manifestPath := filepath.Join(t.TempDir(), "manifest.yaml")
+			writeTestFile(t, manifestPath, manifestData)
+			if tc.signature != nil {
+				writeTestFile(t, manifestPath+signature.Suffix, tc.signature)
+			}
+
+			manifest, err := getReleaseManifestFromURI(ctx, "file://"+manifestPath, WithSignatureVerifier(verifier))
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("Expected error containing %q, got: %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected manifest to be verified, got: %v", err)
+			}
+			if len(manifest.SupportedEksReleases) != 1 {
+				t.Errorf("Expected 1 supported EKS release, got %d", len(manifest.SupportedEksReleases))
+			}
+		})
+	}
+}
+
+func TestSourceChecksumWithSignatureVerifier(t *testing.T) {
+	ctx := context.Background()
+	verifier, sign := newTestSigner(t)
+	dir := t.TempDir()
+	checksumPath := filepath.Join(dir, "kubelet.sha256")
+	checksum := []byte("0123456789abcdef  kubelet\n")
+	writeTestFile(t, checksumPath, checksum)
+	releaseArtifact := Artifact{Name: "kubelet", ChecksumURI: "file://" + checksumPath}
+	source := Source{Verifier: verifier}
+
+	if _, err := source.Checksum(ctx, releaseArtifact); err == nil || !strings.Contains(err.Error(), "getting kubelet checksum signature") {
+		t.Fatalf("Expected missing signature error, got: %v", err)
+	}
+
+	writeTestFile(t, checksumPath+signature.Suffix, sign([]byte("altered checksum")))
+	if _, err := source.Checksum(ctx, releaseArtifact); err == nil || !strings.Contains(err.Error(), "verifying kubelet checksum signature") {
+		t.Fatalf("Expected invalid signature error, got: %v", err)
+	}
+
+	writeTestFile(t, checksumPath+signature.Suffix, sign(checksum))
+	got, err := source.Checksum(ctx, releaseArtifact)
+	if err != nil {
+		t.Fatalf("Expected checksum to be verified, got: %v", err)
+	}
+	if string(got) != string(checksum) {
+		t.Errorf("Expected checksum %q, got %q", checksum, got)
+	}
+}
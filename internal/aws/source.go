@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/sha256"
 	"fmt"
+	"io"
 	"runtime"
 	"strings"
 	"time"
@@ -12,6 +13,7 @@ import (
 	"golang.org/x/mod/semver"
 
 	"github.com/aws/eks-hybrid/internal/artifact"
+	"github.com/aws/eks-hybrid/internal/signature"
 	"github.com/aws/eks-hybrid/internal/util"
 )
 
@@ -20,28 +22,55 @@ type Source struct {
 	Eks        EksPatchRelease
 	Iam        IamRolesAnywhereRelease
 	RegionInfo RegionData
+	// Downloader, if set, downloads the artifacts to its cache before they are
+	// read, instead of streaming them from the release.
+	Downloader *artifact.Downloader
+	// Verifier, if set, verifies the signature of the checksum of every artifact.
+	Verifier *signature.Verifier
+	// Fetchers, if set, read the artifacts and their checksums by URI scheme. Otherwise
+	// only file:// and http(s):// URIs are supported.
+	Fetchers artifact.Fetchers
+	// PinnedVersions has the version of the EKS release each pinned EKS artifact is
+	// installed from. See WithPinnedVersions.
+	PinnedVersions map[string]string
 }
 
 // GetLatestSource gets the source for latest version of aws provided artifacts from the
 // hybrid nodes CDN manifest https://hybrid-assets.eks.amazonaws.com/manifest.yaml
-func GetLatestSource(ctx context.Context, eksVersion, region string) (Source, error) {
-	manifest, err := getReleaseManifest(ctx, region)
+func GetLatestSource(ctx context.Context, eksVersion, region string, opts ...ManifestOption) (Source, error) {
+	manifest, err := getReleaseManifest(ctx, region, opts...)
 	if err != nil {
 		return Source{}, err
 	}
 
-	return getSourceFromManifest(eksVersion, region, manifest)
+	return NewSourceFromManifest(eksVersion, region, manifest, opts...)
 }
 
 // GetLatestSourceFromManifest gets the source for latest version of aws provided artifacts
 // from a manifest URI (supports file:// and https:// protocols)
-func GetLatestSourceFromManifest(ctx context.Context, eksVersion, region, manifestURI string) (Source, error) {
-	manifest, err := getReleaseManifestFromURI(ctx, manifestURI)
+func GetLatestSourceFromManifest(ctx context.Context, eksVersion, region, manifestURI string, opts ...ManifestOption) (Source, error) {
+	manifest, err := getReleaseManifestFromURI(ctx, manifestURI, opts...)
 	if err != nil {
 		return Source{}, err
 	}
 
-	return getSourceFromManifest(eksVersion, region, manifest)
+	return NewSourceFromManifest(eksVersion, region, manifest, opts...)
+}
+
+// NewSourceFromManifest creates the source for the latest version of aws provided
+// artifacts in a manifest that has already been read.
+func NewSourceFromManifest(eksVersion, region string, manifest *Manifest, opts ...ManifestOption) (Source, error) {
+	source, err := getSourceFromManifest(eksVersion, region, manifest)
+	if err != nil {
+		return Source{}, err
+	}
+	o := newManifestOptions(opts)
+	if err := pinArtifacts(&source, manifest, o.pinnedVersions); err != nil {
+		return Source{}, err
+	}
+	source.Verifier = o.verifier
+	source.Fetchers = o.fetchers
+	return source, nil
 }
 
 // getSourceFromManifest is a common function to create Source from a manifest
@@ -214,49 +243,177 @@ func (as Source) GetCniPlugins(ctx context.Context) (artifact.Source, error) {
 }
 
 func (as Source) getEksSource(ctx context.Context, artifactName string) (artifact.Source, error) {
-	return getSource(ctx, artifactName, as.Eks.Artifacts)
+	return as.getSource(ctx, artifactName, as.Eks.Artifacts)
 }
 
 // GetSingingHelper satisfies iamrolesanywhere.SigningHelperSource
 func (as Source) GetSigningHelper(ctx context.Context) (artifact.Source, error) {
-	return getSource(ctx, "aws_signing_helper", as.Iam.Artifacts)
+	return as.getSource(ctx, "aws_signing_helper", as.Iam.Artifacts)
+}
+
+// Prefetch downloads the artifacts to the Downloader cache concurrently, so they are
+// read from disk when installed. It's a no-op if the Source has no Downloader.
+func (as Source) Prefetch(ctx context.Context, artifacts ...Artifact) error {
+	if as.Downloader == nil {
+		return nil
+	}
+	downloads := make([]artifact.Download, 0, len(artifacts))
+	for _, releaseArtifact := range artifacts {
+		download, err := as.newDownload(ctx, releaseArtifact)
+		if err != nil {
+			return err
+		}
+		downloads = append(downloads, download)
+	}
+	return as.Downloader.GetAll(ctx, downloads...)
 }
 
-func getSource(ctx context.Context, artifactName string, availableArtifacts []Artifact) (artifact.Source, error) {
+// PruneCache removes the artifacts in the Downloader cache that aren't any of artifacts.
+// It's a no-op if the Source has no Downloader.
+func (as Source) PruneCache(ctx context.Context, artifacts ...Artifact) error {
+	if as.Downloader == nil {
+		return nil
+	}
+	checksums := make([][]byte, 0, len(artifacts))
+	for _, releaseArtifact := range artifacts {
+		download, err := as.newDownload(ctx, releaseArtifact)
+		if err != nil {
+			return err
+		}
+		checksums = append(checksums, download.Checksum)
+	}
+	return as.Downloader.Prune(checksums...)
+}
+
+// EksArtifact returns the artifact with the given name from the EKS release for the
+// current platform.
+func (as Source) EksArtifact(artifactName string) (Artifact, error) {
+	return findArtifact(artifactName, as.Eks.Artifacts)
+}
+
+// IamRolesAnywhereArtifact returns the artifact with the given name from the IAM Roles
+// Anywhere release for the current platform.
+func (as Source) IamRolesAnywhereArtifact(artifactName string) (Artifact, error) {
+	return findArtifact(artifactName, as.Iam.Artifacts)
+}
+
+func findArtifact(artifactName string, availableArtifacts []Artifact) (Artifact, error) {
 	for _, releaseArtifact := range availableArtifacts {
 		if releaseArtifact.Name == artifactName && releaseArtifact.Arch == runtime.GOARCH && releaseArtifact.OS == runtime.GOOS {
-			uri := releaseArtifact.URI
-			if releaseArtifact.GzipURI != "" {
-				// the same checksum will be used for both gzip and non-gzip uri
-				// gzip decompression will happen before checksum verification
-				uri = releaseArtifact.GzipURI
-			}
-			obj, err := util.GetHttpFileReader(ctx, uri)
-			if err != nil {
-				return nil, fmt.Errorf("getting artifact file reader: %w", err)
-			}
-
-			artifactChecksum, err := util.GetHttpFile(ctx, releaseArtifact.ChecksumURI)
-			if err != nil {
-				obj.Close()
-				return nil, fmt.Errorf("getting artifact checksum file reader: %w", err)
-			}
+			return releaseArtifact, nil
+		}
+	}
+	return Artifact{}, fmt.Errorf("could not find artifact for %s arch and %s os", runtime.GOARCH, runtime.GOOS)
+}
 
-			var source artifact.Source
-			if releaseArtifact.GzipURI != "" {
-				source, err = artifact.GzippedWithChecksum(obj, sha256.New(), artifactChecksum)
-			} else {
-				source, err = artifact.WithChecksum(obj, sha256.New(), artifactChecksum)
-			}
+func (as Source) getSource(ctx context.Context, artifactName string, availableArtifacts []Artifact) (artifact.Source, error) {
+	releaseArtifact, err := findArtifact(artifactName, availableArtifacts)
+	if err != nil {
+		return nil, err
+	}
 
-			if err != nil {
-				obj.Close()
-				return nil, fmt.Errorf("getting artifact with checksum: %w", err)
-			}
-			return source, nil
+	if as.Downloader != nil {
+		download, err := as.newDownload(ctx, releaseArtifact)
+		if err != nil {
+			return nil, err
 		}
+		return as.Downloader.Open(ctx, download)
+	}
+
+	uri := releaseArtifact.URI
+	if releaseArtifact.GzipURI != "" {
+		// the same checksum will be used for both gzip and non-gzip uri
+		// gzip decompression will happen before checksum verification
+		uri = releaseArtifact.GzipURI
+	}
+	obj, err := as.open(ctx, uri)
+	if err != nil {
+		return nil, fmt.Errorf("getting artifact file reader: %w", err)
+	}
+
+	artifactChecksum, err := as.Checksum(ctx, releaseArtifact)
+	if err != nil {
+		obj.Close()
+		return nil, err
+	}
+
+	var source artifact.Source
+	if releaseArtifact.GzipURI != "" {
+		source, err = artifact.GzippedWithChecksum(obj, sha256.New(), artifactChecksum)
+	} else {
+		source, err = artifact.WithChecksum(obj, sha256.New(), artifactChecksum)
+	}
+
+	if err != nil {
+		obj.Close()
+		return nil, fmt.Errorf("getting artifact with checksum: %w", err)
+	}
+	return source, nil
+}
+
+// Checksum returns the GNU checksum file of the artifact. If the source has a Verifier,
+// the checksum file must have a valid detached signature.
+func (as Source) Checksum(ctx context.Context, releaseArtifact Artifact) ([]byte, error) {
+	checksum, err := as.read(ctx, releaseArtifact.ChecksumURI)
+	if err != nil {
+		return nil, fmt.Errorf("getting %s checksum: %w", releaseArtifact.Name, err)
+	}
+	if as.Verifier == nil {
+		return checksum, nil
+	}
+	sig, err := as.ChecksumSignature(ctx, releaseArtifact)
+	if err != nil {
+		return nil, err
+	}
+	if err := as.Verifier.Verify(checksum, sig); err != nil {
+		return nil, fmt.Errorf("verifying %s checksum signature: %w", releaseArtifact.Name, err)
+	}
+	return checksum, nil
+}
+
+// ChecksumSignature returns the detached signature of the checksum file of the artifact.
+func (as Source) ChecksumSignature(ctx context.Context, releaseArtifact Artifact) ([]byte, error) {
+	sig, err := as.read(ctx, releaseArtifact.ChecksumURI+signature.Suffix)
+	if err != nil {
+		return nil, fmt.Errorf("getting %s checksum signature: %w", releaseArtifact.Name, err)
+	}
+	return sig, nil
+}
+
+func (as Source) open(ctx context.Context, uri string) (io.ReadCloser, error) {
+	if as.Fetchers != nil {
+		return as.Fetchers.Open(ctx, uri)
+	}
+	return util.OpenURI(ctx, uri)
+}
+
+func (as Source) read(ctx context.Context, uri string) ([]byte, error) {
+	if as.Fetchers != nil {
+		return as.Fetchers.Read(ctx, uri)
+	}
+	return util.ReadURI(ctx, uri)
+}
+
+// newDownload returns the download for the artifact, preferring the gzip compressed URI.
+func (as Source) newDownload(ctx context.Context, releaseArtifact Artifact) (artifact.Download, error) {
+	artifactChecksum, err := as.Checksum(ctx, releaseArtifact)
+	if err != nil {
+		return artifact.Download{}, err
+	}
+	checksum, err := artifact.ParseGNUChecksum(artifactChecksum)
+	if err != nil {
+		return artifact.Download{}, fmt.Errorf("parsing %s checksum: %w", releaseArtifact.Name, err)
+	}
+	download := artifact.Download{
+		Name:     releaseArtifact.Name,
+		URL:      releaseArtifact.URI,
+		Checksum: checksum,
+	}
+	if releaseArtifact.GzipURI != "" {
+		download.URL = releaseArtifact.GzipURI
+		download.Gzipped = true
 	}
-	return nil, fmt.Errorf("could not find artifact for %s arch and %s os", runtime.GOARCH, runtime.GOOS)
+	return download, nil
 }
 
 // validateKubernetesVersionMatch validates that the requested Kubernetes version is compatible with the manifest version
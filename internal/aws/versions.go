@@ -0,0 +1,74 @@
+package aws
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"golang.org/x/mod/semver"
+)
+
+// MatchVersions returns the Kubernetes versions selected by spec, a comma separated
+// list of major[.minor[.patch]] versions and inclusive major.minor ranges like
+// 1.29-1.31. Ranges select every major.minor version the manifest supports in the
+// range. The versions are returned sorted, without duplicates.
+func (m *Manifest) MatchVersions(spec string) ([]string, error) {
+	var versions []string
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		from, to, isRange := strings.Cut(entry, "-")
+		if !isRange {
+			if !semver.IsValid("v" + entry) {
+				return nil, fmt.Errorf("invalid Kubernetes version %s", entry)
+			}
+			versions = append(versions, entry)
+			continue
+		}
+
+		from, to = strings.TrimSpace(from), strings.TrimSpace(to)
+		if !isMajorMinor(from) || !isMajorMinor(to) {
+			return nil, fmt.Errorf("invalid Kubernetes version range %s, expected <major.minor>-<major.minor>", entry)
+		}
+		if semver.Compare("v"+from, "v"+to) > 0 {
+			return nil, fmt.Errorf("invalid Kubernetes version range %s, %s is greater than %s", entry, from, to)
+		}
+		matched := false
+		for _, release := range m.SupportedEksReleases {
+			version := "v" + release.MajorMinorVersion
+			if semver.Compare(version, "v"+from) >= 0 && semver.Compare(version, "v"+to) <= 0 {
+				versions = append(versions, release.MajorMinorVersion)
+				matched = true
+			}
+		}
+		if !matched {
+			return nil, fmt.Errorf("no supported Kubernetes versions in range %s", entry)
+		}
+	}
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("no Kubernetes versions in %q", spec)
+	}
+
+	slices.SortFunc(versions, func(a, b string) int {
+		return semver.Compare("v"+a, "v"+b)
+	})
+	return slices.Compact(versions), nil
+}
+
+func isMajorMinor(version string) bool {
+	return semver.IsValid("v"+version) && semver.MajorMinor("v"+version) == "v"+version
+}
+
+// EksRelease returns the patch release for a major.minor or major.minor.patch Kubernetes
+// version, the same release a Source created from the manifest uses.
+func (m *Manifest) EksRelease(version string) (EksPatchRelease, error) {
+	return getLatestEksSource(version, m)
+}
+
+// LatestIamRolesAnywhereRelease returns the IAM Roles Anywhere signing helper release
+// installed with every Kubernetes version.
+func (m *Manifest) LatestIamRolesAnywhereRelease() (IamRolesAnywhereRelease, error) {
+	return getLatestIamRolesAnywhereSource(m)
+}
@@ -0,0 +1,55 @@
+package aws
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestManifestMatchVersions(t *testing.T) {
+	manifest := &Manifest{
+		SupportedEksReleases: []SupportedEksRelease{
+			{MajorMinorVersion: "1.32"},
+			{MajorMinorVersion: "1.29"},
+			{MajorMinorVersion: "1.30"},
+			{MajorMinorVersion: "1.31"},
+			{MajorMinorVersion: "1.33"},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		spec    string
+		want    []string
+		wantErr string
+	}{
+		{name: "single version", spec: "1.31", want: []string{"1.31"}},
+		{name: "list with patch versions", spec: "1.31.2, 1.29", want: []string{"1.29", "1.31.2"}},
+		{name: "range", spec: "1.30-1.32", want: []string{"1.30", "1.31", "1.32"}},
+		{name: "range and overlapping list", spec: "1.32,1.29-1.30,1.30", want: []string{"1.29", "1.30", "1.32"}},
+		{name: "range beyond supported versions", spec: "1.32-1.40", want: []string{"1.32", "1.33"}},
+		{name: "range with patch version", spec: "1.29.1-1.31", wantErr: "invalid Kubernetes version range"},
+		{name: "reversed range", spec: "1.31-1.29", wantErr: "1.31 is greater than 1.29"},
+		{name: "range without supported versions", spec: "1.20-1.25", wantErr: "no supported Kubernetes versions in range"},
+		{name: "invalid version", spec: "latest", wantErr: "invalid Kubernetes version latest"},
+		{name: "empty", spec: " , ", wantErr: "no Kubernetes versions"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := manifest.MatchVersions(tt.spec)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Expected error containing %q, got: %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,374 @@
+package bundle
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-hybrid/internal/artifact"
+	"github.com/aws/eks-hybrid/internal/aws"
+	"github.com/aws/eks-hybrid/internal/signature"
+	"github.com/aws/eks-hybrid/internal/ssm"
+	"github.com/aws/eks-hybrid/internal/util"
+)
+
+const (
+	// ManifestFile is the release manifest in the bundle. Artifact URIs are
+	// paths relative to the root of the bundle.
+	ManifestFile = "manifest.yaml"
+	// ChecksumsFile has the SHA256 of every other file in the bundle, in GNU format.
+	ChecksumsFile = "checksums.sha256"
+	// ChecksumsSignatureFile is the detached signature of ChecksumsFile. Since the
+	// checksums cover the manifest, which maps the name, version and arch of every
+	// artifact to its file, it binds each artifact to the release it belongs to.
+	ChecksumsSignatureFile = ChecksumsFile + signature.Suffix
+
+	eksDir              = "eks"
+	iamRolesAnywhereDir = "iam-ra"
+	ssmSignatureSuffix  = ".sig"
+	checksumSuffix      = ".sha256"
+)
+
+// Bundle is an offline install bundle extracted to a directory.
+type Bundle struct {
+	dir      string
+	manifest *aws.Manifest
+}
+
+// Option configures how a bundle is extracted.
+type Option func(*options)
+
+type options struct {
+	verifier *signature.Verifier
+}
+
+// WithVerifier requires the bundle checksums and the checksum file of every artifact in
+// the bundle to have a valid detached signature by a key trusted by verifier, and the
+// artifacts to match them. The artifact signatures are added to the bundle when it's
+// created with the same trust root, and the bundle is signed with the creator's key,
+// which must be in the trust root.
+func WithVerifier(verifier *signature.Verifier) Option {
+	return func(o *options) {
+		o.verifier = verifier
+	}
+}
+
+// Open extracts the bundle archive at archivePath to a new temporary directory.
+// The caller must Close the bundle to remove it.
+func Open(archivePath string, opts ...Option) (*Bundle, error) {
+	dir, err := os.MkdirTemp("", "nodeadm-bundle-")
+	if err != nil {
+		return nil, err
+	}
+	bundle, err := Extract(archivePath, dir, opts...)
+	if err != nil {
+		os.RemoveAll(dir)
+		return nil, err
+	}
+	return bundle, nil
+}
+
+// Extract extracts the bundle archive at archivePath to dir and verifies the
+// checksums of its files. The manifest of the extracted bundle points to the
+// artifacts in dir.
+func Extract(archivePath, dir string, opts ...Option) (*Bundle, error) {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	archive, err := os.Open(archivePath)
+	if err != nil {
+		return nil, err
+	}
+	defer archive.Close()
+
+	if err := extract(archive, dir); err != nil {
+		return nil, fmt.Errorf("extracting bundle %s: %w", archivePath, err)
+	}
+	if err := verifyChecksums(dir); err != nil {
+		return nil, fmt.Errorf("verifying bundle %s: %w", archivePath, err)
+	}
+	if o.verifier != nil {
+		if err := verifyChecksumsSignature(dir, o.verifier); err != nil {
+			return nil, fmt.Errorf("verifying bundle %s: %w", archivePath, err)
+		}
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, ManifestFile))
+	if err != nil {
+		return nil, err
+	}
+	var manifest aws.Manifest
+	if err := yaml.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("invalid yaml data in bundle manifest: %w", err)
+	}
+	if o.verifier != nil {
+		if err := verifySignatures(dir, &manifest, o.verifier); err != nil {
+			return nil, fmt.Errorf("verifying bundle %s: %w", archivePath, err)
+		}
+	}
+
+	bundle := &Bundle{dir: dir, manifest: &manifest}
+	bundle.resolveArtifacts()
+
+	// The resolved manifest is written back so it can be read like a manifest override.
+	resolved, err := yaml.Marshal(bundle.manifest)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(filepath.Join(dir, ManifestFile), resolved, 0o644); err != nil {
+		return nil, err
+	}
+	return bundle, nil
+}
+
+// Manifest returns the release manifest of the bundle.
+func (b *Bundle) Manifest() *aws.Manifest {
+	return b.manifest
+}
+
+// ManifestURI returns the file:// URI of the bundle's manifest, with every
+// artifact pointing to its extracted file.
+func (b *Bundle) ManifestURI() string {
+	return util.FileURI(filepath.Join(b.dir, ManifestFile))
+}
+
+// SSMInstallerOpts configures the SSM installer source to read the installer
+// for the host platform from the bundle.
+func (b *Bundle) SSMInstallerOpts() []ssm.SSMInstallerOption {
+	return []ssm.SSMInstallerOption{
+		ssm.WithURLBuilder(func() (string, error) {
+			platform, err := ssm.Platform()
+			if err != nil {
+				return "", err
+			}
+			installer := filepath.Join(b.dir, filepath.FromSlash(ssm.InstallerPath(platform)))
+			if _, err := os.Stat(installer); err != nil {
+				return "", fmt.Errorf("SSM installer for %s not found in bundle: %w", platform, err)
+			}
+			return util.FileURI(installer), nil
+		}),
+	}
+}
+
+// Close removes the extracted bundle.
+func (b *Bundle) Close() error {
+	return os.RemoveAll(b.dir)
+}
+
+func (b *Bundle) resolveArtifacts() {
+	resolve := func(artifacts []aws.Artifact) {
+		for i := range artifacts {
+			artifacts[i].URI = b.resolve(artifacts[i].URI)
+			artifacts[i].ChecksumURI = b.resolve(artifacts[i].ChecksumURI)
+		}
+	}
+	for i := range b.manifest.SupportedEksReleases {
+		for j := range b.manifest.SupportedEksReleases[i].PatchReleases {
+			resolve(b.manifest.SupportedEksReleases[i].PatchReleases[j].Artifacts)
+		}
+	}
+	for i := range b.manifest.IamRolesAnywhereReleases {
+		resolve(b.manifest.IamRolesAnywhereReleases[i].Artifacts)
+	}
+}
+
+func (b *Bundle) resolve(relative string) string {
+	if relative == "" {
+		return ""
+	}
+	return util.FileURI(filepath.Join(b.dir, filepath.FromSlash(relative)))
+}
+
+func extract(archive io.Reader, dir string) error {
+	decoder, err := zstd.NewReader(archive)
+	if err != nil {
+		return err
+	}
+	defer decoder.Close()
+
+	tr := tar.NewReader(decoder)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if header.Typeflag != tar.TypeReg {
+			return fmt.Errorf("unexpected entry %s in bundle, only regular files are allowed", header.Name)
+		}
+		name, err := cleanName(header.Name)
+		if err != nil {
+			return err
+		}
+		if err := artifact.InstallFile(filepath.Join(dir, name), tr, 0o644); err != nil {
+			return err
+		}
+	}
+}
+
+// cleanName rejects names that would be extracted outside of the bundle directory.
+func cleanName(name string) (string, error) {
+	cleaned := path.Clean(name)
+	if path.IsAbs(cleaned) || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", fmt.Errorf("invalid entry %s in bundle", name)
+	}
+	return filepath.FromSlash(cleaned), nil
+}
+
+// verifyChecksums verifies every file in dir is listed in the checksums file
+// with a matching checksum.
+func verifyChecksums(dir string) error {
+	data, err := os.ReadFile(filepath.Join(dir, ChecksumsFile))
+	if err != nil {
+		return err
+	}
+	expected, err := parseChecksums(data)
+	if err != nil {
+		return err
+	}
+
+	return filepath.WalkDir(dir, func(file string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(dir, file)
+		if err != nil {
+			return err
+		}
+		name := filepath.ToSlash(rel)
+		if name == ChecksumsFile || name == ChecksumsSignatureFile {
+			return nil
+		}
+		checksum, ok := expected[name]
+		if !ok {
+			return fmt.Errorf("%s has no checksum in %s", name, ChecksumsFile)
+		}
+		actual, err := fileSHA256(file)
+		if err != nil {
+			return err
+		}
+		if actual != checksum {
+			return fmt.Errorf("checksum mismatch for %s: expected %s, got %s", name, checksum, actual)
+		}
+		return nil
+	})
+}
+
+// verifyChecksumsSignature verifies the bundle checksums have a valid signature. The
+// artifact signatures only cover their checksums, so without it an artifact could be
+// swapped with another validly signed one, like an older kubelet.
+func verifyChecksumsSignature(dir string, verifier *signature.Verifier) error {
+	checksums, err := os.ReadFile(filepath.Join(dir, ChecksumsFile))
+	if err != nil {
+		return err
+	}
+	sig, err := os.ReadFile(filepath.Join(dir, ChecksumsSignatureFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("bundle has no signature, it must be created with --signing-key")
+	} else if err != nil {
+		return err
+	}
+	if err := verifier.Verify(checksums, sig); err != nil {
+		return fmt.Errorf("verifying bundle signature: %w", err)
+	}
+	return nil
+}
+
+// verifySignatures verifies the checksum file of every artifact in the manifest has a
+// valid signature and the artifact matches it. The bundle checksums file only protects
+// against corruption, since it's written by whoever created the bundle.
+func verifySignatures(dir string, manifest *aws.Manifest, verifier *signature.Verifier) error {
+	var artifacts []aws.Artifact
+	for _, release := range manifest.SupportedEksReleases {
+		for _, patch := range release.PatchReleases {
+			artifacts = append(artifacts, patch.Artifacts...)
+		}
+	}
+	for _, release := range manifest.IamRolesAnywhereReleases {
+		artifacts = append(artifacts, release.Artifacts...)
+	}
+
+	for _, releaseArtifact := range artifacts {
+		checksumFile := filepath.Join(dir, filepath.FromSlash(releaseArtifact.ChecksumURI))
+		checksum, err := os.ReadFile(checksumFile)
+		if err != nil {
+			return err
+		}
+		sig, err := os.ReadFile(checksumFile + signature.Suffix)
+		if errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("%s checksum has no signature, the bundle must be created with --trust-root", releaseArtifact.Name)
+		} else if err != nil {
+			return err
+		}
+		if err := verifier.Verify(checksum, sig); err != nil {
+			return fmt.Errorf("verifying %s checksum signature: %w", releaseArtifact.Name, err)
+		}
+
+		expected, err := artifact.ParseGNUChecksum(checksum)
+		if err != nil {
+			return fmt.Errorf("parsing %s checksum: %w", releaseArtifact.Name, err)
+		}
+		actual, err := fileSHA256(filepath.Join(dir, filepath.FromSlash(releaseArtifact.URI)))
+		if err != nil {
+			return err
+		}
+		if actual != hex.EncodeToString(expected) {
+			return fmt.Errorf("checksum mismatch for %s: expected %s, got %s", releaseArtifact.Name, hex.EncodeToString(expected), actual)
+		}
+	}
+	return nil
+}
+
+func parseChecksums(data []byte) (map[string]string, error) {
+	checksums := map[string]string{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		checksum, name, found := strings.Cut(line, "  ")
+		if !found {
+			return nil, fmt.Errorf("invalid line in %s: %q", ChecksumsFile, line)
+		}
+		checksums[name] = checksum
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(checksums) == 0 {
+		return nil, errors.New("bundle has no checksums")
+	}
+	return checksums, nil
+}
+
+func fileSHA256(file string) (string, error) {
+	fh, err := os.Open(file)
+	if err != nil {
+		return "", err
+	}
+	defer fh.Close()
+
+	digest := sha256.New()
+	if _, err := io.Copy(digest, fh); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(digest.Sum(nil)), nil
+}
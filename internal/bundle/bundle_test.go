@@ -0,0 +1,281 @@
+package bundle
+
+import (
+	"archive/tar"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ProtonMail/gopenpgp/v3/crypto"
+	"github.com/klauspost/compress/zstd"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-hybrid/internal/signature"
+)
+
+const testManifest = `supported_eks_releases:
+- major_minor_version: "1.31"
+  latest_patch_version: "1.31.2"
+  patch_releases:
+  - version: "1.31.2"
+    patch_version: "1.31.2"
+    artifacts:
+    - name: kubelet
+      arch: arm64
+      os: linux
+      uri: eks/kubelet
+      checksum_uri: eks/kubelet.sha256
+`
+
+// writeArchive writes a bundle with files, the checksums of files and then the
+// unlisted files, which have no checksum, to a temp dir.
+func writeArchive(t *testing.T, files, unlisted [][2]string) string {
+	t.Helper()
+	return writeSignedArchive(t, files, unlisted, nil)
+}
+
+// writeSignedArchive writes a bundle like writeArchive, with the signature returned by
+// sign for its checksums, if sign is set.
+func writeSignedArchive(t *testing.T, files, unlisted [][2]string, sign func(checksums []byte) []byte) string {
+	t.Helper()
+	var buf bytes.Buffer
+	encoder, err := zstd.NewWriter(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	archive := &archiveWriter{tw: tar.NewWriter(encoder), modTime: time.Now()}
+	for _, file := range files {
+		if err := archive.addBytes(file[0], []byte(file[1])); err != nil {
+			t.Fatal(err)
+		}
+	}
+	checksums := archive.checksums.Bytes()
+	if err := archive.addBytes(ChecksumsFile, checksums); err != nil {
+		t.Fatal(err)
+	}
+	if sign != nil {
+		if err := archive.addBytes(ChecksumsSignatureFile, sign(checksums)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, file := range unlisted {
+		if err := archive.addBytes(file[0], []byte(file[1])); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := archive.tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := encoder.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(t.TempDir(), "bundle.tar.zst")
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestExtract(t *testing.T) {
+	g := NewWithT(t)
+	archive := writeArchive(t, [][2]string{
+		{ManifestFile, testManifest},
+		{"eks/kubelet", "kubelet binary"},
+		{"eks/kubelet.sha256", "0123  kubelet"},
+		{"ssm/linux_arm64/ssm-setup-cli", "installer"},
+	}, nil)
+	dir := t.TempDir()
+
+	b, err := Extract(archive, dir)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(b.ManifestURI()).To(Equal("file://" + filepath.Join(dir, ManifestFile)))
+
+	kubelet := b.Manifest().SupportedEksReleases[0].PatchReleases[0].Artifacts[0]
+	g.Expect(kubelet.URI).To(Equal("file://" + filepath.Join(dir, "eks", "kubelet")))
+	g.Expect(kubelet.ChecksumURI).To(Equal("file://" + filepath.Join(dir, "eks", "kubelet.sha256")))
+	g.Expect(os.ReadFile(filepath.Join(dir, ManifestFile))).To(ContainSubstring(kubelet.URI))
+
+	g.Expect(b.Close()).To(Succeed())
+	g.Expect(dir).NotTo(BeADirectory())
+}
+
+func TestExtractChecksumMismatch(t *testing.T) {
+	g := NewWithT(t)
+	// The manifest is overwritten by a later entry with different contents.
+	archive := writeArchive(t, [][2]string{
+		{ManifestFile, testManifest},
+	}, [][2]string{
+		{ManifestFile, "tampered"},
+	})
+
+	_, err := Extract(archive, t.TempDir())
+	g.Expect(err).To(MatchError(ContainSubstring("checksum mismatch for " + ManifestFile)))
+}
+
+func TestExtractFileWithoutChecksum(t *testing.T) {
+	g := NewWithT(t)
+	archive := writeArchive(t, [][2]string{
+		{ManifestFile, testManifest},
+	}, [][2]string{
+		{"eks/kubelet", "kubelet binary"},
+	})
+
+	_, err := Extract(archive, t.TempDir())
+	g.Expect(err).To(MatchError(ContainSubstring("eks/kubelet has no checksum")))
+}
+
+func TestExtractPathTraversal(t *testing.T) {
+	g := NewWithT(t)
+	archive := writeArchive(t, [][2]string{
+		{"../kubelet", "kubelet binary"},
+	}, nil)
+
+	_, err := Extract(archive, t.TempDir())
+	g.Expect(err).To(MatchError(ContainSubstring("invalid entry ../kubelet in bundle")))
+}
+
+func TestExtractWithVerifier(t *testing.T) {
+	g := NewWithT(t)
+	key, err := crypto.PGP().KeyGeneration().AddUserId("test", "test@example.com").New().GenerateKey()
+	g.Expect(err).NotTo(HaveOccurred())
+	publicKey, err := key.GetArmoredPublicKey()
+	g.Expect(err).NotTo(HaveOccurred())
+	verifier, err := signature.NewVerifier(publicKey)
+	g.Expect(err).NotTo(HaveOccurred())
+	signer, err := crypto.PGP().Sign().SigningKey(key).Detached().New()
+	g.Expect(err).NotTo(HaveOccurred())
+	untrustedKey, err := crypto.PGP().KeyGeneration().AddUserId("untrusted", "untrusted@example.com").New().GenerateKey()
+	g.Expect(err).NotTo(HaveOccurred())
+	untrustedSigner, err := crypto.PGP().Sign().SigningKey(untrustedKey).Detached().New()
+	g.Expect(err).NotTo(HaveOccurred())
+	signWith := func(signer crypto.PGPSign) func([]byte) []byte {
+		return func(data []byte) []byte {
+			sig, err := signer.Sign(data, crypto.Armor)
+			g.Expect(err).NotTo(HaveOccurred())
+			return sig
+		}
+	}
+
+	kubelet := "kubelet binary"
+	sum := sha256.Sum256([]byte(kubelet))
+	checksum := hex.EncodeToString(sum[:]) + "  kubelet"
+	sig, err := signer.Sign([]byte(checksum), crypto.Armor)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	// An older kubelet with a validly signed checksum, swapped into a signed bundle.
+	olderKubelet := "older kubelet binary"
+	olderSum := sha256.Sum256([]byte(olderKubelet))
+	olderChecksum := hex.EncodeToString(olderSum[:]) + "  kubelet"
+	olderSig, err := signer.Sign([]byte(olderChecksum), crypto.Armor)
+	g.Expect(err).NotTo(HaveOccurred())
+	// The signed checksums of the bundle with the current kubelet.
+	var originalChecksums []byte
+	writeSignedArchive(t, [][2]string{
+		{ManifestFile, testManifest},
+		{"eks/kubelet", kubelet},
+		{"eks/kubelet.sha256", checksum},
+		{"eks/kubelet.sha256.sig", string(sig)},
+	}, nil, func(checksums []byte) []byte {
+		originalChecksums = bytes.Clone(checksums)
+		return signWith(signer)(checksums)
+	})
+
+	tests := []struct {
+		name  string
+		files [][2]string
+		// sign signs the bundle checksums, with the trusted key if nil.
+		sign     func([]byte) []byte
+		unsigned bool
+		wantErr  string
+	}{
+		{
+			name: "signed",
+			files: [][2]string{
+				{"eks/kubelet", kubelet},
+				{"eks/kubelet.sha256", checksum},
+				{"eks/kubelet.sha256.sig", string(sig)},
+			},
+		},
+		{
+			name: "unsigned bundle",
+			files: [][2]string{
+				{"eks/kubelet", kubelet},
+				{"eks/kubelet.sha256", checksum},
+				{"eks/kubelet.sha256.sig", string(sig)},
+			},
+			unsigned: true,
+			wantErr:  "bundle has no signature",
+		},
+		{
+			name: "bundle signed by an untrusted key",
+			files: [][2]string{
+				{"eks/kubelet", kubelet},
+				{"eks/kubelet.sha256", checksum},
+				{"eks/kubelet.sha256.sig", string(sig)},
+			},
+			sign:    signWith(untrustedSigner),
+			wantErr: "verifying bundle signature",
+		},
+		{
+			name: "swapped signed artifact",
+			files: [][2]string{
+				{"eks/kubelet", olderKubelet},
+				{"eks/kubelet.sha256", olderChecksum},
+				{"eks/kubelet.sha256.sig", string(olderSig)},
+			},
+			sign:    func([]byte) []byte { return signWith(signer)(originalChecksums) },
+			wantErr: "verifying bundle signature",
+		},
+		{
+			name: "unsigned",
+			files: [][2]string{
+				{"eks/kubelet", kubelet},
+				{"eks/kubelet.sha256", checksum},
+			},
+			wantErr: "kubelet checksum has no signature",
+		},
+		{
+			name: "altered checksum",
+			files: [][2]string{
+				{"eks/kubelet", "altered"},
+				{"eks/kubelet.sha256", "0123  kubelet"},
+				{"eks/kubelet.sha256.sig", string(sig)},
+			},
+			wantErr: "verifying kubelet checksum signature",
+		},
+		{
+			name: "altered binary",
+			files: [][2]string{
+				{"eks/kubelet", "altered"},
+				{"eks/kubelet.sha256", checksum},
+				{"eks/kubelet.sha256.sig", string(sig)},
+			},
+			wantErr: "checksum mismatch for kubelet",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := NewWithT(t)
+			sign := test.sign
+			if sign == nil {
+				sign = signWith(signer)
+			}
+			if test.unsigned {
+				sign = nil
+			}
+			archive := writeSignedArchive(t, append([][2]string{{ManifestFile, testManifest}}, test.files...), nil, sign)
+
+			_, err := Extract(archive, t.TempDir(), WithVerifier(verifier))
+			if test.wantErr == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(test.wantErr)))
+			}
+		})
+	}
+}
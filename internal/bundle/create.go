@@ -0,0 +1,256 @@
+package bundle
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+	"go.uber.org/zap"
+	"golang.org/x/mod/semver"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-hybrid/internal/artifact"
+	"github.com/aws/eks-hybrid/internal/aws"
+	"github.com/aws/eks-hybrid/internal/signature"
+	"github.com/aws/eks-hybrid/internal/ssm"
+	"github.com/aws/eks-hybrid/internal/util"
+)
+
+// Creator packages the artifacts of a release for an architecture into an offline
+// install bundle.
+type Creator struct {
+	// Manifest is the release manifest. Its region config is copied to the bundle
+	// so it can be installed in any region.
+	Manifest *aws.Manifest
+	// Source is the release to bundle.
+	Source aws.Source
+	Arch   string
+	// Region is the region the SSM installer is downloaded from.
+	Region     string
+	Downloader *artifact.Downloader
+	Logger     *zap.Logger
+	// Signer signs the bundle checksums, if set.
+	Signer *signature.Signer
+}
+
+const bundleOS = "linux"
+
+// Write downloads the artifacts and writes the bundle archive, zstd compressed, to w.
+func (c *Creator) Write(ctx context.Context, w io.Writer) error {
+	eksArtifacts, err := c.addArtifacts(ctx, eksDir, c.Source.Eks.Artifacts)
+	if err != nil {
+		return err
+	}
+	iamArtifacts, err := c.addArtifacts(ctx, iamRolesAnywhereDir, c.Source.Iam.Artifacts)
+	if err != nil {
+		return err
+	}
+	if len(eksArtifacts.artifacts) == 0 {
+		return fmt.Errorf("no artifacts found for architecture %s and OS %s", c.Arch, bundleOS)
+	}
+
+	encoder, err := zstd.NewWriter(w)
+	if err != nil {
+		return err
+	}
+	archive := &archiveWriter{tw: tar.NewWriter(encoder), modTime: time.Now()}
+
+	manifest, err := c.manifest(eksArtifacts.artifacts, iamArtifacts.artifacts)
+	if err != nil {
+		return err
+	}
+	if err := archive.addBytes(ManifestFile, manifest); err != nil {
+		return err
+	}
+	for _, files := range []*bundleFiles{eksArtifacts, iamArtifacts} {
+		for _, file := range files.files {
+			if err := archive.add(file); err != nil {
+				return err
+			}
+		}
+	}
+	if err := c.addSSMInstallers(ctx, archive); err != nil {
+		return err
+	}
+
+	checksums := archive.checksums.Bytes()
+	if err := archive.addBytes(ChecksumsFile, checksums); err != nil {
+		return err
+	}
+	if c.Signer != nil {
+		sig, err := c.Signer.Sign(checksums)
+		if err != nil {
+			return fmt.Errorf("signing bundle: %w", err)
+		}
+		if err := archive.addBytes(ChecksumsSignatureFile, sig); err != nil {
+			return err
+		}
+	}
+	if err := archive.tw.Close(); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+// bundleFiles are the files to add to the bundle for a list of artifacts and
+// the artifacts pointing to them.
+type bundleFiles struct {
+	artifacts []aws.Artifact
+	files     []bundleFile
+}
+
+type bundleFile struct {
+	name string
+	// path is where the file is on disk. If empty, data is used.
+	path string
+	data []byte
+}
+
+// addArtifacts downloads the artifacts for the bundle architecture to the cache
+// and returns the files to add to the bundle under dir.
+func (c *Creator) addArtifacts(ctx context.Context, dir string, artifacts []aws.Artifact) (*bundleFiles, error) {
+	bundled := &bundleFiles{}
+	for _, releaseArtifact := range artifacts {
+		if releaseArtifact.Arch != c.Arch || releaseArtifact.OS != bundleOS {
+			continue
+		}
+
+		checksum, err := c.Source.Checksum(ctx, releaseArtifact)
+		if err != nil {
+			return nil, err
+		}
+		parsed, err := artifact.ParseGNUChecksum(checksum)
+		if err != nil {
+			return nil, fmt.Errorf("parsing %s checksum: %w", releaseArtifact.Name, err)
+		}
+		download := artifact.Download{Name: releaseArtifact.Name, URL: releaseArtifact.URI, Checksum: parsed}
+		if releaseArtifact.GzipURI != "" {
+			download.URL = releaseArtifact.GzipURI
+			download.Gzipped = true
+		}
+		cached, err := c.Downloader.Get(ctx, download)
+		if err != nil {
+			return nil, err
+		}
+
+		name := path.Join(dir, releaseArtifact.Name)
+		bundled.files = append(bundled.files,
+			bundleFile{name: name, path: cached},
+			bundleFile{name: name + checksumSuffix, data: checksum},
+		)
+		if c.Source.Verifier != nil {
+			// The signatures are bundled so the bundle can be verified with the same trust root.
+			sig, err := c.Source.ChecksumSignature(ctx, releaseArtifact)
+			if err != nil {
+				return nil, err
+			}
+			bundled.files = append(bundled.files, bundleFile{name: name + checksumSuffix + signature.Suffix, data: sig})
+		}
+		bundled.artifacts = append(bundled.artifacts, aws.Artifact{
+			Name:        releaseArtifact.Name,
+			Arch:        releaseArtifact.Arch,
+			OS:          releaseArtifact.OS,
+			URI:         name,
+			ChecksumURI: name + checksumSuffix,
+		})
+	}
+	return bundled, nil
+}
+
+// addSSMInstallers adds the SSM installer and its signature for every platform of
+// the bundle architecture, since the platform depends on the host package manager.
+func (c *Creator) addSSMInstallers(ctx context.Context, archive *archiveWriter) error {
+	for _, platform := range ssm.Platforms(c.Arch) {
+		url := ssm.InstallerURL(c.Region, c.Source.RegionInfo.DnsSuffix, platform)
+		name := ssm.InstallerPath(platform)
+		c.Logger.Info("Downloading SSM installer", zap.String("platform", platform), zap.String("url", url))
+		for _, suffix := range []string{"", ssmSignatureSuffix} {
+			data, err := util.ReadURI(ctx, url+suffix)
+			if err != nil {
+				return fmt.Errorf("downloading %s: %w", url+suffix, err)
+			}
+			if err := archive.addBytes(name+suffix, data); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (c *Creator) manifest(eksArtifacts, iamArtifacts []aws.Artifact) ([]byte, error) {
+	eksRelease := c.Source.Eks
+	eksRelease.Artifacts = eksArtifacts
+	manifest := aws.Manifest{
+		SupportedEksReleases: []aws.SupportedEksRelease{
+			{
+				MajorMinorVersion:  strings.TrimPrefix(semver.MajorMinor("v"+eksRelease.Version), "v"),
+				LatestPatchVersion: eksRelease.PatchVersion,
+				PatchReleases:      []aws.EksPatchRelease{eksRelease},
+			},
+		},
+		RegionConfig: c.Manifest.RegionConfig,
+	}
+	if len(iamArtifacts) > 0 {
+		manifest.IamRolesAnywhereReleases = []aws.IamRolesAnywhereRelease{
+			{Version: c.Source.Iam.Version, Artifacts: iamArtifacts},
+		}
+	}
+	return yaml.Marshal(manifest)
+}
+
+// archiveWriter writes files to a tar archive and records their checksums.
+type archiveWriter struct {
+	tw        *tar.Writer
+	modTime   time.Time
+	checksums bytes.Buffer
+}
+
+func (a *archiveWriter) add(file bundleFile) error {
+	if file.path == "" {
+		return a.addBytes(file.name, file.data)
+	}
+	fh, err := os.Open(file.path)
+	if err != nil {
+		return err
+	}
+	defer fh.Close()
+	info, err := fh.Stat()
+	if err != nil {
+		return err
+	}
+	return a.addReader(file.name, info.Size(), fh)
+}
+
+func (a *archiveWriter) addBytes(name string, data []byte) error {
+	return a.addReader(name, int64(len(data)), bytes.NewReader(data))
+}
+
+func (a *archiveWriter) addReader(name string, size int64, r io.Reader) error {
+	header := &tar.Header{
+		Name:     name,
+		Mode:     0o644,
+		Size:     size,
+		ModTime:  a.modTime,
+		Typeflag: tar.TypeReg,
+	}
+	if err := a.tw.WriteHeader(header); err != nil {
+		return err
+	}
+	digest := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(a.tw, digest), r); err != nil {
+		return fmt.Errorf("adding %s to bundle: %w", name, err)
+	}
+	if name != ChecksumsFile && name != ChecksumsSignatureFile {
+		fmt.Fprintf(&a.checksums, "%s  %s\n", hex.EncodeToString(digest.Sum(nil)), name)
+	}
+	return nil
+}
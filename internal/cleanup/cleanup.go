@@ -54,6 +54,15 @@ func (c *Force) Cleanup() error {
 	return nil
 }
 
+// Directories returns the directories removed by Cleanup.
+func (c *Force) Directories() []string {
+	dirs := make([]string, 0, len(cleanupDirs))
+	for _, dir := range cleanupDirs {
+		dirs = append(dirs, filepath.Join(c.rootDir, strings.TrimPrefix(dir, "/")))
+	}
+	return dirs
+}
+
 func (c *Force) removeDir(dir string) error {
 	c.logger.Info("Removing directory", zap.String("path", dir))
 	return os.RemoveAll(dir)
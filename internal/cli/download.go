@@ -0,0 +1,143 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/integrii/flaggy"
+	"go.uber.org/zap"
+
+	"github.com/aws/eks-hybrid/internal/artifact"
+	"github.com/aws/eks-hybrid/internal/aws/ecr"
+	"github.com/aws/eks-hybrid/internal/aws/s3"
+	"github.com/aws/eks-hybrid/internal/configprovider"
+	"github.com/aws/eks-hybrid/internal/iamrolesanywhere"
+	"github.com/aws/eks-hybrid/internal/util"
+)
+
+// Environment variables with the credentials for artifact mirrors. They are read
+// from the environment so they don't show up in the process list.
+const (
+	MirrorUsernameEnv = "NODEADM_MIRROR_USERNAME"
+	MirrorPasswordEnv = "NODEADM_MIRROR_PASSWORD"
+	MirrorTokenEnv    = "NODEADM_MIRROR_TOKEN"
+)
+
+// DownloadFlags configures how release artifacts are downloaded.
+type DownloadFlags struct {
+	Workers        int
+	CacheDir       string
+	MirrorCABundle string
+	// MirrorHosts are the hosts, as host or host:port, the mirror credentials are sent to.
+	MirrorHosts []string
+}
+
+// RegisterDownloadFlags adds the artifact download flags to cmd.
+func RegisterDownloadFlags(cmd *flaggy.Subcommand) *DownloadFlags {
+	flags := &DownloadFlags{
+		Workers:  artifact.DefaultDownloadWorkers,
+		CacheDir: artifact.DefaultCacheDir,
+	}
+	cmd.Int(&flags.Workers, "", "download-workers", "Maximum number of artifacts downloaded concurrently.")
+	cmd.String(&flags.CacheDir, "", "download-cache-dir", "Directory where downloaded artifacts are cached and partial downloads are resumed from.")
+	cmd.String(&flags.MirrorCABundle, "", "mirror-ca-bundle", "Path to a PEM CA bundle trusted for https:// and oci:// artifact mirrors, in addition to the system roots.")
+	cmd.StringSlice(&flags.MirrorHosts, "", "mirror-host", "Host, as host or host:port, of an https:// or oci:// artifact mirror the mirror credentials are sent to. Can be repeated.")
+	return flags
+}
+
+// Fetchers returns the artifact fetchers for every supported URI scheme:
+//   - file:// reads from disk.
+//   - http(s):// uses the mirror CA bundle and, for the mirror hosts, the basic
+//     or bearer credentials from the environment.
+//   - s3:// uses the node AWS credentials. Buckets outside region must set ?region=.
+//   - oci:// pulls blobs from OCI registries, with ECR credentials for ECR
+//     registries and the basic credentials from the environment for the mirror hosts.
+func (f *DownloadFlags) Fetchers(region string) (artifact.Fetchers, error) {
+	client, err := MirrorHTTPClient(f.MirrorCABundle)
+	if err != nil {
+		return nil, err
+	}
+
+	if username, _, token := MirrorCredentials(); (username != "" || token != "") && len(f.MirrorHosts) == 0 {
+		return nil, fmt.Errorf("mirror credentials are set in the environment, set --mirror-host to the hosts they are sent to")
+	}
+	httpOpts := append(MirrorHTTPOpts(client), artifact.WithAuthHosts(f.MirrorHosts...))
+	httpFetcher := artifact.NewHTTPFetcher(httpOpts...)
+
+	var s3ConfigOpts []func(*config.LoadOptions) error
+	if _, err := os.Stat(iamrolesanywhere.DefaultAWSConfigPath); err == nil {
+		s3ConfigOpts = append(s3ConfigOpts,
+			config.WithSharedConfigFiles([]string{iamrolesanywhere.DefaultAWSConfigPath}),
+			config.WithSharedConfigProfile(iamrolesanywhere.ProfileName),
+		)
+	}
+
+	fetchers := artifact.DefaultFetchers()
+	fetchers["http"] = httpFetcher
+	fetchers["https"] = httpFetcher
+	fetchers["s3"] = s3.NewFetcher(region, s3ConfigOpts...)
+	fetchers["oci"] = MirrorOCIFetcher(client, f.MirrorHosts)
+	return fetchers, nil
+}
+
+// MirrorHTTPClient returns the HTTP client for artifact mirrors, trusting the PEM
+// CA bundle at caBundle, if set, in addition to the system roots.
+func MirrorHTTPClient(caBundle string) (*http.Client, error) {
+	return configprovider.NewCABundleClient(caBundle)
+}
+
+// MirrorHTTPOpts returns the options for HTTP requests to artifact mirrors, sent with
+// client and authenticated with the basic or bearer credentials from the environment.
+func MirrorHTTPOpts(client *http.Client) []artifact.HTTPFetcherOpt {
+	opts := []artifact.HTTPFetcherOpt{
+		artifact.WithClient(client),
+		artifact.WithHTTPUserAgent(util.UserAgent()),
+	}
+	username, password, token := MirrorCredentials()
+	if token != "" {
+		opts = append(opts, artifact.WithBearerToken(token))
+	} else if username != "" {
+		opts = append(opts, artifact.WithBasicAuth(username, password))
+	}
+	return opts
+}
+
+// MirrorCredentials returns the artifact mirror credentials from the environment.
+func MirrorCredentials() (username, password, token string) {
+	return os.Getenv(MirrorUsernameEnv), os.Getenv(MirrorPasswordEnv), os.Getenv(MirrorTokenEnv)
+}
+
+// MirrorOCIFetcher returns an OCI fetcher that authenticates to ECR registries with the
+// AWS credentials and to the registries in hosts with the mirror credentials.
+// Other registries are accessed anonymously.
+func MirrorOCIFetcher(client *http.Client, hosts []string) *artifact.OCIFetcher {
+	username, password, _ := MirrorCredentials()
+	registryCredentials := func(ctx context.Context, registry string) (string, string, error) {
+		if _, ok := ecr.RegistryRegion(registry); ok {
+			return ecr.RegistryCredentials(ctx, registry)
+		}
+		if !slices.Contains(hosts, registry) {
+			return "", "", nil
+		}
+		return username, password, nil
+	}
+	return artifact.NewOCIFetcher(
+		artifact.WithOCIClient(client),
+		artifact.WithOCIUserAgent(util.UserAgent()),
+		artifact.WithRegistryCredentials(registryCredentials),
+	)
+}
+
+// Downloader returns an artifact Downloader configured with the flags that reads
+// artifacts with fetchers.
+func (f *DownloadFlags) Downloader(log *zap.Logger, fetchers artifact.Fetchers) *artifact.Downloader {
+	return artifact.NewDownloader(log,
+		artifact.WithDownloadWorkers(f.Workers),
+		artifact.WithCacheDir(f.CacheDir),
+		artifact.WithFetchers(fetchers),
+	)
+}
@@ -3,23 +3,25 @@ package cli
 import (
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+
+	"github.com/aws/eks-hybrid/internal/logger"
 )
 
 func NewLogger(opts *GlobalOptions) *zap.Logger {
-	var logger *zap.Logger
+	var log *zap.Logger
 	var err error
 
 	if opts.DevelopmentMode {
-		logger, err = zap.NewDevelopment()
+		log, err = zap.NewDevelopment(logger.RedactSecrets())
 	} else {
 		config := zap.NewProductionConfig()
 		config.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
 		config.DisableStacktrace = true
-		logger, err = config.Build()
+		log, err = config.Build(logger.RedactSecrets())
 	}
 	if err != nil {
 		panic(err)
 	}
-	zap.ReplaceGlobals(logger)
-	return logger
+	zap.ReplaceGlobals(log)
+	return log
 }
@@ -0,0 +1,45 @@
+package cli
+
+import (
+	"context"
+
+	"github.com/integrii/flaggy"
+
+	"github.com/aws/eks-hybrid/internal/aws"
+	"github.com/aws/eks-hybrid/internal/ssm"
+)
+
+// ManifestFlags configures how commands that inspect release manifests read them.
+type ManifestFlags struct {
+	Region    string
+	download  *DownloadFlags
+	signature *SignatureFlags
+}
+
+// RegisterManifestFlags adds the flags to read release manifests to cmd.
+func RegisterManifestFlags(cmd *flaggy.Subcommand) *ManifestFlags {
+	flags := &ManifestFlags{
+		Region:    ssm.DefaultSsmInstallerRegion,
+		download:  &DownloadFlags{},
+		signature: RegisterSignatureFlags(cmd),
+	}
+	cmd.String(&flags.Region, "r", "region", "AWS region used to select the default manifest and to read s3:// manifests.")
+	cmd.String(&flags.download.MirrorCABundle, "", "mirror-ca-bundle", "Path to a PEM CA bundle trusted for https:// and oci:// manifest mirrors, in addition to the system roots.")
+	cmd.StringSlice(&flags.download.MirrorHosts, "", "mirror-host", "Host, as host or host:port, of an https:// or oci:// manifest mirror the mirror credentials are sent to. Can be repeated.")
+	return flags
+}
+
+// GetManifest reads the release manifest at manifestURI or, if it's empty, the default
+// release manifest for the region.
+func (f *ManifestFlags) GetManifest(ctx context.Context, manifestURI string) (*aws.Manifest, error) {
+	opts, err := f.signature.ManifestOpts()
+	if err != nil {
+		return nil, err
+	}
+	fetchers, err := f.download.Fetchers(f.Region)
+	if err != nil {
+		return nil, err
+	}
+	opts = append(opts, aws.WithFetchers(fetchers))
+	return aws.GetManifest(ctx, f.Region, manifestURI, opts...)
+}
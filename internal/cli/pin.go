@@ -0,0 +1,45 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/integrii/flaggy"
+
+	"github.com/aws/eks-hybrid/internal/aws"
+)
+
+// PinFlags configures the artifacts installed from a different release than the
+// latest one for the Kubernetes version.
+type PinFlags struct {
+	Pins []string
+}
+
+// RegisterPinFlags adds the artifact pinning flags to cmd.
+func RegisterPinFlags(cmd *flaggy.Subcommand) *PinFlags {
+	flags := &PinFlags{}
+	cmd.StringSlice(&flags.Pins, "", "pin", "Install an artifact from a specific release, as <artifact>=<version>. "+
+		"aws_signing_helper takes an IAM Roles Anywhere release version. ecr-credential-provider, aws-iam-authenticator and cni-plugins "+
+		"take an EKS release major.minor.patch version, or major.minor for its latest patch. Can be repeated.")
+	return flags
+}
+
+// ManifestOpts returns the options to install the pinned artifacts, if any.
+func (f *PinFlags) ManifestOpts() ([]aws.ManifestOption, error) {
+	if len(f.Pins) == 0 {
+		return nil, nil
+	}
+	versions := map[string]string{}
+	for _, pin := range f.Pins {
+		name, version, ok := strings.Cut(pin, "=")
+		name, version = strings.TrimSpace(name), strings.TrimSpace(version)
+		if !ok || name == "" || version == "" {
+			return nil, fmt.Errorf("invalid --pin %q, expected <artifact>=<version>", pin)
+		}
+		if existing, ok := versions[name]; ok && existing != version {
+			return nil, fmt.Errorf("%s is pinned to both %s and %s", name, existing, version)
+		}
+		versions[name] = version
+	}
+	return []aws.ManifestOption{aws.WithPinnedVersions(versions)}, nil
+}
@@ -0,0 +1,56 @@
+package cli
+
+import (
+	"github.com/integrii/flaggy"
+
+	"github.com/aws/eks-hybrid/internal/aws"
+	"github.com/aws/eks-hybrid/internal/bundle"
+	"github.com/aws/eks-hybrid/internal/signature"
+)
+
+// SignatureFlags configures the trust root used to verify the signatures of the
+// release manifest and artifact checksums.
+type SignatureFlags struct {
+	TrustRoots []string
+}
+
+// RegisterSignatureFlags adds the signature verification flags to cmd.
+func RegisterSignatureFlags(cmd *flaggy.Subcommand) *SignatureFlags {
+	flags := &SignatureFlags{}
+	cmd.StringSlice(&flags.TrustRoots, "", "trust-root", "Path to an armored OpenPGP public key trusted to sign the release manifest and artifact checksums. "+
+		"When set, the manifest and every checksum file must have a valid detached signature at the same URI with a .sig suffix. Can be repeated.")
+	return flags
+}
+
+// Enabled returns true if a trust root is configured.
+func (f *SignatureFlags) Enabled() bool {
+	return len(f.TrustRoots) > 0
+}
+
+// Verifier returns the verifier for the trust root, or nil if none is configured.
+func (f *SignatureFlags) Verifier() (*signature.Verifier, error) {
+	if !f.Enabled() {
+		return nil, nil
+	}
+	return signature.LoadVerifier(f.TrustRoots...)
+}
+
+// ManifestOpts returns the options to verify the release manifest and artifact
+// checksums with the trust root, if configured.
+func (f *SignatureFlags) ManifestOpts() ([]aws.ManifestOption, error) {
+	verifier, err := f.Verifier()
+	if err != nil || verifier == nil {
+		return nil, err
+	}
+	return []aws.ManifestOption{aws.WithSignatureVerifier(verifier)}, nil
+}
+
+// BundleOpts returns the options to verify the artifact checksums in a bundle
+// with the trust root, if configured.
+func (f *SignatureFlags) BundleOpts() ([]bundle.Option, error) {
+	verifier, err := f.Verifier()
+	if err != nil || verifier == nil {
+		return nil, err
+	}
+	return []bundle.Option{bundle.WithVerifier(verifier)}, nil
+}
@@ -0,0 +1,40 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/integrii/flaggy"
+
+	"github.com/aws/eks-hybrid/internal/validation"
+)
+
+// ValidationPolicyFlags allows users to override the timeout and retries of
+// individual validations by name, the same names accepted by --skip, and how
+// many validations run at the same time.
+type ValidationPolicyFlags struct {
+	Timeouts []string
+	Retries  []string
+	Parallel int
+}
+
+// RegisterValidationPolicyFlags adds the validation policy flags to cmd.
+func RegisterValidationPolicyFlags(cmd *flaggy.Subcommand) *ValidationPolicyFlags {
+	flags := &ValidationPolicyFlags{Parallel: validation.DefaultWorkers}
+	cmd.StringSlice(&flags.Timeouts, "", "validation-timeout", "Override the timeout of a validation in the format name=duration. Example: k8s-endpoint-network=30s")
+	cmd.StringSlice(&flags.Retries, "", "validation-retries", "Retry a failing validation up to a number of times in the format name=count. Example: aws-auth=3")
+	cmd.Int(&flags.Parallel, "", "validation-parallel", "Maximum number of validations to run at the same time. Use 1 to run them one after the other.")
+	return flags
+}
+
+// RunnerOpts returns the validation Runner options for the flags.
+// Only the validations in validations can be overridden.
+func (f *ValidationPolicyFlags) RunnerOpts(validations []string) ([]validation.RunnerOpt, error) {
+	if f.Parallel < 1 {
+		return nil, fmt.Errorf("invalid --validation-parallel %d, must be at least 1", f.Parallel)
+	}
+	opts, err := validation.ParsePolicyOverrides(f.Timeouts, f.Retries, validations)
+	if err != nil {
+		return nil, err
+	}
+	return append(opts, validation.WithWorkers(f.Parallel)), nil
+}
@@ -37,28 +37,31 @@ type InstallOptions struct {
 	Tracker     *tracker.Tracker
 }
 
-func Install(ctx context.Context, opts InstallOptions) error {
-	if err := installFromSource(ctx, opts); err != nil {
-		return err
+// Install installs the cni-plugins and returns the paths of the plugin binaries it installed.
+func Install(ctx context.Context, opts InstallOptions) ([]string, error) {
+	plugins, err := installFromSource(ctx, opts)
+	if err != nil {
+		return nil, err
 	}
 
 	if err := opts.Tracker.Add(artifact.CniPlugins); err != nil {
-		return errors.Wrap(err, "adding cni-plugins to tracker")
+		return nil, errors.Wrap(err, "adding cni-plugins to tracker")
 	}
 
-	return nil
+	return plugins, nil
 }
 
-func installFromSource(ctx context.Context, opts InstallOptions) error {
+func installFromSource(ctx context.Context, opts InstallOptions) ([]string, error) {
 	if err := downloadFileWithRetries(ctx, opts); err != nil {
-		return errors.Wrap(err, "installing cni-plugins")
+		return nil, errors.Wrap(err, "installing cni-plugins")
 	}
 
-	if err := artifact.InstallTarGz(filepath.Join(opts.InstallRoot, BinPath), filepath.Join(opts.InstallRoot, TgzPath)); err != nil {
-		return errors.Wrap(err, "extracting and installing cni-plugins")
+	plugins, err := artifact.InstallTarGz(filepath.Join(opts.InstallRoot, BinPath), filepath.Join(opts.InstallRoot, TgzPath))
+	if err != nil {
+		return nil, errors.Wrap(err, "extracting and installing cni-plugins")
 	}
 
-	return nil
+	return plugins, nil
 }
 
 func downloadFileWithRetries(ctx context.Context, opts InstallOptions) error {
@@ -96,17 +99,19 @@ func Uninstall() error {
 	return os.RemoveAll(rootDir)
 }
 
-// Upgrade re-installs the cni-plugins available from the source
+// Upgrade re-installs the cni-plugins available from the source and returns the paths
+// of the plugin binaries it installed.
 // Since cni-plugins is delivered as a tarball, its not possible to check if they are due for an upgrade
 // todo: (@vignesh-goutham) check if we can publish cni-plugins independently with their checksum on our manifest
-func Upgrade(ctx context.Context, src Source, log *zap.Logger) error {
+func Upgrade(ctx context.Context, src Source, log *zap.Logger) ([]string, error) {
 	opts := InstallOptions{
 		Source: src,
 		Logger: log,
 	}
-	if err := installFromSource(ctx, opts); err != nil {
-		return errors.Wrapf(err, "upgrading cni-plugins")
+	plugins, err := installFromSource(ctx, opts)
+	if err != nil {
+		return nil, errors.Wrapf(err, "upgrading cni-plugins")
 	}
 	log.Info("Upgraded", zap.String("artifact", artifactName))
-	return nil
+	return plugins, nil
 }
@@ -50,12 +50,17 @@ func TestInstall(t *testing.T) {
 		BinaryName:   "cni-plugins.tgz",
 		Data:         tarGzFile(g),
 		Install: func(ctx context.Context, tempDir string, source aws.Source, tr *tracker.Tracker) error {
-			return cni.Install(ctx, cni.InstallOptions{
+			plugins, err := cni.Install(ctx, cni.InstallOptions{
 				Tracker:     tr,
 				Source:      source,
 				Logger:      zap.NewNop(),
 				InstallRoot: tempDir,
 			})
+			if err != nil {
+				return err
+			}
+			g.Expect(plugins).To(ContainElement(filepath.Join(tempDir, cni.BinPath, "fake-plugin")))
+			return nil
 		},
 		Verify: func(g *GomegaWithT, tempDir string, tr *tracker.Tracker) {
 			g.Expect(tr.Artifacts.CniPlugins).To(BeTrue())
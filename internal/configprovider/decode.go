@@ -0,0 +1,75 @@
+package configprovider
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+
+	internalapi "github.com/aws/eks-hybrid/internal/api"
+)
+
+// nodeConfigDecoder decodes a single NodeConfig document.
+type nodeConfigDecoder func(data []byte) (*internalapi.NodeConfig, error)
+
+// decodeConfig decodes configuration data from any config source. The data can be
+// a MIME multipart document with application/node.eks.aws parts, a `---` separated
+// multi-document YAML stream or a single NodeConfig. When multiple NodeConfigs are
+// found, they are merged in order.
+func decodeConfig(data []byte, decode nodeConfigDecoder) (*internalapi.NodeConfig, error) {
+	// if the MIME data fails to parse as a multipart document, then fall back
+	// to parsing the data as YAML documents.
+	if multipartReader, err := getMIMEMultipartReader(data); err == nil {
+		return parseMultipart(multipartReader, decode)
+	}
+
+	documents, err := splitYAMLDocuments(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(documents) <= 1 {
+		return decode(data)
+	}
+
+	var nodeConfigs []*internalapi.NodeConfig
+	for i, document := range documents {
+		config, err := decode(document)
+		if err != nil {
+			return nil, fmt.Errorf("decoding document %d: %w", i+1, err)
+		}
+		nodeConfigs = append(nodeConfigs, config)
+	}
+	return mergeNodeConfigs(nodeConfigs)
+}
+
+// splitYAMLDocuments splits a YAML stream into its documents, dropping empty ones.
+func splitYAMLDocuments(data []byte) ([][]byte, error) {
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(data)))
+	var documents [][]byte
+	for {
+		document, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if isEmptyYAMLDocument(document) {
+			continue
+		}
+		documents = append(documents, document)
+	}
+	return documents, nil
+}
+
+func isEmptyYAMLDocument(document []byte) bool {
+	for _, line := range bytes.Split(document, []byte("\n")) {
+		line = bytes.TrimSpace(line)
+		if len(line) > 0 && line[0] != '#' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,66 @@
+package configprovider
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	apibridge "github.com/aws/eks-hybrid/internal/api/bridge"
+)
+
+func TestDecodeConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr string
+	}{
+		{
+			name: "multi-document yaml",
+			data: completeNodeConfig + partialNodeConfig,
+		},
+		{
+			name: "multi-document yaml with empty documents",
+			data: "---\n# comment only\n" + completeNodeConfig + "---\n" + partialNodeConfig + "---\n",
+		},
+		{
+			name: "multipart mime",
+			data: mimeifyNodeConfigs(completeNodeConfig, partialNodeConfig),
+		},
+		{
+			name:    "multi-document yaml with unknown field",
+			data:    completeNodeConfig + "---\nspec:\n  unknownField: true\n",
+			wantErr: "decoding document 2",
+		},
+		{
+			name:    "multipart mime without node config",
+			data:    "MIME-Version: 1.0\nContent-Type: multipart/mixed; boundary=\"#\"\n\n--#\nContent-Type: text/x-shellscript\n\necho hello\n--#--",
+			wantErr: "Could not find NodeConfig within multipart data",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			config, err := decodeConfig([]byte(tt.data), apibridge.DecodeStrictNodeConfig)
+			if tt.wantErr != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(config.Spec).To(Equal(completeMergedWithPartial.Spec))
+		})
+	}
+}
+
+func TestFileConfigProviderMultipart(t *testing.T) {
+	g := NewWithT(t)
+	path := filepath.Join(t.TempDir(), "user-data")
+	if err := os.WriteFile(path, []byte(mimeifyNodeConfigs(completeNodeConfig, partialNodeConfig)), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	config, err := NewFileConfigProvider(path).Provide()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(config.Spec).To(Equal(completeMergedWithPartial.Spec))
+}
@@ -3,13 +3,38 @@ package configprovider
 import (
 	"fmt"
 	"net/url"
+	"strings"
 )
 
+// Options configures how config providers fetch the configuration from remote sources.
+type Options struct {
+	// CABundlePath is an optional path to a PEM encoded CA bundle used to verify
+	// the server certificate of https config sources, in addition to the system roots.
+	CABundlePath string
+}
+
+type Option func(*Options)
+
+// WithCABundle sets the CA bundle used to verify https config sources.
+func WithCABundle(path string) Option {
+	return func(o *Options) {
+		o.CABundlePath = path
+	}
+}
+
 // BuildConfigProvider returns a ConfigProvider appropriate for the given source URL.
 // The source URL must have a scheme, and the supported schemes are:
 // - `file`. To use configuration from the filesystem: `file:///path/to/file/or/directory`.
 // - `imds`. To use configuration from the instance's user data: `imds://user-data`.
-func BuildConfigProvider(rawConfigSourceURL string) (ConfigProvider, error) {
+// - `s3`. To use configuration from an S3 object: `s3://bucket/key`. The region can be set with `?region=us-west-2`,
+// otherwise the region from the default AWS config is used.
+// - `https`. To use configuration from an https endpoint: `https://example.com/nodeConfig.yaml`.
+func BuildConfigProvider(rawConfigSourceURL string, opts ...Option) (ConfigProvider, error) {
+	options := &Options{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
 	parsedURL, err := url.Parse(rawConfigSourceURL)
 	if err != nil {
 		return nil, err
@@ -20,6 +45,14 @@ func BuildConfigProvider(rawConfigSourceURL string) (ConfigProvider, error) {
 	case "file":
 		source := getURLWithoutScheme(parsedURL)
 		return NewFileConfigProvider(source), nil
+	case "s3":
+		key := strings.TrimPrefix(parsedURL.Path, "/")
+		if parsedURL.Host == "" || key == "" {
+			return nil, fmt.Errorf("invalid s3 config source %q, expected format s3://bucket/key", rawConfigSourceURL)
+		}
+		return NewS3ConfigProvider(parsedURL.Host, key, parsedURL.Query().Get("region")), nil
+	case "https":
+		return NewHTTPSConfigProvider(rawConfigSourceURL, options.CABundlePath), nil
 	default:
 		return nil, fmt.Errorf("unsupported scheme: %s", parsedURL.Scheme)
 	}
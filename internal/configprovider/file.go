@@ -2,17 +2,24 @@ package configprovider
 
 import (
 	"fmt"
-	"io"
 	"os"
+	"path/filepath"
+	"strings"
 
 	internalapi "github.com/aws/eks-hybrid/internal/api"
 	apibridge "github.com/aws/eks-hybrid/internal/api/bridge"
 )
 
+// configFileExtension is the extension of the files loaded from a config directory.
+const configFileExtension = ".yaml"
+
 type fileConfigProvider struct {
 	path string
 }
 
+// NewFileConfigProvider returns a ConfigProvider that reads the node configuration from the filesystem.
+// If path is a directory, every *.yaml file in it is loaded in lexical order and merged,
+// with later files taking precedence.
 func NewFileConfigProvider(path string) ConfigProvider {
 	return &fileConfigProvider{
 		path: path,
@@ -20,25 +27,47 @@ func NewFileConfigProvider(path string) ConfigProvider {
 }
 
 func (fcs *fileConfigProvider) Provide() (*internalapi.NodeConfig, error) {
-	file, err := os.Open(fcs.path)
+	info, err := os.Stat(fcs.path)
 	if err != nil {
 		return nil, err
 	}
-	defer file.Close()
-	info, err := file.Stat()
+	if info.IsDir() {
+		return fcs.provideFromDirectory()
+	}
+	return decodeConfigFile(fcs.path)
+}
+
+func (fcs *fileConfigProvider) provideFromDirectory() (*internalapi.NodeConfig, error) {
+	// os.ReadDir returns the entries sorted by filename
+	entries, err := os.ReadDir(fcs.path)
 	if err != nil {
 		return nil, err
 	}
-	if info.IsDir() {
-		return nil, fmt.Errorf("path is a directory, which is not currently supported: %s", fcs.path)
+	var nodeConfigs []*internalapi.NodeConfig
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), configFileExtension) {
+			continue
+		}
+		config, err := decodeConfigFile(filepath.Join(fcs.path, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		nodeConfigs = append(nodeConfigs, config)
+	}
+	if len(nodeConfigs) == 0 {
+		return nil, fmt.Errorf("no %s files found in config directory %s", configFileExtension, fcs.path)
 	}
-	data, err := io.ReadAll(file)
+	return mergeNodeConfigs(nodeConfigs)
+}
+
+func decodeConfigFile(path string) (*internalapi.NodeConfig, error) {
+	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, err
 	}
-	config, err := apibridge.DecodeStrictNodeConfig(data)
+	config, err := decodeConfig(data, apibridge.DecodeStrictNodeConfig)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("decoding config file %s: %w", path, err)
 	}
 	return config, nil
 }
@@ -0,0 +1,58 @@
+package configprovider
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func writeConfigFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestFileConfigProviderDirectory(t *testing.T) {
+	t.Run("merges yaml files in lexical order", func(t *testing.T) {
+		g := NewWithT(t)
+		dir := t.TempDir()
+		writeConfigFiles(t, dir, map[string]string{
+			"20-kubelet.yaml": partialNodeConfig,
+			"10-cluster.yaml": completeNodeConfig,
+			"README.md":       "not a node config",
+		})
+		if err := os.Mkdir(filepath.Join(dir, "nested.yaml"), 0o755); err != nil {
+			t.Fatal(err)
+		}
+
+		config, err := NewFileConfigProvider(dir + "/").Provide()
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(config.Spec).To(Equal(completeMergedWithPartial.Spec))
+	})
+
+	t.Run("no yaml files", func(t *testing.T) {
+		g := NewWithT(t)
+		dir := t.TempDir()
+		writeConfigFiles(t, dir, map[string]string{"README.md": "not a node config"})
+
+		_, err := NewFileConfigProvider(dir).Provide()
+		g.Expect(err).To(MatchError(ContainSubstring("no .yaml files found in config directory")))
+	})
+
+	t.Run("invalid file", func(t *testing.T) {
+		g := NewWithT(t)
+		dir := t.TempDir()
+		writeConfigFiles(t, dir, map[string]string{
+			"10-cluster.yaml": completeNodeConfig,
+			"20-bad.yaml":     "spec:\n  unknownField: true\n",
+		})
+
+		_, err := NewFileConfigProvider(dir).Provide()
+		g.Expect(err).To(MatchError(ContainSubstring("20-bad.yaml")))
+	})
+}
@@ -0,0 +1,98 @@
+package configprovider
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	internalapi "github.com/aws/eks-hybrid/internal/api"
+	apibridge "github.com/aws/eks-hybrid/internal/api/bridge"
+	"github.com/aws/eks-hybrid/internal/retry"
+)
+
+type httpsConfigProvider struct {
+	url          string
+	caBundlePath string
+	// client is built from caBundlePath when nil.
+	client    *http.Client
+	retryOpts []retry.RetrierOption
+}
+
+// NewHTTPSConfigProvider returns a ConfigProvider that reads the node configuration from an https URL.
+// If caBundlePath is not empty, the certificates in it are trusted in addition to the system roots.
+func NewHTTPSConfigProvider(url, caBundlePath string) ConfigProvider {
+	return &httpsConfigProvider{
+		url:          url,
+		caBundlePath: caBundlePath,
+	}
+}
+
+func (h *httpsConfigProvider) Provide() (*internalapi.NodeConfig, error) {
+	ctx := context.Background()
+	client, err := h.httpClient()
+	if err != nil {
+		return nil, err
+	}
+
+	var data []byte
+	err = retry.NetworkRequest(ctx, func(ctx context.Context) error {
+		request, err := http.NewRequestWithContext(ctx, http.MethodGet, h.url, nil)
+		if err != nil {
+			return err
+		}
+		resp, err := client.Do(request)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		}
+		data, err = io.ReadAll(resp.Body)
+		return err
+	}, h.retryOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("reading config from %s: %w", h.url, err)
+	}
+
+	return decodeConfig(data, apibridge.DecodeStrictNodeConfig)
+}
+
+func (h *httpsConfigProvider) httpClient() (*http.Client, error) {
+	if h.client != nil {
+		return h.client, nil
+	}
+	return NewCABundleClient(h.caBundlePath)
+}
+
+// NewCABundleClient returns an HTTP client that trusts the PEM encoded certificates
+// in the CA bundle at caBundlePath in addition to the system roots. If caBundlePath
+// is empty, http.DefaultClient is returned.
+func NewCABundleClient(caBundlePath string) (*http.Client, error) {
+	if caBundlePath == "" {
+		return http.DefaultClient, nil
+	}
+
+	caBundle, err := os.ReadFile(caBundlePath)
+	if err != nil {
+		return nil, fmt.Errorf("reading CA bundle: %w", err)
+	}
+	rootCAs, err := x509.SystemCertPool()
+	if err != nil {
+		rootCAs = x509.NewCertPool()
+	}
+	if !rootCAs.AppendCertsFromPEM(caBundle) {
+		return nil, fmt.Errorf("no valid certificates found in CA bundle %s", caBundlePath)
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{
+		RootCAs:    rootCAs,
+		MinVersion: tls.VersionTLS12,
+	}
+	return &http.Client{Transport: transport}, nil
+}
@@ -0,0 +1,15 @@
+package configprovider
+
+import internalapi "github.com/aws/eks-hybrid/internal/api"
+
+// mergeNodeConfigs merges the given configs in order, so values in later
+// configs take precedence over earlier ones. configs must not be empty.
+func mergeNodeConfigs(configs []*internalapi.NodeConfig) (*internalapi.NodeConfig, error) {
+	config := configs[0]
+	for _, nodeConfig := range configs[1:] {
+		if err := config.Merge(nodeConfig); err != nil {
+			return nil, err
+		}
+	}
+	return config, nil
+}
@@ -0,0 +1,174 @@
+package configprovider
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-hybrid/internal/retry"
+)
+
+const remoteNodeConfig = `---
+apiVersion: node.eks.aws/v1alpha1
+kind: NodeConfig
+spec:
+  cluster:
+    name: my-cluster
+    region: us-west-2
+  hybrid:
+    ssm:
+      activationCode: code
+      activationId: id
+`
+
+var fastRetry = []retry.RetrierOption{
+	retry.WithTimeout(100 * time.Millisecond),
+	retry.WithBackoffDuration(10 * time.Millisecond),
+}
+
+func TestS3ConfigProvider(t *testing.T) {
+	g := NewWithT(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/my-bucket/nodeadm/config.yaml" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(remoteNodeConfig))
+	}))
+	defer server.Close()
+
+	client := s3.New(s3.Options{
+		BaseEndpoint: aws.String(server.URL),
+		UsePathStyle: true,
+		Region:       "us-west-2",
+		Credentials:  aws.AnonymousCredentials{},
+	})
+
+	provider := &s3ConfigProvider{
+		bucket:    "my-bucket",
+		key:       "nodeadm/config.yaml",
+		client:    client,
+		retryOpts: fastRetry,
+	}
+	config, err := provider.Provide()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(config.Spec.Cluster.Name).To(Equal("my-cluster"))
+	g.Expect(config.Spec.Hybrid.SSM.ActivationID).To(Equal("id"))
+
+	provider.key = "missing.yaml"
+	_, err = provider.Provide()
+	g.Expect(err).To(MatchError(ContainSubstring("reading config from s3://my-bucket/missing.yaml")))
+}
+
+func TestHTTPSConfigProvider(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/config.yaml" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(remoteNodeConfig))
+	}))
+	defer server.Close()
+
+	caBundlePath := filepath.Join(t.TempDir(), "ca.pem")
+	caBundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(caBundlePath, caBundle, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("with CA bundle", func(t *testing.T) {
+		g := NewWithT(t)
+		provider := &httpsConfigProvider{
+			url:          server.URL + "/config.yaml",
+			caBundlePath: caBundlePath,
+			retryOpts:    fastRetry,
+		}
+		config, err := provider.Provide()
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(config.Spec.Cluster.Name).To(Equal("my-cluster"))
+	})
+
+	t.Run("untrusted server certificate", func(t *testing.T) {
+		g := NewWithT(t)
+		provider := &httpsConfigProvider{
+			url:       server.URL + "/config.yaml",
+			retryOpts: fastRetry,
+		}
+		_, err := provider.Provide()
+		g.Expect(err).To(MatchError(ContainSubstring("certificate")))
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		g := NewWithT(t)
+		provider := &httpsConfigProvider{
+			url:          server.URL + "/missing.yaml",
+			caBundlePath: caBundlePath,
+			retryOpts:    fastRetry,
+		}
+		_, err := provider.Provide()
+		g.Expect(err).To(MatchError(ContainSubstring("unexpected status code: 404")))
+	})
+
+	t.Run("invalid CA bundle", func(t *testing.T) {
+		g := NewWithT(t)
+		invalid := filepath.Join(t.TempDir(), "invalid.pem")
+		g.Expect(os.WriteFile(invalid, []byte("not a certificate"), 0o644)).To(Succeed())
+		_, err := NewCABundleClient(invalid)
+		g.Expect(err).To(MatchError(ContainSubstring("no valid certificates")))
+	})
+}
+
+func TestBuildConfigProvider(t *testing.T) {
+	tests := []struct {
+		name    string
+		source  string
+		want    ConfigProvider
+		wantErr string
+	}{
+		{
+			name:   "file",
+			source: "file:///etc/nodeadm/config.yaml",
+			want:   &fileConfigProvider{path: "/etc/nodeadm/config.yaml"},
+		},
+		{
+			name:   "s3 with region",
+			source: "s3://my-bucket/path/to/config.yaml?region=eu-west-1",
+			want:   &s3ConfigProvider{bucket: "my-bucket", key: "path/to/config.yaml", region: "eu-west-1"},
+		},
+		{
+			name:    "s3 without key",
+			source:  "s3://my-bucket",
+			wantErr: "expected format s3://bucket/key",
+		},
+		{
+			name:   "https",
+			source: "https://example.com/config.yaml",
+			want:   &httpsConfigProvider{url: "https://example.com/config.yaml"},
+		},
+		{
+			name:    "unsupported",
+			source:  "ftp://example.com/config.yaml",
+			wantErr: "unsupported scheme: ftp",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			provider, err := BuildConfigProvider(tt.source)
+			if tt.wantErr != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(provider).To(Equal(tt.want))
+		})
+	}
+}
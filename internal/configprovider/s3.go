@@ -0,0 +1,85 @@
+package configprovider
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+
+	internalapi "github.com/aws/eks-hybrid/internal/api"
+	apibridge "github.com/aws/eks-hybrid/internal/api/bridge"
+	"github.com/aws/eks-hybrid/internal/retry"
+)
+
+// S3GetObjectAPI is the subset of the S3 client needed to read a config object.
+type S3GetObjectAPI interface {
+	GetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error)
+}
+
+type s3ConfigProvider struct {
+	bucket string
+	key    string
+	region string
+	// client is built from the default AWS config when nil.
+	client    S3GetObjectAPI
+	retryOpts []retry.RetrierOption
+}
+
+// NewS3ConfigProvider returns a ConfigProvider that reads the node configuration from an S3 object.
+// Credentials are resolved through the default AWS credential chain. If region is empty,
+// the region from the default AWS config is used.
+func NewS3ConfigProvider(bucket, key, region string) ConfigProvider {
+	return &s3ConfigProvider{
+		bucket: bucket,
+		key:    key,
+		region: region,
+	}
+}
+
+func (s *s3ConfigProvider) Provide() (*internalapi.NodeConfig, error) {
+	ctx := context.Background()
+	client, err := s.s3Client(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var data []byte
+	err = retry.NetworkRequest(ctx, func(ctx context.Context) error {
+		out, err := client.GetObject(ctx, &s3.GetObjectInput{
+			Bucket: aws.String(s.bucket),
+			Key:    aws.String(s.key),
+		})
+		if err != nil {
+			return err
+		}
+		defer out.Body.Close()
+		data, err = io.ReadAll(out.Body)
+		return err
+	}, s.retryOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("reading config from s3://%s/%s: %w", s.bucket, s.key, err)
+	}
+
+	return decodeConfig(data, apibridge.DecodeStrictNodeConfig)
+}
+
+func (s *s3ConfigProvider) s3Client(ctx context.Context) (S3GetObjectAPI, error) {
+	if s.client != nil {
+		return s.client, nil
+	}
+	var opts []func(*config.LoadOptions) error
+	if s.region != "" {
+		opts = append(opts, config.WithRegion(s.region))
+	}
+	awsConfig, err := config.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("loading AWS config for s3 config source: %w", err)
+	}
+	if awsConfig.Region == "" {
+		return nil, fmt.Errorf("no region configured for s3 config source, set it with s3://%s/%s?region=<region>", s.bucket, s.key)
+	}
+	return s3.NewFromConfig(awsConfig), nil
+}
@@ -33,21 +33,7 @@ func (ics *userDataConfigProvider) Provide() (*internalapi.NodeConfig, error) {
 	if err != nil {
 		return nil, err
 	}
-	// if the MIME data fails to parse as a multipart document, then fall back
-	// to parsing the entire userdata as the node config.
-	if multipartReader, err := getMIMEMultipartReader(userData); err == nil {
-		config, err := parseMultipart(multipartReader)
-		if err != nil {
-			return nil, err
-		}
-		return config, nil
-	} else {
-		config, err := apibridge.DecodeNodeConfig(userData)
-		if err != nil {
-			return nil, err
-		}
-		return config, nil
-	}
+	return decodeConfig(userData, apibridge.DecodeNodeConfig)
 }
 
 func getMIMEMultipartReader(data []byte) (*multipart.Reader, error) {
@@ -65,7 +51,7 @@ func getMIMEMultipartReader(data []byte) (*multipart.Reader, error) {
 	return multipart.NewReader(msg.Body, params[mimeBoundaryParam]), nil
 }
 
-func parseMultipart(userDataReader *multipart.Reader) (*internalapi.NodeConfig, error) {
+func parseMultipart(userDataReader *multipart.Reader, decode nodeConfigDecoder) (*internalapi.NodeConfig, error) {
 	var nodeConfigs []*internalapi.NodeConfig
 	for {
 		part, err := userDataReader.NextPart()
@@ -85,7 +71,7 @@ func parseMultipart(userDataReader *multipart.Reader) (*internalapi.NodeConfig,
 				if err != nil {
 					return nil, err
 				}
-				decodedConfig, err := apibridge.DecodeNodeConfig(nodeConfigPart)
+				decodedConfig, err := decode(nodeConfigPart)
 				if err != nil {
 					return nil, err
 				}
@@ -94,14 +80,8 @@ func parseMultipart(userDataReader *multipart.Reader) (*internalapi.NodeConfig,
 		}
 	}
 	if len(nodeConfigs) > 0 {
-		config := nodeConfigs[0]
-		for _, nodeConfig := range nodeConfigs[1:] {
-			if err := config.Merge(nodeConfig); err != nil {
-				return nil, err
-			}
-		}
-		return config, nil
+		return mergeNodeConfigs(nodeConfigs)
 	} else {
-		return nil, fmt.Errorf("Could not find NodeConfig within UserData")
+		return nil, fmt.Errorf("Could not find NodeConfig within multipart data")
 	}
 }
@@ -12,6 +12,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 
 	"github.com/aws/eks-hybrid/internal/api"
+	apibridge "github.com/aws/eks-hybrid/internal/api/bridge"
 )
 
 const (
@@ -104,7 +105,7 @@ func TestParseMIMENodeConfig(t *testing.T) {
 		t.Fatal(err)
 	}
 	userDataReader := multipart.NewReader(mimeMessage.Body, boundary)
-	if _, err := parseMultipart(userDataReader); err != nil {
+	if _, err := parseMultipart(userDataReader, apibridge.DecodeNodeConfig); err != nil {
 		t.Fatal(err)
 	}
 }
@@ -125,7 +126,7 @@ func TestMergeNodeConfig(t *testing.T) {
 		t.Fatal(err)
 	}
 	userDataReader := multipart.NewReader(mimeMessage.Body, boundary)
-	config, err := parseMultipart(userDataReader)
+	config, err := parseMultipart(userDataReader, apibridge.DecodeNodeConfig)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -3,7 +3,10 @@ package containerd
 import (
 	"bytes"
 	_ "embed"
+	"fmt"
 	"path/filepath"
+	"regexp"
+	"strconv"
 	"text/template"
 
 	"go.uber.org/zap"
@@ -23,9 +26,24 @@ const (
 )
 
 var (
+	containerdConfigFuncs = template.FuncMap{"quote": strconv.Quote}
+
 	//go:embed config.template.toml
 	containerdConfigTemplateData string
-	containerdConfigTemplate     = template.Must(template.New(containerdConfigFile).Parse(containerdConfigTemplateData))
+	containerdConfigTemplate     = template.Must(template.New(containerdConfigFile).Funcs(containerdConfigFuncs).Parse(containerdConfigTemplateData))
+
+	//go:embed config-v3.template.toml
+	containerdConfigV3TemplateData string
+	containerdConfigV3Template     = template.Must(template.New(containerdConfigFile).Funcs(containerdConfigFuncs).Parse(containerdConfigV3TemplateData))
+
+	// containerdConfigTemplates are the templates for each supported config version.
+	containerdConfigTemplates = map[int]*template.Template{
+		2: containerdConfigTemplate,
+		3: containerdConfigV3Template,
+	}
+
+	configVersionKeyRegex = regexp.MustCompile(`(?m)^\s*version\s*=\s*(\d+)`)
+	configTableRegex      = regexp.MustCompile(`(?m)^\s*\[`)
 
 	//go:embed kernel-modules.conf
 	containerdKernelModulesFileData string
@@ -33,37 +51,90 @@ var (
 
 type containerdTemplateVars struct {
 	SandboxImage string
+	Runtimes     []runtimeTemplateVars
 }
 
-func writeContainerdConfig(cfg *api.NodeConfig) error {
+func writeContainerdConfig(fsys util.FileSystem, cfg *api.NodeConfig) error {
+	configVersion, err := resolveConfigVersion(cfg.Spec.Containerd.Config, containerdConfigVersion())
+	if err != nil {
+		return err
+	}
 	// write nodeadm's generated containerd config to the default path
-	containerdConfig, err := generateContainerdConfig(cfg)
+	containerdConfig, err := generateContainerdConfig(cfg, configVersion)
 	if err != nil {
 		return err
 	}
-	zap.L().Info("Writing containerd config to file...", zap.String("path", containerdConfigFile))
-	if err := util.WriteFileWithDir(containerdConfigFile, containerdConfig, containerdConfigPerm); err != nil {
+	zap.L().Info("Writing containerd config to file...", zap.String("path", containerdConfigFile), zap.Int("version", configVersion))
+	if err := util.WriteFileWithDirFS(fsys, containerdConfigFile, containerdConfig, containerdConfigPerm); err != nil {
+		return err
+	}
+	if err := writeRegistriesConfig(fsys, cfg); err != nil {
 		return err
 	}
 	if len(cfg.Spec.Containerd.Config) > 0 {
 		containerConfigImportPath := filepath.Join(containerdConfigImportDir, "00-nodeadm.toml")
 		zap.L().Info("Writing user containerd config to drop-in file...", zap.String("path", containerConfigImportPath))
-		return util.WriteFileWithDir(containerConfigImportPath, []byte(cfg.Spec.Containerd.Config), containerdConfigPerm)
+		return util.WriteFileWithDirFS(fsys, containerConfigImportPath, []byte(cfg.Spec.Containerd.Config), containerdConfigPerm)
 	}
 	return nil
 }
 
-func generateContainerdConfig(cfg *api.NodeConfig) ([]byte, error) {
+// containerdConfigVersion returns the config version native to the installed containerd,
+// 3 for containerd 2.x and 2 before that. If the version can't be read, like when rendering
+// on a host without containerd, it returns 2, which containerd 2.x also loads.
+func containerdConfigVersion() int {
+	majorVersion, err := GetContainerdMajorVersion()
+	if err != nil {
+		zap.L().Warn("Unable to detect containerd version, using config version 2", zap.Error(err))
+		return 2
+	}
+	if majorVersion >= 2 {
+		return 3
+	}
+	return 2
+}
+
+// resolveConfigVersion returns the version of the generated config for the user config
+// and the installed containerd, which natively uses configVersion. containerd takes the
+// version of the last imported file for the whole merged config, so the generated config
+// uses the version the user config sets. containerd 2.x migrates version 2 configs, but
+// containerd 1.x can't read version 3. A config without version is migrated by containerd
+// when it's imported, so it gets the native version.
+func resolveConfigVersion(userConfig string, configVersion int) (int, error) {
+	// only keys before the first table are top level
+	if table := configTableRegex.FindStringIndex(userConfig); table != nil {
+		userConfig = userConfig[:table[0]]
+	}
+	matches := configVersionKeyRegex.FindStringSubmatch(userConfig)
+	if matches == nil {
+		return configVersion, nil
+	}
+	userVersion, err := strconv.Atoi(matches[1])
+	if err != nil {
+		return 0, fmt.Errorf("parsing containerd config version %q: %w", matches[1], err)
+	}
+	if _, ok := containerdConfigTemplates[userVersion]; !ok || userVersion > configVersion {
+		return 0, fmt.Errorf("containerd config version %d is not supported by the installed containerd, which uses version %d, update it to version %d or remove version to have containerd migrate it", userVersion, configVersion, configVersion)
+	}
+	return userVersion, nil
+}
+
+func generateContainerdConfig(cfg *api.NodeConfig, configVersion int) ([]byte, error) {
+	configTemplate, ok := containerdConfigTemplates[configVersion]
+	if !ok {
+		return nil, fmt.Errorf("unsupported containerd config version: %d", configVersion)
+	}
 	configVars := containerdTemplateVars{
 		SandboxImage: cfg.Status.Defaults.SandboxImage,
+		Runtimes:     newRuntimesTemplateVars(cfg.Spec.Containerd.Runtimes),
 	}
 	var buf bytes.Buffer
-	if err := containerdConfigTemplate.Execute(&buf, configVars); err != nil {
+	if err := configTemplate.Execute(&buf, configVars); err != nil {
 		return nil, err
 	}
 	return buf.Bytes(), nil
 }
 
-func writeContainerdKernelModulesConfig() error {
-	return util.WriteFileWithDir(containerdKernelModulesConfigFile, []byte(containerdKernelModulesFileData), containerdConfigPerm)
+func writeContainerdKernelModulesConfig(fsys util.FileSystem) error {
+	return util.WriteFileWithDirFS(fsys, containerdKernelModulesConfigFile, []byte(containerdKernelModulesFileData), containerdConfigPerm)
 }
@@ -0,0 +1,135 @@
+package containerd
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aws/eks-hybrid/internal/api"
+)
+
+func TestGenerateContainerdConfig(t *testing.T) {
+	runtimes := map[string]api.RuntimeOptions{
+		"gvisor": {
+			Type:       "io.containerd.runsc.v1",
+			ConfigPath: "/etc/containerd/runsc.toml",
+			Options:    map[string]string{"TypeUrl": "io.containerd.runsc.v1.options"},
+		},
+		"kata": {Type: "io.containerd.kata.v2"},
+		"nvidia": {
+			Type:       "io.containerd.runc.v2",
+			BinaryPath: "/usr/bin/nvidia-container-runtime",
+			Options:    map[string]string{"NoPivotRoot": "true", "IoUid": "1000"},
+		},
+	}
+	tests := []struct {
+		name         string
+		version      int
+		runtimes     map[string]api.RuntimeOptions
+		expectedFile string
+	}{
+		{name: "version 2", version: 2, expectedFile: "./testdata/config-v2.toml"},
+		{name: "version 3", version: 3, expectedFile: "./testdata/config-v3.toml"},
+		{name: "version 2 with runtimes", version: 2, runtimes: runtimes, expectedFile: "./testdata/config-v2-runtimes.toml"},
+		{name: "version 3 with runtimes", version: 3, runtimes: runtimes, expectedFile: "./testdata/config-v3-runtimes.toml"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &api.NodeConfig{
+				Spec: api.NodeConfigSpec{
+					Containerd: api.ContainerdOptions{Runtimes: tt.runtimes},
+				},
+				Status: api.NodeConfigStatus{
+					Defaults: api.DefaultOptions{
+						SandboxImage: "602401143452.dkr.ecr.us-west-2.amazonaws.com/eks/pause:3.10",
+					},
+				},
+			}
+			expected, err := os.ReadFile(tt.expectedFile)
+			assert.NoError(t, err)
+
+			config, err := generateContainerdConfig(cfg, tt.version)
+			assert.NoError(t, err)
+			assert.Equal(t, string(expected), string(config))
+
+			configVersion, err := parseConfigVersion(config)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.version, configVersion)
+		})
+	}
+
+	_, err := generateContainerdConfig(&api.NodeConfig{}, 1)
+	assert.EqualError(t, err, "unsupported containerd config version: 1")
+}
+
+func TestResolveConfigVersion(t *testing.T) {
+	tests := []struct {
+		name          string
+		userConfig    string
+		configVersion int
+		wantVersion   int
+		wantErr       string
+	}{
+		{
+			name:          "empty",
+			configVersion: 3,
+			wantVersion:   3,
+		},
+		{
+			name: "no version",
+			userConfig: `[plugins."io.containerd.grpc.v1.cri".containerd]
+discard_unpacked_layers = false`,
+			configVersion: 3,
+			wantVersion:   3,
+		},
+		{
+			name: "same version",
+			userConfig: `# pinned images
+version = 3
+[plugins."io.containerd.cri.v1.images"]
+discard_unpacked_layers = false`,
+			configVersion: 3,
+			wantVersion:   3,
+		},
+		{
+			name: "version in table",
+			userConfig: `[plugins."io.containerd.grpc.v1.cri".containerd.runtimes.kata]
+version = 2`,
+			configVersion: 3,
+			wantVersion:   3,
+		},
+		{
+			name: "older version",
+			userConfig: `version = 2
+[plugins."io.containerd.grpc.v1.cri".containerd]
+discard_unpacked_layers = false`,
+			configVersion: 3,
+			wantVersion:   2,
+		},
+		{
+			name: "newer version",
+			userConfig: `root = "/data/containerd"
+version = 3`,
+			configVersion: 2,
+			wantErr:       "containerd config version 3 is not supported by the installed containerd, which uses version 2, update it to version 2 or remove version to have containerd migrate it",
+		},
+		{
+			name:          "unsupported version",
+			userConfig:    `version = 1`,
+			configVersion: 2,
+			wantErr:       "containerd config version 1 is not supported by the installed containerd, which uses version 2, update it to version 2 or remove version to have containerd migrate it",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			version, err := resolveConfigVersion(tt.userConfig, tt.configVersion)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantVersion, version)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/aws/eks-hybrid/internal/api"
 	"github.com/aws/eks-hybrid/internal/daemon"
+	"github.com/aws/eks-hybrid/internal/util"
 )
 
 const (
@@ -36,10 +37,11 @@ func NewContainerdDaemon(daemonManager daemon.DaemonManager, cfg *api.NodeConfig
 }
 
 func (cd *containerd) Configure(ctx context.Context) error {
-	if err := writeContainerdConfig(cd.nodeConfig); err != nil {
+	fsys := util.FileSystemFromContext(ctx)
+	if err := writeContainerdConfig(fsys, cd.nodeConfig); err != nil {
 		return err
 	}
-	return writeContainerdKernelModulesConfig()
+	return writeContainerdKernelModulesConfig(fsys)
 }
 
 // EnsureRunning ensures containerd is running with the written configuration
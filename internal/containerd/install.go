@@ -28,19 +28,12 @@ type Source interface {
 }
 
 func Install(ctx context.Context, artifactsTracker *tracker.Tracker, source Source, containerdSource tracker.ContainerdSourceName, kubernetesVersion string) error {
-	// if containerd/run are already installed, we skip the installation and set the source to none
-	// which exclude it from being upgrading during upgrade and removed during uninstall
-	// this has the (potentially negative) side effect of the user not knowing that we have chosen none on
-	// their behalf based on it already being installed
-	// TODO: a better approach would be to determine if the installed versions are from the user supplied
-	// containerd-source (distro/docker) and if they are, treat it as such including upgrading/uninstalling
-	// if they are not, we error and ask the user to explictly pass none to the --containerd-source flag
-	if containerdSource == tracker.ContainerdSourceNone || areContainerdAndRuncInstalled() {
+	containerd := PackageToInstall(source, containerdSource, kubernetesVersion)
+	if containerd == nil {
 		artifactsTracker.Artifacts.Containerd = tracker.ContainerdSourceNone
 		return nil
 	}
-	containerdVersionConstraint := determineContainerdVersionConstraint(kubernetesVersion)
-	containerd := source.GetContainerd(containerdVersionConstraint)
+
 	// Sometimes install fails due to conflicts with other processes
 	// updating packages, specially when automating at machine startup.
 	// We assume errors are transient and just retry for a bit.
@@ -51,30 +44,65 @@ func Install(ctx context.Context, artifactsTracker *tracker.Tracker, source Sour
 	return nil
 }
 
-func Uninstall(ctx context.Context, source Source) error {
-	if isContainerdInstalled() {
-		// Use no version constraint to uninstall any version (1.x or 2.x)
-		containerd := source.GetContainerd("")
-		if err := cmd.Retry(ctx, containerd.UninstallCmd, 5*time.Second); err != nil {
-			return errors.Wrap(err, "uninstalling containerd")
-		}
+// PackageToInstall returns the containerd package Install would install. It returns nil
+// if containerd won't be installed, in which case it's not managed by nodeadm.
+func PackageToInstall(source Source, containerdSource tracker.ContainerdSourceName, kubernetesVersion string) artifact.Package {
+	// if containerd/run are already installed, we skip the installation and set the source to none
+	// which exclude it from being upgrading during upgrade and removed during uninstall
+	// this has the (potentially negative) side effect of the user not knowing that we have chosen none on
+	// their behalf based on it already being installed
+	// TODO: a better approach would be to determine if the installed versions are from the user supplied
+	// containerd-source (distro/docker) and if they are, treat it as such including upgrading/uninstalling
+	// if they are not, we error and ask the user to explictly pass none to the --containerd-source flag
+	if containerdSource == tracker.ContainerdSourceNone || areContainerdAndRuncInstalled() {
+		return nil
+	}
+	return source.GetContainerd(determineContainerdVersionConstraint(kubernetesVersion))
+}
 
-		if err := os.RemoveAll(containerdConfigDir); err != nil {
-			return errors.Wrap(err, "removing containerd config files")
-		}
+func Uninstall(ctx context.Context, source Source) error {
+	containerd := PackageToUninstall(source)
+	if containerd == nil {
+		return nil
+	}
+	if err := cmd.Retry(ctx, containerd.UninstallCmd, 5*time.Second); err != nil {
+		return errors.Wrap(err, "uninstalling containerd")
+	}
+	if err := os.RemoveAll(containerdConfigDir); err != nil {
+		return errors.Wrap(err, "removing containerd config files")
 	}
 	return nil
 }
 
+// PackageToUninstall returns the containerd package Uninstall would remove, or nil if
+// containerd is not installed. Uninstall also removes the containerd config directory.
+func PackageToUninstall(source Source) artifact.Package {
+	if !isContainerdInstalled() {
+		return nil
+	}
+	// Use no version constraint to uninstall any version (1.x or 2.x)
+	return source.GetContainerd("")
+}
+
 func Upgrade(ctx context.Context, source Source, kubernetesVersion string, skipContainerdMajorVersionUpgrade bool) error {
-	var containerdVersionConstraint string
+	containerd, err := PackageToUpgrade(source, kubernetesVersion, skipContainerdMajorVersionUpgrade)
+	if err != nil {
+		return err
+	}
+	if err := cmd.Retry(ctx, containerd.UpgradeCmd, 5*time.Second); err != nil {
+		return errors.Wrap(err, "upgrading containerd")
+	}
+	return nil
+}
 
+// PackageToUpgrade returns the containerd package Upgrade would upgrade to.
+func PackageToUpgrade(source Source, kubernetesVersion string, skipContainerdMajorVersionUpgrade bool) (artifact.Package, error) {
+	var containerdVersionConstraint string
 	if skipContainerdMajorVersionUpgrade {
 		containerdMajorVersion, err := GetContainerdMajorVersion()
 		if err != nil {
-			return err
+			return nil, err
 		}
-
 		// pins containerd upgrade version to current installed major version
 		switch containerdMajorVersion {
 		case 1:
@@ -82,18 +110,14 @@ func Upgrade(ctx context.Context, source Source, kubernetesVersion string, skipC
 		case 2:
 			containerdVersionConstraint = "2.0.*"
 		default:
-			return fmt.Errorf("unsupported containerd major version: %d", containerdMajorVersion)
+			return nil, fmt.Errorf("unsupported containerd major version: %d", containerdMajorVersion)
 		}
 	} else {
 		// Upgrade containerd to latest compatible version including major version upgrade (1.x -> 2.x) if available
 		containerdVersionConstraint = determineContainerdVersionConstraint(kubernetesVersion)
 	}
 
-	containerd := source.GetContainerd(containerdVersionConstraint)
-	if err := cmd.Retry(ctx, containerd.UpgradeCmd, 5*time.Second); err != nil {
-		return errors.Wrap(err, "upgrading containerd")
-	}
-	return nil
+	return source.GetContainerd(containerdVersionConstraint), nil
 }
 
 func ValidateContainerdSource(source tracker.ContainerdSourceName) error {
@@ -0,0 +1,266 @@
+package containerd
+
+import (
+	"bytes"
+	"crypto/x509"
+	_ "embed"
+	"encoding/base64"
+	"fmt"
+	"maps"
+	"net/url"
+	"os"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"go.uber.org/zap"
+
+	"github.com/aws/eks-hybrid/internal/api"
+	"github.com/aws/eks-hybrid/internal/logger"
+	"github.com/aws/eks-hybrid/internal/util"
+	"github.com/aws/eks-hybrid/internal/util/file"
+)
+
+const (
+	containerdCertsDir = "/etc/containerd/certs.d"
+	// defaultRegistryHost configures every registry without its own hosts.toml.
+	defaultRegistryHost = "_default"
+	// hostsConfigPerm is restrictive because hosts.toml can have registry credentials.
+	hostsConfigPerm = 0o600
+	// generatedHostsHeader starts every hosts.toml written by nodeadm.
+	generatedHostsHeader = "# Generated by nodeadm from spec.containerd.registries"
+)
+
+var (
+	//go:embed hosts.template.toml
+	hostsTemplateData string
+	hostsTemplate     = template.Must(template.New("hosts.toml").Funcs(template.FuncMap{
+		"quote": strconv.Quote,
+		"quoteAll": func(values []string) string {
+			quoted := make([]string, 0, len(values))
+			for _, value := range values {
+				quoted = append(quoted, strconv.Quote(value))
+			}
+			return strings.Join(quoted, ", ")
+		},
+	}).Parse(hostsTemplateData))
+
+	defaultMirrorCapabilities = []string{"pull", "resolve"}
+	mirrorCapabilities        = []string{"pull", "resolve", "push"}
+)
+
+type hostsTemplateVars struct {
+	Server  string
+	Host    hostTemplateVars
+	Mirrors []mirrorTemplateVars
+}
+
+type mirrorTemplateVars struct {
+	Endpoint     string
+	Capabilities []string
+	OverridePath bool
+	Host         hostTemplateVars
+}
+
+type hostTemplateVars struct {
+	CA                string
+	ClientCertificate string
+	ClientKey         string
+	SkipVerify        bool
+	Authorization     string
+}
+
+// ValidateRegistries returns an error if a registry in spec.containerd.registries
+// can't be written to a valid hosts.toml.
+func ValidateRegistries(registries map[string]api.RegistryOptions) error {
+	for _, host := range slices.Sorted(maps.Keys(registries)) {
+		registry := registries[host]
+		if err := validateRegistryHost(host); err != nil {
+			return err
+		}
+		if registry.Server != "" {
+			if err := validateEndpoint(registry.Server); err != nil {
+				return fmt.Errorf("invalid server for registry %s: %w", host, err)
+			}
+		}
+		if err := validateRegistryTLS(registry.TLS); err != nil {
+			return fmt.Errorf("invalid tls for registry %s: %w", host, err)
+		}
+		if err := validateRegistryAuth(registry.Auth); err != nil {
+			return fmt.Errorf("invalid auth for registry %s: %w", host, err)
+		}
+		for i, mirror := range registry.Mirrors {
+			if mirror.Endpoint == "" {
+				return fmt.Errorf("endpoint is missing in mirror %d of registry %s", i, host)
+			}
+			if err := validateEndpoint(mirror.Endpoint); err != nil {
+				return fmt.Errorf("invalid endpoint for mirror %d of registry %s: %w", i, host, err)
+			}
+			for _, capability := range mirror.Capabilities {
+				if !slices.Contains(mirrorCapabilities, capability) {
+					return fmt.Errorf("invalid capability %q for mirror %s of registry %s, must be one of: [%s]", capability, mirror.Endpoint, host, strings.Join(mirrorCapabilities, ", "))
+				}
+			}
+			if err := validateRegistryTLS(mirror.TLS); err != nil {
+				return fmt.Errorf("invalid tls for mirror %s of registry %s: %w", mirror.Endpoint, host, err)
+			}
+			if err := validateRegistryAuth(mirror.Auth); err != nil {
+				return fmt.Errorf("invalid auth for mirror %s of registry %s: %w", mirror.Endpoint, host, err)
+			}
+		}
+	}
+	return nil
+}
+
+func validateRegistryHost(host string) error {
+	if host == defaultRegistryHost {
+		return nil
+	}
+	parsed, err := url.Parse("//" + host)
+	if err != nil || parsed.Host != host || parsed.Hostname() == "" {
+		return fmt.Errorf("invalid registry %q, must be a host like registry.example.com or registry.example.com:5000", host)
+	}
+	return nil
+}
+
+func validateEndpoint(endpoint string) error {
+	parsed, err := url.Parse(endpoint)
+	if err != nil {
+		return err
+	}
+	if (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Host == "" {
+		return fmt.Errorf("%q must be an http:// or https:// URL", endpoint)
+	}
+	return nil
+}
+
+func validateRegistryTLS(tls api.RegistryTLS) error {
+	if tls.CABundle != "" && !x509.NewCertPool().AppendCertsFromPEM([]byte(tls.CABundle)) {
+		return fmt.Errorf("caBundle has no PEM encoded certificates")
+	}
+	if (tls.ClientCertificatePath == "") != (tls.ClientKeyPath == "") {
+		return fmt.Errorf("clientCertificatePath and clientKeyPath must be set together")
+	}
+	for _, path := range []string{tls.ClientCertificatePath, tls.ClientKeyPath} {
+		if path != "" && !file.Exists(path) {
+			return fmt.Errorf("%s not found", path)
+		}
+	}
+	return nil
+}
+
+func validateRegistryAuth(auth api.RegistryAuth) error {
+	if auth.Token != "" && (auth.Username != "" || auth.Password != "") {
+		return fmt.Errorf("token can't be set with username and password")
+	}
+	if auth.Password != "" && auth.Username == "" {
+		return fmt.Errorf("username is missing")
+	}
+	return nil
+}
+
+// writeRegistriesConfig writes the hosts.toml of each registry in spec.containerd.registries,
+// with the CA bundles it references, to the containerd certs.d directory.
+func writeRegistriesConfig(fsys util.FileSystem, cfg *api.NodeConfig) error {
+	if err := removeStaleRegistriesConfig(fsys, containerdCertsDir, cfg.Spec.Containerd.Registries); err != nil {
+		return err
+	}
+	files, err := generateRegistriesConfig(cfg.Spec.Containerd.Registries)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		if err := util.WriteFileWithDirFS(fsys, file.Path, file.Data, file.Perm); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeStaleRegistriesConfig removes the directories in certsDir that nodeadm generated
+// for registries no longer in spec.containerd.registries. Directories without a hosts.toml
+// generated by nodeadm are left alone.
+func removeStaleRegistriesConfig(fsys util.FileSystem, certsDir string, registries map[string]api.RegistryOptions) error {
+	entries, err := os.ReadDir(certsDir)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if _, ok := registries[entry.Name()]; ok || !entry.IsDir() {
+			continue
+		}
+		dir := path.Join(certsDir, entry.Name())
+		hostsConfig, err := fsys.ReadFile(path.Join(dir, "hosts.toml"))
+		if err != nil || !bytes.HasPrefix(hostsConfig, []byte(generatedHostsHeader)) {
+			continue
+		}
+		zap.L().Info("Removing containerd config of registry no longer in spec.containerd.registries", zap.String("path", dir))
+		if err := fsys.RemoveAll(dir); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func generateRegistriesConfig(registries map[string]api.RegistryOptions) ([]util.MemoryFile, error) {
+	var files []util.MemoryFile
+	for _, host := range slices.Sorted(maps.Keys(registries)) {
+		registry := registries[host]
+		dir := path.Join(containerdCertsDir, host)
+		vars := hostsTemplateVars{
+			Server: registry.Server,
+			Host:   newHostTemplateVars(registry.TLS, registry.Auth, path.Join(dir, "ca.crt"), &files),
+		}
+		for i, mirror := range registry.Mirrors {
+			capabilities := mirror.Capabilities
+			if len(capabilities) == 0 {
+				capabilities = defaultMirrorCapabilities
+			}
+			vars.Mirrors = append(vars.Mirrors, mirrorTemplateVars{
+				Endpoint:     mirror.Endpoint,
+				Capabilities: capabilities,
+				OverridePath: mirror.OverridePath,
+				Host:         newHostTemplateVars(mirror.TLS, mirror.Auth, path.Join(dir, fmt.Sprintf("mirror-%d-ca.crt", i)), &files),
+			})
+		}
+
+		var buf bytes.Buffer
+		if err := hostsTemplate.Execute(&buf, vars); err != nil {
+			return nil, err
+		}
+		files = append(files, util.MemoryFile{Path: path.Join(dir, "hosts.toml"), Data: buf.Bytes(), Perm: hostsConfigPerm})
+	}
+	return files, nil
+}
+
+// newHostTemplateVars returns the hosts.toml options for an endpoint. If it has a CA
+// bundle, the bundle is added to files at caPath.
+func newHostTemplateVars(tls api.RegistryTLS, auth api.RegistryAuth, caPath string, files *[]util.MemoryFile) hostTemplateVars {
+	vars := hostTemplateVars{
+		ClientCertificate: tls.ClientCertificatePath,
+		ClientKey:         tls.ClientKeyPath,
+		SkipVerify:        tls.InsecureSkipVerify,
+	}
+	if tls.CABundle != "" {
+		vars.CA = caPath
+		*files = append(*files, util.MemoryFile{Path: caPath, Data: []byte(tls.CABundle), Perm: containerdConfigPerm})
+	}
+	// The credentials end up in rendered files and plans, which are redacted before
+	// they are shown.
+	switch {
+	case auth.Token != "":
+		logger.RegisterSecret(auth.Token)
+		vars.Authorization = "Bearer " + auth.Token
+	case auth.Username != "":
+		credentials := base64.StdEncoding.EncodeToString([]byte(auth.Username + ":" + auth.Password))
+		logger.RegisterSecret(auth.Password)
+		logger.RegisterSecret(credentials)
+		vars.Authorization = "Basic " + credentials
+	}
+	return vars
+}
@@ -0,0 +1,193 @@
+package containerd
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aws/eks-hybrid/internal/api"
+	"github.com/aws/eks-hybrid/internal/util"
+)
+
+func TestGenerateRegistriesConfig(t *testing.T) {
+	ca := generateTestCA(t)
+	files, err := generateRegistriesConfig(map[string]api.RegistryOptions{
+		"docker.io": {
+			Server: "https://registry-1.docker.io",
+			Mirrors: []api.RegistryMirror{
+				{
+					Endpoint: "https://harbor.example.com",
+					TLS:      api.RegistryTLS{CABundle: ca},
+					Auth:     api.RegistryAuth{Username: "user", Password: "pass"},
+				},
+				{
+					Endpoint:     "https://artifactory.example.com/artifactory/api/docker/docker-remote",
+					Capabilities: []string{"pull"},
+					OverridePath: true,
+					TLS:          api.RegistryTLS{ClientCertificatePath: "/etc/pki/client.crt", ClientKeyPath: "/etc/pki/client.key"},
+					Auth:         api.RegistryAuth{Token: "token"},
+				},
+			},
+		},
+		"registry.example.com:5000": {
+			TLS: api.RegistryTLS{CABundle: ca, InsecureSkipVerify: true},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []util.MemoryFile{
+		{Path: "/etc/containerd/certs.d/docker.io/mirror-0-ca.crt", Data: []byte(ca), Perm: containerdConfigPerm},
+		{Path: "/etc/containerd/certs.d/docker.io/hosts.toml", Data: []byte(`# Generated by nodeadm from spec.containerd.registries, changes are overwritten.
+server = "https://registry-1.docker.io"
+
+[host."https://harbor.example.com"]
+capabilities = ["pull", "resolve"]
+ca = "/etc/containerd/certs.d/docker.io/mirror-0-ca.crt"
+
+[host."https://harbor.example.com".header]
+Authorization = "Basic dXNlcjpwYXNz"
+
+[host."https://artifactory.example.com/artifactory/api/docker/docker-remote"]
+capabilities = ["pull"]
+override_path = true
+client = [["/etc/pki/client.crt", "/etc/pki/client.key"]]
+
+[host."https://artifactory.example.com/artifactory/api/docker/docker-remote".header]
+Authorization = "Bearer token"
+`), Perm: hostsConfigPerm},
+		{Path: "/etc/containerd/certs.d/registry.example.com:5000/ca.crt", Data: []byte(ca), Perm: containerdConfigPerm},
+		{Path: "/etc/containerd/certs.d/registry.example.com:5000/hosts.toml", Data: []byte(`# Generated by nodeadm from spec.containerd.registries, changes are overwritten.
+ca = "/etc/containerd/certs.d/registry.example.com:5000/ca.crt"
+skip_verify = true
+`), Perm: hostsConfigPerm},
+	}, files)
+}
+
+func TestRemoveStaleRegistriesConfig(t *testing.T) {
+	certsDir := t.TempDir()
+	hostsConfigs := map[string]string{
+		"docker.io":           generatedHostsHeader + ", changes are overwritten.\n",
+		"old.example.com":     generatedHostsHeader + ", changes are overwritten.\n",
+		"foreign.example.com": "server = \"https://foreign.example.com\"\n",
+	}
+	for host, hostsConfig := range hostsConfigs {
+		assert.NoError(t, os.MkdirAll(filepath.Join(certsDir, host), 0o755))
+		assert.NoError(t, os.WriteFile(filepath.Join(certsDir, host, "hosts.toml"), []byte(hostsConfig), 0o600))
+	}
+
+	err := removeStaleRegistriesConfig(util.HostFileSystem(), certsDir, map[string]api.RegistryOptions{
+		"docker.io": {Server: "https://registry-1.docker.io"},
+	})
+	assert.NoError(t, err)
+	assert.DirExists(t, filepath.Join(certsDir, "docker.io"))
+	assert.NoDirExists(t, filepath.Join(certsDir, "old.example.com"))
+	assert.DirExists(t, filepath.Join(certsDir, "foreign.example.com"))
+
+	assert.NoError(t, removeStaleRegistriesConfig(util.HostFileSystem(), filepath.Join(certsDir, "missing"), nil))
+}
+
+func TestValidateRegistries(t *testing.T) {
+	dir := t.TempDir()
+	certPath := filepath.Join(dir, "client.crt")
+	keyPath := filepath.Join(dir, "client.key")
+	assert.NoError(t, os.WriteFile(certPath, []byte("cert"), 0o644))
+	assert.NoError(t, os.WriteFile(keyPath, []byte("key"), 0o600))
+
+	tests := []struct {
+		name       string
+		registries map[string]api.RegistryOptions
+		wantErr    string
+	}{
+		{
+			name: "valid",
+			registries: map[string]api.RegistryOptions{
+				"_default": {Mirrors: []api.RegistryMirror{{Endpoint: "https://harbor.example.com"}}},
+				"registry.example.com:5000": {
+					Server: "http://registry.example.com:5000",
+					TLS:    api.RegistryTLS{CABundle: generateTestCA(t), ClientCertificatePath: certPath, ClientKeyPath: keyPath},
+					Auth:   api.RegistryAuth{Username: "user", Password: "pass"},
+				},
+			},
+		},
+		{
+			name:       "invalid host",
+			registries: map[string]api.RegistryOptions{"https://docker.io": {}},
+			wantErr:    `invalid registry "https://docker.io", must be a host like registry.example.com or registry.example.com:5000`,
+		},
+		{
+			name:       "invalid server",
+			registries: map[string]api.RegistryOptions{"docker.io": {Server: "registry-1.docker.io"}},
+			wantErr:    `invalid server for registry docker.io: "registry-1.docker.io" must be an http:// or https:// URL`,
+		},
+		{
+			name:       "missing endpoint",
+			registries: map[string]api.RegistryOptions{"docker.io": {Mirrors: []api.RegistryMirror{{}}}},
+			wantErr:    "endpoint is missing in mirror 0 of registry docker.io",
+		},
+		{
+			name:       "invalid capability",
+			registries: map[string]api.RegistryOptions{"docker.io": {Mirrors: []api.RegistryMirror{{Endpoint: "https://harbor.example.com", Capabilities: []string{"pull", "delete"}}}}},
+			wantErr:    `invalid capability "delete" for mirror https://harbor.example.com of registry docker.io, must be one of: [pull, resolve, push]`,
+		},
+		{
+			name:       "invalid CA bundle",
+			registries: map[string]api.RegistryOptions{"docker.io": {TLS: api.RegistryTLS{CABundle: "not a certificate"}}},
+			wantErr:    "invalid tls for registry docker.io: caBundle has no PEM encoded certificates",
+		},
+		{
+			name:       "client certificate without key",
+			registries: map[string]api.RegistryOptions{"docker.io": {Mirrors: []api.RegistryMirror{{Endpoint: "https://harbor.example.com", TLS: api.RegistryTLS{ClientCertificatePath: certPath}}}}},
+			wantErr:    "invalid tls for mirror https://harbor.example.com of registry docker.io: clientCertificatePath and clientKeyPath must be set together",
+		},
+		{
+			name:       "missing client key",
+			registries: map[string]api.RegistryOptions{"docker.io": {TLS: api.RegistryTLS{ClientCertificatePath: certPath, ClientKeyPath: filepath.Join(dir, "missing.key")}}},
+			wantErr:    "invalid tls for registry docker.io: " + filepath.Join(dir, "missing.key") + " not found",
+		},
+		{
+			name:       "token and username",
+			registries: map[string]api.RegistryOptions{"docker.io": {Auth: api.RegistryAuth{Username: "user", Token: "token"}}},
+			wantErr:    "invalid auth for registry docker.io: token can't be set with username and password",
+		},
+		{
+			name:       "password without username",
+			registries: map[string]api.RegistryOptions{"docker.io": {Auth: api.RegistryAuth{Password: "pass"}}},
+			wantErr:    "invalid auth for registry docker.io: username is missing",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateRegistries(tt.registries)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func generateTestCA(t *testing.T) string {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "registry-ca"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
@@ -0,0 +1,132 @@
+package containerd
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+
+	nodev1 "k8s.io/api/node/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/aws/eks-hybrid/internal/api"
+)
+
+const (
+	defaultRuntimeName = "runc"
+	runcRuntimeType    = "io.containerd.runc.v2"
+
+	runtimeBinaryNameOption    = "BinaryName"
+	runtimeConfigPathOption    = "ConfigPath"
+	runtimeSystemdCgroupOption = "SystemdCgroup"
+)
+
+var (
+	// runtimeOptionKeyRegex matches the option names that can be written as bare TOML keys.
+	runtimeOptionKeyRegex = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+	// runtimeOptionIntegerRegex matches the option values that are TOML integers.
+	runtimeOptionIntegerRegex = regexp.MustCompile(`^-?(0|[1-9][0-9]{0,17})$`)
+)
+
+type runtimeTemplateVars struct {
+	Name    string
+	Type    string
+	Options []runtimeOptionTemplateVars
+}
+
+type runtimeOptionTemplateVars struct {
+	Key string
+	// Value is TOML encoded.
+	Value string
+}
+
+// ValidateRuntimes returns an error if a runtime in spec.containerd.runtimes can't be
+// added to the containerd config.
+func ValidateRuntimes(runtimes map[string]api.RuntimeOptions) error {
+	for _, name := range slices.Sorted(maps.Keys(runtimes)) {
+		runtime := runtimes[name]
+		if name == defaultRuntimeName {
+			return fmt.Errorf("runtime %s can't be configured, it's the default runtime", name)
+		}
+		if errs := validation.IsDNS1123Label(name); len(errs) > 0 {
+			return fmt.Errorf("invalid runtime name %q, it's used as RuntimeClass handler: %s", name, errs[0])
+		}
+		if runtime.Type == "" {
+			return fmt.Errorf("type is missing in runtime %s", name)
+		}
+		if runtime.BinaryPath != "" && runtime.Type != runcRuntimeType {
+			return fmt.Errorf("binaryPath of runtime %s is only supported by %s runtimes", name, runcRuntimeType)
+		}
+		if runtime.ConfigPath != "" && runtime.Type == runcRuntimeType {
+			return fmt.Errorf("configPath of runtime %s is not supported by %s runtimes", name, runcRuntimeType)
+		}
+		for _, key := range slices.Sorted(maps.Keys(runtime.Options)) {
+			if !runtimeOptionKeyRegex.MatchString(key) {
+				return fmt.Errorf("invalid option %q for runtime %s, must only have letters, digits, - and _", key, name)
+			}
+			if key == runtimeBinaryNameOption || key == runtimeConfigPathOption || key == runtimeSystemdCgroupOption {
+				return fmt.Errorf("option %s of runtime %s is set by nodeadm, use binaryPath or configPath instead", key, name)
+			}
+		}
+	}
+	return nil
+}
+
+// RuntimeClasses returns a RuntimeClass for each runtime in spec.containerd.runtimes.
+// If nodeSelector is set, pods that use them are only scheduled to nodes that match it.
+func RuntimeClasses(runtimes map[string]api.RuntimeOptions, nodeSelector map[string]string) []nodev1.RuntimeClass {
+	var runtimeClasses []nodev1.RuntimeClass
+	for _, name := range slices.Sorted(maps.Keys(runtimes)) {
+		runtimeClass := nodev1.RuntimeClass{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: nodev1.SchemeGroupVersion.String(),
+				Kind:       "RuntimeClass",
+			},
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Handler:    name,
+		}
+		if len(nodeSelector) > 0 {
+			runtimeClass.Scheduling = &nodev1.Scheduling{NodeSelector: nodeSelector}
+		}
+		runtimeClasses = append(runtimeClasses, runtimeClass)
+	}
+	return runtimeClasses
+}
+
+// encodeRuntimeOption returns value TOML encoded. Shims decode their options into typed
+// fields, so booleans and integers are written unquoted and everything else as a string.
+func encodeRuntimeOption(value string) string {
+	if value == "true" || value == "false" || runtimeOptionIntegerRegex.MatchString(value) {
+		return value
+	}
+	return strconv.Quote(value)
+}
+
+func newRuntimesTemplateVars(runtimes map[string]api.RuntimeOptions) []runtimeTemplateVars {
+	var vars []runtimeTemplateVars
+	for _, name := range slices.Sorted(maps.Keys(runtimes)) {
+		runtime := runtimes[name]
+		options := map[string]string{}
+		for key, value := range runtime.Options {
+			options[key] = encodeRuntimeOption(value)
+		}
+		if runtime.BinaryPath != "" {
+			options[runtimeBinaryNameOption] = strconv.Quote(runtime.BinaryPath)
+		}
+		if runtime.ConfigPath != "" {
+			options[runtimeConfigPathOption] = strconv.Quote(runtime.ConfigPath)
+		}
+		if runtime.Type == runcRuntimeType {
+			// match the cgroup driver of the default runtime and the kubelet
+			options[runtimeSystemdCgroupOption] = "true"
+		}
+		runtimeVars := runtimeTemplateVars{Name: name, Type: runtime.Type}
+		for _, key := range slices.Sorted(maps.Keys(options)) {
+			runtimeVars.Options = append(runtimeVars.Options, runtimeOptionTemplateVars{Key: key, Value: options[key]})
+		}
+		vars = append(vars, runtimeVars)
+	}
+	return vars
+}
@@ -0,0 +1,104 @@
+package containerd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aws/eks-hybrid/internal/api"
+)
+
+func TestValidateRuntimes(t *testing.T) {
+	tests := []struct {
+		name     string
+		runtimes map[string]api.RuntimeOptions
+		wantErr  string
+	}{
+		{
+			name: "valid",
+			runtimes: map[string]api.RuntimeOptions{
+				"gvisor": {Type: "io.containerd.runsc.v1", Options: map[string]string{"TypeUrl": "io.containerd.runsc.v1.options"}},
+				"nvidia": {Type: "io.containerd.runc.v2", BinaryPath: "/usr/bin/nvidia-container-runtime"},
+			},
+		},
+		{
+			name:     "default runtime",
+			runtimes: map[string]api.RuntimeOptions{"runc": {Type: "io.containerd.runc.v2"}},
+			wantErr:  "runtime runc can't be configured, it's the default runtime",
+		},
+		{
+			name:     "invalid name",
+			runtimes: map[string]api.RuntimeOptions{"gVisor": {Type: "io.containerd.runsc.v1"}},
+			wantErr:  `invalid runtime name "gVisor", it's used as RuntimeClass handler: a lowercase RFC 1123 label must consist of lower case alphanumeric characters or '-', and must start and end with an alphanumeric character (e.g. 'my-name',  or '123-abc', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?')`,
+		},
+		{
+			name:     "missing type",
+			runtimes: map[string]api.RuntimeOptions{"kata": {}},
+			wantErr:  "type is missing in runtime kata",
+		},
+		{
+			name:     "binary path for non runc runtime",
+			runtimes: map[string]api.RuntimeOptions{"kata": {Type: "io.containerd.kata.v2", BinaryPath: "/usr/bin/kata-runtime"}},
+			wantErr:  "binaryPath of runtime kata is only supported by io.containerd.runc.v2 runtimes",
+		},
+		{
+			name:     "config path for runc runtime",
+			runtimes: map[string]api.RuntimeOptions{"nvidia": {Type: "io.containerd.runc.v2", ConfigPath: "/etc/nvidia-container-runtime/config.toml"}},
+			wantErr:  "configPath of runtime nvidia is not supported by io.containerd.runc.v2 runtimes",
+		},
+		{
+			name:     "invalid option",
+			runtimes: map[string]api.RuntimeOptions{"gvisor": {Type: "io.containerd.runsc.v1", Options: map[string]string{"Type Url": "io.containerd.runsc.v1.options"}}},
+			wantErr:  `invalid option "Type Url" for runtime gvisor, must only have letters, digits, - and _`,
+		},
+		{
+			name:     "option set by nodeadm",
+			runtimes: map[string]api.RuntimeOptions{"kata": {Type: "io.containerd.kata.v2", Options: map[string]string{"ConfigPath": "/etc/kata-containers/configuration.toml"}}},
+			wantErr:  "option ConfigPath of runtime kata is set by nodeadm, use binaryPath or configPath instead",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateRuntimes(tt.runtimes)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEncodeRuntimeOption(t *testing.T) {
+	expected := map[string]string{
+		"true":                           "true",
+		"false":                          "false",
+		"1000":                           "1000",
+		"-1":                             "-1",
+		"0755":                           `"0755"`,
+		"True":                           `"True"`,
+		"io.containerd.runsc.v1.options": `"io.containerd.runsc.v1.options"`,
+	}
+	for value, encoded := range expected {
+		assert.Equal(t, encoded, encodeRuntimeOption(value), "value %s", value)
+	}
+}
+
+func TestRuntimeClasses(t *testing.T) {
+	runtimes := map[string]api.RuntimeOptions{
+		"kata":   {Type: "io.containerd.kata.v2"},
+		"gvisor": {Type: "io.containerd.runsc.v1"},
+	}
+
+	runtimeClasses := RuntimeClasses(runtimes, map[string]string{"sandboxed": "true"})
+	assert.Len(t, runtimeClasses, 2)
+	for i, name := range []string{"gvisor", "kata"} {
+		assert.Equal(t, "node.k8s.io/v1", runtimeClasses[i].APIVersion)
+		assert.Equal(t, "RuntimeClass", runtimeClasses[i].Kind)
+		assert.Equal(t, name, runtimeClasses[i].Name)
+		assert.Equal(t, name, runtimeClasses[i].Handler)
+		assert.Equal(t, map[string]string{"sandboxed": "true"}, runtimeClasses[i].Scheduling.NodeSelector)
+	}
+
+	assert.Nil(t, RuntimeClasses(runtimes, nil)[0].Scheduling)
+}
@@ -3,12 +3,16 @@ package creds
 import (
 	"context"
 	"errors"
+	"os"
+	"os/exec"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials/processcreds"
 
 	"github.com/aws/eks-hybrid/internal/api"
 	"github.com/aws/eks-hybrid/internal/iamrolesanywhere"
+	"github.com/aws/eks-hybrid/internal/validation"
 )
 
 func ReadConfigAsKubelet(ctx context.Context, node *api.NodeConfig, opts ...func(*config.LoadOptions) error) (aws.Config, error) {
@@ -39,8 +43,35 @@ func ReadConfigAsKubelet(ctx context.Context, node *api.NodeConfig, opts ...func
 			config.WithSharedConfigProfile(iamrolesanywhere.ProfileName),
 		)
 
+		// the aws config is written by init, so it doesn't exist yet on a fresh node. Like
+		// LoadDefaultConfig, a missing profile means there is no credential process.
+		sharedConfig, err := config.LoadSharedConfigProfile(ctx, iamrolesanywhere.ProfileName, func(o *config.LoadSharedConfigOptions) {
+			o.ConfigFiles = []string{awsConfigPath}
+		})
+		var notExistErr config.SharedConfigProfileNotExistError
+		if err != nil && !errors.As(err, &notExistErr) {
+			return aws.Config{}, err
+		}
+		if sharedConfig.CredentialProcess != "" {
+			opts = append(opts, config.WithCredentialsProvider(aws.NewCredentialsCache(
+				processcreds.NewProviderCommand(credentialProcessCommand(sharedConfig.CredentialProcess)),
+			)))
+		}
+
 		return config.LoadDefaultConfig(ctx, opts...)
 	}
 
 	return aws.Config{}, errors.New("don't know how to build aws config for node config: only EC2, SSM or IAM Roles Anywhere are supported")
 }
+
+// credentialProcessCommand builds the credential process command like the SDK does,
+// but sends its stderr to the output of the validation retrieving the credentials,
+// so the signing helper errors are reported with the validation that triggered them.
+func credentialProcessCommand(command string) processcreds.NewCommandBuilderFunc {
+	return func(ctx context.Context) (*exec.Cmd, error) {
+		cmd := exec.CommandContext(ctx, "sh", "-c", command)
+		cmd.Env = os.Environ()
+		cmd.Stderr = validation.OutputFromContext(ctx)
+		return cmd, nil
+	}
+}
@@ -0,0 +1,49 @@
+package creds_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-hybrid/internal/api"
+	"github.com/aws/eks-hybrid/internal/creds"
+)
+
+func iamRolesAnywhereNodeConfig(awsConfigPath string) *api.NodeConfig {
+	return &api.NodeConfig{
+		Spec: api.NodeConfigSpec{
+			Cluster: api.ClusterDetails{Name: "my-cluster", Region: "us-west-2"},
+			Hybrid: &api.HybridOptions{
+				IAMRolesAnywhere: &api.IAMRolesAnywhere{
+					NodeName:       "my-node",
+					TrustAnchorARN: "trust-anchor-arn",
+					ProfileARN:     "profile-arn",
+					RoleARN:        "role-arn",
+					AwsConfigPath:  awsConfigPath,
+				},
+			},
+		},
+	}
+}
+
+func TestReadConfigAsKubeletMissingAWSConfig(t *testing.T) {
+	g := NewWithT(t)
+	awsConfigPath := filepath.Join(t.TempDir(), "aws_config")
+
+	cfg, err := creds.ReadConfigAsKubelet(context.Background(), iamRolesAnywhereNodeConfig(awsConfigPath))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(cfg.Region).To(Equal("us-west-2"))
+}
+
+func TestReadConfigAsKubeletWithoutCredentialProcess(t *testing.T) {
+	g := NewWithT(t)
+	awsConfigPath := filepath.Join(t.TempDir(), "aws_config")
+	g.Expect(os.WriteFile(awsConfigPath, []byte("[profile other]\nregion = us-east-1\n"), 0o644)).To(Succeed())
+
+	cfg, err := creds.ReadConfigAsKubelet(context.Background(), iamRolesAnywhereNodeConfig(awsConfigPath))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(cfg.Region).To(Equal("us-west-2"))
+}
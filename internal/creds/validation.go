@@ -9,15 +9,25 @@ import (
 	"github.com/aws/eks-hybrid/internal/validation"
 )
 
+const (
+	ssmAPINetworkValidation   = "ssm-api-network"
+	iamRAAPINetworkValidation = "iam-ra-api-network"
+)
+
+// ValidationNames returns the names of the validations returned by Validations.
+func ValidationNames() []string {
+	return []string{ssmAPINetworkValidation, iamRAAPINetworkValidation}
+}
+
 func Validations(config aws.Config, node *api.NodeConfig) []validation.Validation[*api.NodeConfig] {
 	if node.IsSSM() {
 		return []validation.Validation[*api.NodeConfig]{
-			validation.New("ssm-api-network", ssm.NewAccessValidator(config).Run),
+			validation.New(ssmAPINetworkValidation, ssm.NewAccessValidator(config).Run),
 		}
 	}
 	if node.IsIAMRolesAnywhere() {
 		return []validation.Validation[*api.NodeConfig]{
-			validation.New("iam-ra-api-network", iamrolesanywhere.NewAccessValidator(config).Run),
+			validation.New(iamRAAPINetworkValidation, iamrolesanywhere.NewAccessValidator(config).Run),
 		}
 	}
 
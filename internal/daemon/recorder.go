@@ -0,0 +1,117 @@
+package daemon
+
+import (
+	"context"
+	"sync"
+)
+
+var _ DaemonManager = &RecordingDaemonManager{}
+
+// Operation is a daemon operation recorded by a RecordingDaemonManager.
+type Operation struct {
+	// Action is the operation performed, like enable or restart.
+	Action string
+	// Name is the name of the daemon. It's empty for operations that
+	// don't target a specific daemon, like daemon-reload.
+	Name string
+}
+
+// RecordingDaemonManager records daemon operations instead of applying them.
+// Daemons that were started, restarted or stopped report the status they would
+// have after the operation, so callers waiting for a daemon to be running don't
+// block. Other status queries are delegated to the optional underlying manager so
+// callers can still make decisions based on the current state of the host.
+type RecordingDaemonManager struct {
+	manager    DaemonManager
+	mu         sync.Mutex
+	operations []Operation
+	statuses   map[string]DaemonStatus
+}
+
+// NewRecordingDaemonManager returns a RecordingDaemonManager. manager is only used
+// to read daemon status and can be nil, in which case all daemons report an unknown status.
+func NewRecordingDaemonManager(manager DaemonManager) *RecordingDaemonManager {
+	return &RecordingDaemonManager{
+		manager:  manager,
+		statuses: map[string]DaemonStatus{},
+	}
+}
+
+// Operations returns the recorded operations in the order they were requested.
+func (m *RecordingDaemonManager) Operations() []Operation {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]Operation(nil), m.operations...)
+}
+
+func (m *RecordingDaemonManager) record(action, name string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.operations = append(m.operations, Operation{Action: action, Name: name})
+}
+
+func (m *RecordingDaemonManager) setStatus(name string, status DaemonStatus) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.statuses[name] = status
+}
+
+func (m *RecordingDaemonManager) StartDaemon(name string) error {
+	m.record("start", name)
+	m.setStatus(name, DaemonStatusRunning)
+	return nil
+}
+
+func (m *RecordingDaemonManager) StopDaemon(name string) error {
+	m.record("stop", name)
+	m.setStatus(name, DaemonStatusStopped)
+	return nil
+}
+
+func (m *RecordingDaemonManager) RestartDaemon(ctx context.Context, name string, opts ...OperationOption) error {
+	m.record("restart", name)
+	m.setStatus(name, DaemonStatusRunning)
+	o := &OperationOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.Result != nil {
+		// Callers waiting for the operation expect the result to be delivered asynchronously.
+		go func() { o.Result <- Done }()
+	}
+	return nil
+}
+
+func (m *RecordingDaemonManager) GetDaemonStatus(name string) (DaemonStatus, error) {
+	m.mu.Lock()
+	status, ok := m.statuses[name]
+	m.mu.Unlock()
+	if ok {
+		return status, nil
+	}
+	if m.manager == nil {
+		return DaemonStatusUnknown, nil
+	}
+	return m.manager.GetDaemonStatus(name)
+}
+
+func (m *RecordingDaemonManager) EnableDaemon(name string) error {
+	m.record("enable", name)
+	return nil
+}
+
+func (m *RecordingDaemonManager) DisableDaemon(name string) error {
+	m.record("disable", name)
+	return nil
+}
+
+func (m *RecordingDaemonManager) DaemonReload() error {
+	m.record("daemon-reload", "")
+	return nil
+}
+
+func (m *RecordingDaemonManager) Close() {
+	if m.manager != nil {
+		m.manager.Close()
+	}
+}
@@ -0,0 +1,42 @@
+package daemon_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-hybrid/internal/daemon"
+)
+
+func TestRecordingDaemonManager(t *testing.T) {
+	g := NewWithT(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	manager := daemon.NewRecordingDaemonManager(nil)
+	g.Expect(manager.DaemonReload()).To(Succeed())
+	g.Expect(manager.EnableDaemon("kubelet")).To(Succeed())
+	g.Expect(daemon.WaitForOperation(ctx, manager.RestartDaemon, "kubelet")).To(Succeed())
+	g.Expect(manager.StopDaemon("containerd")).To(Succeed())
+
+	status, err := manager.GetDaemonStatus("kubelet")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(status).To(Equal(daemon.DaemonStatusRunning))
+
+	status, err = manager.GetDaemonStatus("containerd")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(status).To(Equal(daemon.DaemonStatusStopped))
+
+	status, err = manager.GetDaemonStatus("amazon-ssm-agent")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(status).To(Equal(daemon.DaemonStatusUnknown))
+
+	g.Expect(manager.Operations()).To(Equal([]daemon.Operation{
+		{Action: "daemon-reload"},
+		{Action: "enable", Name: "kubelet"},
+		{Action: "restart", Name: "kubelet"},
+		{Action: "stop", Name: "containerd"},
+	}))
+}
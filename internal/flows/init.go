@@ -2,13 +2,20 @@ package flows
 
 import (
 	"context"
+	"time"
 
 	"go.uber.org/zap"
 	"k8s.io/utils/strings/slices"
 
+	"github.com/aws/eks-hybrid/internal/api"
 	"github.com/aws/eks-hybrid/internal/aws"
 	"github.com/aws/eks-hybrid/internal/configenricher"
+	"github.com/aws/eks-hybrid/internal/daemon"
+	"github.com/aws/eks-hybrid/internal/iamrolesanywhere"
 	"github.com/aws/eks-hybrid/internal/nodeprovider"
+	"github.com/aws/eks-hybrid/internal/nodestatus"
+	"github.com/aws/eks-hybrid/internal/ssm"
+	"github.com/aws/eks-hybrid/internal/validation"
 )
 
 const (
@@ -23,9 +30,20 @@ type Initer struct {
 	Logger           *zap.Logger
 	ManifestOverride string
 	PrivateMode      bool
+	// Validations records the results of the node validations for the node status
+	// report. The runner of the node validations must be configured to inform it.
+	Validations *validation.Report
 }
 
+// Run initializes the node and writes the node status report with the outcome.
 func (i *Initer) Run(ctx context.Context) error {
+	start := time.Now()
+	err := i.run(ctx)
+	saveNodeStatus(nodestatus.OperationInit, start, err, i.NodeProvider, i.SkipPhases, i.Validations, i.Logger)
+	return err
+}
+
+func (i *Initer) run(ctx context.Context) error {
 	i.NodeProvider.PopulateNodeConfigDefaults()
 
 	if err := i.NodeProvider.ValidateConfig(); err != nil {
@@ -37,23 +55,9 @@ func (i *Initer) Run(ctx context.Context) error {
 		return err
 	}
 
-	var regionConfig *aws.RegionData
-	var err error
-
 	// Get region config for ECR registry lookup
 	region := i.NodeProvider.GetNodeConfig().Spec.Cluster.Region
-	// Use manifest override if provided, otherwise use default AWS source
-	if i.ManifestOverride != "" {
-		regionConfig, err = aws.GetRegionConfigFromManifest(ctx, region, i.ManifestOverride)
-		if err != nil {
-			i.Logger.Warn("Failed to get region config from manifest", zap.Error(err))
-		}
-	} else {
-		regionConfig, err = aws.GetRegionConfig(ctx, region)
-		if err != nil {
-			i.Logger.Warn("Failed to get region config", zap.Error(err))
-		}
-	}
+	regionConfig := getRegionConfig(ctx, region, i.ManifestOverride, i.Logger)
 
 	if err := i.NodeProvider.Enrich(ctx, configenricher.WithRegionConfig(regionConfig)); err != nil {
 		return err
@@ -81,6 +85,24 @@ func (i *Initer) Run(ctx context.Context) error {
 	return i.NodeProvider.Cleanup()
 }
 
+// getRegionConfig reads the region config from the manifest override if provided,
+// otherwise from the default manifest. Failures are logged and a nil config is returned
+// so callers can fall back to the default region settings.
+func getRegionConfig(ctx context.Context, region, manifestOverride string, logger *zap.Logger) *aws.RegionData {
+	if manifestOverride != "" {
+		regionConfig, err := aws.GetRegionConfigFromManifest(ctx, region, manifestOverride)
+		if err != nil {
+			logger.Warn("Failed to get region config from manifest", zap.Error(err))
+		}
+		return regionConfig
+	}
+	regionConfig, err := aws.GetRegionConfig(ctx, region)
+	if err != nil {
+		logger.Warn("Failed to get region config", zap.Error(err))
+	}
+	return regionConfig
+}
+
 func initDaemons(ctx context.Context, nodeProvider nodeprovider.NodeProvider, skipPhases []string, logger *zap.Logger) error {
 	if !slices.Contains(skipPhases, preprocessPhase) {
 		logger.Info("Configuring Pre-process daemons...")
@@ -125,3 +147,79 @@ func initDaemons(ctx context.Context, nodeProvider nodeprovider.NodeProvider, sk
 	}
 	return nil
 }
+
+// Plan returns the changes Run would make to the host, in order, without applying them.
+// NodeProvider must be built with daemonManager, see Renderer.
+func (i *Initer) Plan(ctx context.Context, daemonManager *daemon.RecordingDaemonManager) (*Plan, error) {
+	renderer := &Renderer{
+		NodeProvider:     i.NodeProvider,
+		DaemonManager:    daemonManager,
+		ManifestOverride: i.ManifestOverride,
+		SkipPhases:       i.SkipPhases,
+		Logger:           i.Logger,
+	}
+	rendered, err := renderer.Run(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := i.NodeProvider.Validate(ctx); err != nil {
+		return nil, err
+	}
+
+	plan := &Plan{}
+	if err := planCredentials(ctx, plan, rendered.NodeConfig, daemonManager, i.Logger); err != nil {
+		return nil, err
+	}
+	for _, aspect := range i.NodeProvider.GetAspects() {
+		plan.Add(PlanStepAspect, "set up", aspect.Name())
+	}
+	if err := planDaemons(ctx, plan, i.NodeProvider, rendered, daemonManager, i.SkipPhases); err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
+// planCredentials adds the SSM registration and starts the daemons that provide AWS
+// credentials with daemonManager, so their operations are recorded.
+func planCredentials(ctx context.Context, plan *Plan, nodeConfig *api.NodeConfig, daemonManager *daemon.RecordingDaemonManager, logger *zap.Logger) error {
+	switch {
+	case nodeConfig.IsSSM():
+		if _, err := ssm.NewSSMRegistration().GetManagedHybridInstanceId(); err != nil {
+			plan.Add(PlanStepSSM, "register", nodeConfig.Spec.Hybrid.SSM.ActivationID,
+				"region: "+nodeConfig.Spec.Cluster.Region,
+			)
+		}
+		return ssm.NewSsmDaemon(daemonManager, nodeConfig, logger).EnsureRunning(ctx)
+	case nodeConfig.IsIAMRolesAnywhere() && nodeConfig.Spec.Hybrid.EnableCredentialsFile:
+		return iamrolesanywhere.NewSigningHelperDaemon(daemonManager, nodeConfig, logger).EnsureRunning(ctx)
+	default:
+		return nil
+	}
+}
+
+// planDaemons adds the rendered files and removals and ensures every daemon is running with
+// daemonManager, adding the recorded operations. Post-launch tasks are not run.
+func planDaemons(ctx context.Context, plan *Plan, nodeProvider nodeprovider.NodeProvider, rendered *Rendered, daemonManager *daemon.RecordingDaemonManager, skipPhases []string) error {
+	if err := plan.addFiles(rendered.Files); err != nil {
+		return err
+	}
+	if err := plan.addRemovals(rendered.Removed); err != nil {
+		return err
+	}
+
+	if !slices.Contains(skipPhases, runPhase) {
+		daemons, err := nodeProvider.GetDaemons()
+		if err != nil {
+			return err
+		}
+		for _, daemon := range daemons {
+			if err := daemon.EnsureRunning(ctx); err != nil {
+				return err
+			}
+		}
+	}
+
+	plan.addDaemonOperations(daemonManager.Operations())
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 
 	"go.uber.org/zap"
 
+	"github.com/aws/eks-hybrid/internal/artifact"
 	"github.com/aws/eks-hybrid/internal/aws"
 	"github.com/aws/eks-hybrid/internal/cni"
 	"github.com/aws/eks-hybrid/internal/containerd"
@@ -19,6 +20,7 @@ import (
 	"github.com/aws/eks-hybrid/internal/packagemanager"
 	"github.com/aws/eks-hybrid/internal/ssm"
 	"github.com/aws/eks-hybrid/internal/tracker"
+	"github.com/aws/eks-hybrid/internal/util"
 )
 
 type Installer struct {
@@ -30,15 +32,29 @@ type Installer struct {
 	Tracker            *tracker.Tracker
 	Logger             *zap.Logger
 	PrivateMode        bool
+	// SsmInstallerOpts configure where the SSM installer is downloaded from.
+	SsmInstallerOpts []ssm.SSMInstallerOption
 }
 
 func (i *Installer) Run(ctx context.Context) error {
+	if err := i.install(ctx); err != nil {
+		return err
+	}
+	pruneArtifactCache(ctx, i.AwsSource, i.CredentialProvider, i.Logger)
+	return nil
+}
+
+func (i *Installer) install(ctx context.Context) error {
 	var err error
 	i.Tracker, err = tracker.GetCurrentState()
 	if err != nil {
 		return err
 	}
 
+	if err := prefetchArtifacts(ctx, i.AwsSource, i.CredentialProvider, i.Logger); err != nil {
+		return err
+	}
+
 	if i.PrivateMode {
 		i.Logger.Info("Private mode: Skipping OS package installation")
 		i.Logger.Info("Installing credential processes and EKS artifacts from manifest...")
@@ -80,6 +96,57 @@ func (i *Installer) Run(ctx context.Context) error {
 	return i.Tracker.Save()
 }
 
+// prefetchArtifacts downloads the release artifacts for the credential provider
+// concurrently, before any of them is installed.
+func prefetchArtifacts(ctx context.Context, source aws.Source, credentialProvider creds.CredentialProvider, log *zap.Logger) error {
+	if source.Downloader == nil {
+		return nil
+	}
+	artifacts, err := artifactsToPrefetch(source, credentialProvider)
+	if err != nil {
+		return err
+	}
+
+	log.Info("Downloading artifacts...")
+	return source.Prefetch(ctx, artifacts...)
+}
+
+// pruneArtifactCache removes the artifacts of other releases from the download cache
+// once the artifacts for the credential provider are installed. Failing to prune
+// the cache doesn't fail the install, it only uses more disk space.
+func pruneArtifactCache(ctx context.Context, source aws.Source, credentialProvider creds.CredentialProvider, log *zap.Logger) {
+	if source.Downloader == nil {
+		return
+	}
+	artifacts, err := artifactsToPrefetch(source, credentialProvider)
+	if err == nil {
+		err = source.PruneCache(ctx, artifacts...)
+	}
+	if err != nil {
+		log.Warn("Failed to remove unused artifacts from the download cache", zap.Error(err))
+	}
+}
+
+// artifactsToPrefetch returns the release artifacts downloaded by prefetchArtifacts.
+func artifactsToPrefetch(source aws.Source, credentialProvider creds.CredentialProvider) ([]aws.Artifact, error) {
+	var artifacts []aws.Artifact
+	for _, eksArtifact := range eksArtifacts {
+		releaseArtifact, err := source.EksArtifact(eksArtifact.name)
+		if err != nil {
+			return nil, err
+		}
+		artifacts = append(artifacts, releaseArtifact)
+	}
+	if credentialProvider == creds.IamRolesAnywhereCredentialProvider {
+		releaseArtifact, err := source.IamRolesAnywhereArtifact(signingHelperArtifact.name)
+		if err != nil {
+			return nil, err
+		}
+		artifacts = append(artifacts, releaseArtifact)
+	}
+	return artifacts, nil
+}
+
 func (i *Installer) installDistroPackages(ctx context.Context) error {
 	i.Logger.Info("Installing containerd...")
 	if err := containerd.Install(ctx, i.Tracker, i.PackageManager, i.ContainerdSource, i.AwsSource.Eks.Version); err != nil {
@@ -107,11 +174,14 @@ func (i *Installer) installCredentialProcess(ctx context.Context) error {
 		}); err != nil {
 			return err
 		}
+		if err := recordArtifacts(i.Tracker, i.AwsSource.Iam.Version, signingHelperArtifact); err != nil {
+			return err
+		}
 	case creds.SsmCredentialProvider:
 		ssmInstaller := ssm.NewSSMInstaller(
 			i.Logger,
 			i.SsmRegion,
-			ssm.WithDnsSuffix(i.AwsSource.RegionInfo.DnsSuffix),
+			append([]ssm.SSMInstallerOption{ssm.WithDnsSuffix(i.AwsSource.RegionInfo.DnsSuffix)}, i.SsmInstallerOpts...)...,
 		)
 
 		i.Logger.Info("Installing SSM agent installer...")
@@ -149,11 +219,12 @@ func (i *Installer) installEksArtifacts(ctx context.Context) error {
 	}
 
 	i.Logger.Info("Installing cni-plugins...")
-	if err := cni.Install(ctx, cni.InstallOptions{
+	cniPlugins, err := cni.Install(ctx, cni.InstallOptions{
 		Tracker: i.Tracker,
 		Source:  i.AwsSource,
 		Logger:  i.Logger,
-	}); err != nil {
+	})
+	if err != nil {
 		return err
 	}
 
@@ -167,9 +238,91 @@ func (i *Installer) installEksArtifacts(ctx context.Context) error {
 	}
 
 	i.Logger.Info("Installing AWS IAM authenticator...")
-	return iamauthenticator.Install(ctx, iamauthenticator.InstallOptions{
+	if err := iamauthenticator.Install(ctx, iamauthenticator.InstallOptions{
 		Tracker: i.Tracker,
 		Source:  i.AwsSource,
 		Logger:  i.Logger,
-	})
+	}); err != nil {
+		return err
+	}
+
+	for _, eksArtifact := range eksArtifacts {
+		version := i.AwsSource.EksArtifactVersion(eksArtifact.name)
+		if err := recordArtifact(i.Tracker, version, eksArtifact, eksArtifactFiles(eksArtifact, cniPlugins)...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Plan returns the changes Run would make to the host, in order, without applying them.
+func (i *Installer) Plan(ctx context.Context) (*Plan, error) {
+	artifactsTracker, err := tracker.GetCurrentState()
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &Plan{}
+	if !i.PrivateMode {
+		if dockerRepo := i.PackageManager.DockerRepo(); dockerRepo != "" {
+			plan.Add(PlanStepPackage, "add repository", dockerRepo)
+		}
+
+		if containerdPackage := containerd.PackageToInstall(i.PackageManager, i.ContainerdSource, i.AwsSource.Eks.Version); containerdPackage != nil {
+			plan.addPackage(ctx, "install", "containerd", containerdPackage.InstallCmd)
+			artifactsTracker.Artifacts.Containerd = i.ContainerdSource
+		} else {
+			artifactsTracker.Artifacts.Containerd = tracker.ContainerdSourceNone
+		}
+
+		if !iptables.IsInstalled() {
+			plan.addPackage(ctx, "install", "iptables", i.PackageManager.GetIptables().InstallCmd)
+			if err := artifactsTracker.Add(artifact.Iptables); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	switch i.CredentialProvider {
+	case creds.IamRolesAnywhereCredentialProvider:
+		source, err := i.AwsSource.IamRolesAnywhereArtifact(signingHelperArtifact.name)
+		if err != nil {
+			return nil, err
+		}
+		plan.addArtifactDownload("install", signingHelperArtifact, i.AwsSource.Iam.Version, source)
+		if err := artifactsTracker.Add(signingHelperArtifact.trackerName); err != nil {
+			return nil, err
+		}
+	case creds.SsmCredentialProvider:
+		plan.Add(PlanStepSSM, "install", ssm.SsmDaemonName, "region: "+i.SsmRegion)
+		if err := artifactsTracker.Add(artifact.Ssm); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unable to detect hybrid auth method")
+	}
+
+	for _, eksArtifact := range eksArtifacts {
+		source, err := i.AwsSource.EksArtifact(eksArtifact.name)
+		if err != nil {
+			return nil, err
+		}
+		plan.addArtifactDownload("install", eksArtifact, i.AwsSource.EksArtifactVersion(eksArtifact.name), source)
+		if eksArtifact == kubeletArtifact {
+			plan.Add(PlanStepFile, "install", kubelet.UnitPath)
+		}
+		if err := artifactsTracker.Add(eksArtifact.trackerName); err != nil {
+			return nil, err
+		}
+	}
+
+	fileSystem := util.NewMemoryFileSystem()
+	if err := artifactsTracker.SaveTo(fileSystem); err != nil {
+		return nil, err
+	}
+	if err := plan.addFiles(fileSystem.Files()); err != nil {
+		return nil, err
+	}
+
+	return plan, nil
 }
@@ -0,0 +1,215 @@
+package flows
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+
+	"github.com/aws/eks-hybrid/internal/artifact"
+	"github.com/aws/eks-hybrid/internal/aws"
+	"github.com/aws/eks-hybrid/internal/cni"
+	"github.com/aws/eks-hybrid/internal/daemon"
+	"github.com/aws/eks-hybrid/internal/iamauthenticator"
+	"github.com/aws/eks-hybrid/internal/iamrolesanywhere"
+	"github.com/aws/eks-hybrid/internal/imagecredentialprovider"
+	"github.com/aws/eks-hybrid/internal/kubectl"
+	"github.com/aws/eks-hybrid/internal/kubelet"
+	"github.com/aws/eks-hybrid/internal/logger"
+	"github.com/aws/eks-hybrid/internal/tracker"
+	"github.com/aws/eks-hybrid/internal/util"
+)
+
+// PlanStepKind is the kind of host resource changed by a plan step.
+type PlanStepKind string
+
+const (
+	PlanStepFile     PlanStepKind = "file"
+	PlanStepPackage  PlanStepKind = "package"
+	PlanStepArtifact PlanStepKind = "artifact"
+	PlanStepDaemon   PlanStepKind = "daemon"
+	PlanStepAspect   PlanStepKind = "aspect"
+	PlanStepSSM      PlanStepKind = "ssm"
+)
+
+// PlanStep is a single change a flow makes to the host.
+type PlanStep struct {
+	Kind PlanStepKind
+	// Action is the change applied to the target, like install or restart.
+	Action string
+	// Target is what the action applies to, like a package, a daemon or a file path.
+	Target string
+	// Details are additional lines describing the change, like a file diff.
+	Details []string
+}
+
+// Plan is the ordered list of changes a flow makes to the host. Plans are built
+// without modifying the host, so they can be reviewed before running the flow.
+type Plan struct {
+	Steps []PlanStep
+}
+
+// Add appends a step to the plan.
+func (p *Plan) Add(kind PlanStepKind, action, target string, details ...string) {
+	p.Steps = append(p.Steps, PlanStep{
+		Kind:    kind,
+		Action:  action,
+		Target:  target,
+		Details: details,
+	})
+}
+
+// addPackage adds a package operation with the command that would run it.
+func (p *Plan) addPackage(ctx context.Context, action, name string, command func(context.Context) *exec.Cmd) {
+	p.Add(PlanStepPackage, action, name, "$ "+command(ctx).String())
+}
+
+// addFiles adds a step with a diff against the current content for every file that
+// doesn't exist or would change.
+func (p *Plan) addFiles(files []util.MemoryFile) error {
+	for _, file := range files {
+		current, err := os.ReadFile(file.Path)
+		switch {
+		case os.IsNotExist(err):
+			p.Add(PlanStepFile, "create", file.Path, fileDiff("/dev/null", file.Path, nil, file.Data)...)
+		case err != nil:
+			return fmt.Errorf("reading %s: %w", file.Path, err)
+		case !bytes.Equal(current, file.Data):
+			p.Add(PlanStepFile, "update", file.Path, fileDiff(file.Path, file.Path, current, file.Data)...)
+		}
+	}
+	return nil
+}
+
+// addRemovals adds a step for every path that exists and would be removed.
+func (p *Plan) addRemovals(paths []string) error {
+	for _, path := range paths {
+		_, err := os.Stat(path)
+		switch {
+		case os.IsNotExist(err):
+		case err != nil:
+			return fmt.Errorf("reading %s: %w", path, err)
+		default:
+			p.Add(PlanStepFile, "remove", path)
+		}
+	}
+	return nil
+}
+
+// addDaemonOperations adds the operations recorded by a daemon.RecordingDaemonManager.
+func (p *Plan) addDaemonOperations(operations []daemon.Operation) {
+	for _, operation := range operations {
+		if operation.Name == "" {
+			p.Add(PlanStepDaemon, operation.Action, "systemd")
+			continue
+		}
+		p.Add(PlanStepDaemon, operation.Action, operation.Name)
+	}
+}
+
+// Write writes the plan to w as a numbered list of steps.
+func (p *Plan) Write(w io.Writer) error {
+	if len(p.Steps) == 0 {
+		_, err := fmt.Fprintln(w, "No changes.")
+		return err
+	}
+
+	var b strings.Builder
+	for i, step := range p.Steps {
+		fmt.Fprintf(&b, "%d. [%s] %s %s\n", i+1, step.Kind, step.Action, step.Target)
+		for _, line := range step.Details {
+			fmt.Fprintf(&b, "     %s\n", line)
+		}
+	}
+	// Rendered files might contain values resolved from secret references.
+	_, err := io.WriteString(w, logger.Redact(b.String()))
+	return err
+}
+
+func fileDiff(fromFile, toFile string, current, desired []byte) []string {
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(current)),
+		B:        difflib.SplitLines(string(desired)),
+		FromFile: fromFile,
+		ToFile:   toFile,
+		Context:  3,
+	})
+	if err != nil {
+		return nil
+	}
+	return strings.Split(strings.TrimRight(diff, "\n"), "\n")
+}
+
+// releaseArtifact is an artifact from an EKS or IAM Roles Anywhere release installed by nodeadm.
+type releaseArtifact struct {
+	// name is the name of the artifact in the release manifest.
+	name string
+	// trackerName is the name of the artifact in the tracker.
+	trackerName string
+	// path is where the artifact is installed. For cni-plugins, it's the
+	// directory the plugin binaries are installed in.
+	path string
+}
+
+var (
+	kubeletArtifact                 = releaseArtifact{name: "kubelet", trackerName: artifact.Kubelet, path: kubelet.BinPath}
+	kubectlArtifact                 = releaseArtifact{name: "kubectl", trackerName: artifact.Kubectl, path: kubectl.BinPath}
+	cniPluginsArtifact              = releaseArtifact{name: "cni-plugins", trackerName: artifact.CniPlugins, path: cni.BinPath}
+	imageCredentialProviderArtifact = releaseArtifact{name: "ecr-credential-provider", trackerName: artifact.ImageCredentialProvider, path: imagecredentialprovider.BinPath}
+	iamAuthenticatorArtifact        = releaseArtifact{name: "aws-iam-authenticator", trackerName: artifact.IamAuthenticator, path: iamauthenticator.IAMAuthenticatorBinPath}
+	signingHelperArtifact           = releaseArtifact{name: "aws_signing_helper", trackerName: artifact.IamRolesAnywhere, path: iamrolesanywhere.SigningHelperBinPath}
+)
+
+// eksArtifacts are the artifacts installed from the EKS release, in install order.
+var eksArtifacts = []releaseArtifact{
+	kubeletArtifact,
+	kubectlArtifact,
+	cniPluginsArtifact,
+	imageCredentialProviderArtifact,
+	iamAuthenticatorArtifact,
+}
+
+// recordArtifacts records the version and checksums of installed release artifacts in the tracker.
+func recordArtifacts(artifactsTracker *tracker.Tracker, version string, artifacts ...releaseArtifact) error {
+	for _, a := range artifacts {
+		if err := recordArtifact(artifactsTracker, version, a, a.path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// recordArtifact records the version and checksums of the files installed for a release artifact in the tracker.
+func recordArtifact(artifactsTracker *tracker.Tracker, version string, a releaseArtifact, files ...string) error {
+	if err := artifactsTracker.Record(a.trackerName, version, files...); err != nil {
+		return fmt.Errorf("recording %s in tracker: %w", a.name, err)
+	}
+	return nil
+}
+
+// eksArtifactFiles returns the files installed for an EKS release artifact,
+// given the cni plugin binaries extracted from the cni-plugins archive.
+func eksArtifactFiles(a releaseArtifact, cniPlugins []string) []string {
+	if a == cniPluginsArtifact {
+		return cniPlugins
+	}
+	return []string{a.path}
+}
+
+// addArtifactDownload adds a step downloading a release artifact to its install path.
+func (p *Plan) addArtifactDownload(action string, a releaseArtifact, version string, source aws.Artifact) {
+	uri := source.URI
+	if source.GzipURI != "" {
+		uri = source.GzipURI
+	}
+	p.Add(PlanStepArtifact, action, a.name,
+		"version: "+version,
+		"source: "+uri,
+		"destination: "+a.path,
+	)
+}
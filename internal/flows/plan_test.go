@@ -0,0 +1,60 @@
+package flows
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-hybrid/internal/daemon"
+	"github.com/aws/eks-hybrid/internal/util"
+)
+
+func TestPlanAddFiles(t *testing.T) {
+	g := NewWithT(t)
+	dir := t.TempDir()
+	unchanged := filepath.Join(dir, "unchanged")
+	updated := filepath.Join(dir, "updated")
+	created := filepath.Join(dir, "created")
+	g.Expect(os.WriteFile(unchanged, []byte("same\n"), 0o644)).To(Succeed())
+	g.Expect(os.WriteFile(updated, []byte("a\nb\n"), 0o644)).To(Succeed())
+
+	plan := &Plan{}
+	g.Expect(plan.addFiles([]util.MemoryFile{
+		{Path: unchanged, Data: []byte("same\n")},
+		{Path: updated, Data: []byte("a\nc\n")},
+		{Path: created, Data: []byte("new\n")},
+	})).To(Succeed())
+
+	g.Expect(plan.Steps).To(HaveLen(2))
+	g.Expect(plan.Steps[0].Action).To(Equal("update"))
+	g.Expect(plan.Steps[0].Target).To(Equal(updated))
+	g.Expect(plan.Steps[0].Details).To(ContainElements(" a", "-b", "+c"))
+	g.Expect(plan.Steps[1].Action).To(Equal("create"))
+	g.Expect(plan.Steps[1].Target).To(Equal(created))
+	g.Expect(plan.Steps[1].Details).To(ContainElements("--- /dev/null", "+new"))
+}
+
+func TestPlanWrite(t *testing.T) {
+	g := NewWithT(t)
+	plan := &Plan{}
+	plan.Add(PlanStepArtifact, "install", "kubelet", "version: 1.31.0")
+	plan.addDaemonOperations([]daemon.Operation{
+		{Action: "daemon-reload"},
+		{Action: "restart", Name: "kubelet"},
+	})
+
+	var out bytes.Buffer
+	g.Expect(plan.Write(&out)).To(Succeed())
+	g.Expect(out.String()).To(Equal(`1. [artifact] install kubelet
+     version: 1.31.0
+2. [daemon] daemon-reload systemd
+3. [daemon] restart kubelet
+`))
+
+	out.Reset()
+	g.Expect((&Plan{}).Write(&out)).To(Succeed())
+	g.Expect(out.String()).To(Equal("No changes.\n"))
+}
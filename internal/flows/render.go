@@ -0,0 +1,114 @@
+package flows
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+	"k8s.io/utils/strings/slices"
+
+	"github.com/aws/eks-hybrid/internal/api"
+	"github.com/aws/eks-hybrid/internal/aws"
+	"github.com/aws/eks-hybrid/internal/configenricher"
+	"github.com/aws/eks-hybrid/internal/daemon"
+	"github.com/aws/eks-hybrid/internal/node/hybrid"
+	"github.com/aws/eks-hybrid/internal/nodeprovider"
+	"github.com/aws/eks-hybrid/internal/ssm"
+	"github.com/aws/eks-hybrid/internal/util"
+)
+
+// Renderer runs the init flow up to, but not including, writing files and starting
+// daemons. It returns the fully resolved NodeConfig and every file init would write.
+type Renderer struct {
+	// NodeProvider must be built with a DaemonManager that doesn't modify the host,
+	// like daemon.RecordingDaemonManager. Hybrid node providers must also be given an
+	// AWS config, since their ConfigureAws registers the node.
+	NodeProvider     nodeprovider.NodeProvider
+	DaemonManager    daemon.DaemonManager
+	ManifestOverride string
+	// RegionConfig is used to enrich the NodeConfig when set. Otherwise it's read
+	// from the manifest.
+	RegionConfig *aws.RegionData
+	// SkipPhases skips rendering daemon configuration when it contains the config phase.
+	SkipPhases []string
+	Logger     *zap.Logger
+}
+
+// Rendered is the output of a Renderer.
+type Rendered struct {
+	NodeConfig *api.NodeConfig
+	Files      []util.MemoryFile
+	// Removed are the paths the configuration would remove from the host.
+	Removed []string
+}
+
+func (r *Renderer) Run(ctx context.Context) (*Rendered, error) {
+	r.NodeProvider.PopulateNodeConfigDefaults()
+
+	if err := r.NodeProvider.ValidateConfig(); err != nil {
+		return nil, err
+	}
+
+	nodeConfig := r.NodeProvider.GetNodeConfig()
+	if r.NodeProvider.GetConfig() == nil {
+		r.Logger.Info("Configuring Aws...")
+		if err := r.NodeProvider.ConfigureAws(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	if nodeConfig.IsSSM() {
+		r.populateSSMNodeName(nodeConfig)
+	}
+
+	regionConfig := r.RegionConfig
+	if regionConfig == nil {
+		regionConfig = getRegionConfig(ctx, nodeConfig.Spec.Cluster.Region, r.ManifestOverride, r.Logger)
+	}
+	if err := r.NodeProvider.Enrich(ctx, configenricher.WithRegionConfig(regionConfig)); err != nil {
+		return nil, err
+	}
+
+	fileSystem := util.NewMemoryFileSystem()
+	ctx = util.NewFileSystemContext(ctx, fileSystem)
+
+	if nodeConfig.IsIAMRolesAnywhere() {
+		configurator := hybrid.RolesAnywhereAWSConfigurator{
+			Manager: r.DaemonManager,
+			Logger:  r.Logger,
+		}
+		if err := configurator.WriteConfig(ctx, nodeConfig); err != nil {
+			return nil, fmt.Errorf("rendering IAM Roles Anywhere config: %w", err)
+		}
+	}
+
+	if !slices.Contains(r.SkipPhases, configPhase) {
+		daemons, err := r.NodeProvider.GetDaemons()
+		if err != nil {
+			return nil, err
+		}
+		for _, daemon := range daemons {
+			r.Logger.Info("Rendering daemon configuration...", zap.String("name", daemon.Name()))
+			if err := daemon.Configure(ctx); err != nil {
+				return nil, fmt.Errorf("rendering %s configuration: %w", daemon.Name(), err)
+			}
+		}
+	}
+
+	return &Rendered{
+		NodeConfig: nodeConfig,
+		Files:      fileSystem.Files(),
+		Removed:    fileSystem.Removed(),
+	}, nil
+}
+
+// populateSSMNodeName sets the node name from an existing SSM registration. The node
+// name is only known after registering with SSM, which render doesn't do.
+func (r *Renderer) populateSSMNodeName(nodeConfig *api.NodeConfig) {
+	nodeName, err := ssm.NewSSMRegistration().GetManagedHybridInstanceId()
+	if err != nil {
+		r.Logger.Warn("Node is not registered with SSM, the node name will be empty in the rendered configuration", zap.Error(err))
+		return
+	}
+	nodeConfig.Status.Hybrid.NodeName = nodeName
+}
@@ -0,0 +1,107 @@
+package flows
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/aws/eks-hybrid/internal/daemon"
+	"github.com/aws/eks-hybrid/internal/iamrolesanywhere"
+	"github.com/aws/eks-hybrid/internal/kubelet"
+	"github.com/aws/eks-hybrid/internal/rollback"
+	"github.com/aws/eks-hybrid/internal/tracker"
+)
+
+// Rollbacker restores the binaries and configuration saved before the last upgrade.
+type Rollbacker struct {
+	DaemonManager daemon.DaemonManager
+	Logger        *zap.Logger
+}
+
+func (r *Rollbacker) Run(ctx context.Context) error {
+	snapshot, err := rollback.Load(rollback.Dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("no upgrade to roll back, a snapshot is taken by nodeadm upgrade before replacing any binary")
+	} else if err != nil {
+		return err
+	}
+
+	r.Logger.Info("Restoring binaries and configuration from snapshot...", zap.Time("createdAt", snapshot.CreatedAt))
+	if err := restoreSnapshot(ctx, snapshot, snapshot.Paths(), r.DaemonManager, r.Logger); err != nil {
+		return err
+	}
+
+	// The snapshot includes the tracker, so the restored binaries are checked
+	// against the checksums recorded when they were installed.
+	installed, err := tracker.GetInstalledArtifacts()
+	if err != nil {
+		return err
+	}
+	results, err := installed.Verify()
+	if err != nil {
+		return err
+	}
+	for _, result := range results {
+		switch result.State {
+		case tracker.FileModified, tracker.FileMissing:
+			return fmt.Errorf("restored %s for %s is %s, the snapshot doesn't match the tracker", result.Path, result.Artifact, result.State)
+		case tracker.FileUnchanged:
+			r.Logger.Info("Restored", zap.String("artifact", result.Artifact), zap.String("version", result.Version), zap.String("path", result.Path))
+		}
+	}
+
+	// The snapshot now matches the node, a second rollback would be a no-op.
+	return os.RemoveAll(rollback.Dir)
+}
+
+// restoreTimeout bounds restoring a snapshot after a failed upgrade, which
+// doesn't use the upgrade's context since it might be the reason it failed.
+const restoreTimeout = 5 * time.Minute
+
+// eksArtifactPaths returns the files installed for the EKS release artifacts.
+// Only the cni plugin binaries recorded in the tracker are included, so plugins
+// installed by other tools in the same directory are left alone. cni-plugins
+// installed before nodeadm recorded them are not included.
+func eksArtifactPaths(installed *tracker.Tracker) []string {
+	var paths []string
+	for _, a := range eksArtifacts {
+		if a != cniPluginsArtifact {
+			paths = append(paths, a.path)
+			continue
+		}
+		if details, ok := installed.Details[a.trackerName]; ok {
+			for _, file := range details.Files {
+				paths = append(paths, file.Path)
+			}
+		}
+	}
+	return paths
+}
+
+// configurationPaths returns the generated configuration an upgrade replaces.
+// containerd configuration is not included, since the containerd package
+// isn't restored either and the configuration must match it.
+func configurationPaths() []string {
+	return append(kubelet.ConfigPaths(), iamrolesanywhere.DefaultAWSConfigPath, iamrolesanywhere.SigningHelperServiceFilePath)
+}
+
+// restoreSnapshot restores paths from the snapshot and restarts the kubelet
+// so it picks up the restored binaries and configuration.
+func restoreSnapshot(ctx context.Context, snapshot *rollback.Snapshot, paths []string, daemonManager daemon.DaemonManager, log *zap.Logger) error {
+	if err := snapshot.RestorePaths(paths...); err != nil {
+		return err
+	}
+	if err := daemonManager.DaemonReload(); err != nil {
+		return err
+	}
+	log.Info("Restarting daemon...", zap.String("name", kubelet.KubeletDaemonName))
+	if err := daemonManager.RestartDaemon(ctx, kubelet.KubeletDaemonName); err != nil {
+		return fmt.Errorf("restarting %s: %w", kubelet.KubeletDaemonName, err)
+	}
+	return nil
+}
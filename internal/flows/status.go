@@ -0,0 +1,52 @@
+package flows
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/aws/eks-hybrid/cmd/nodeadm/version"
+	"github.com/aws/eks-hybrid/internal/kubelet"
+	"github.com/aws/eks-hybrid/internal/logger"
+	"github.com/aws/eks-hybrid/internal/nodeprovider"
+	"github.com/aws/eks-hybrid/internal/nodestatus"
+	"github.com/aws/eks-hybrid/internal/validation"
+)
+
+// saveNodeStatus writes the node status report for an operation that started at start
+// and finished with runErr. Failing to write the report doesn't fail the operation.
+func saveNodeStatus(operation nodestatus.Operation, start time.Time, runErr error, nodeProvider nodeprovider.NodeProvider, skipPhases []string, validations *validation.Report, log *zap.Logger) {
+	report := &nodestatus.Report{
+		Operation:      operation,
+		NodeadmVersion: version.GitVersion,
+		StartTime:      start,
+		EndTime:        time.Now(),
+		Outcome:        nodestatus.OutcomeSucceeded,
+		SkippedPhases:  skipPhases,
+	}
+	if runErr != nil {
+		report.Outcome = nodestatus.OutcomeFailed
+		// Errors might contain values resolved from secret references.
+		report.Error = logger.Redact(runErr.Error())
+	}
+	if validations != nil {
+		report.Validations = validations.Results()
+	}
+
+	if nodeConfig := nodeProvider.GetNodeConfig(); nodeConfig != nil {
+		report.NodeConfigStatus = &nodeConfig.Status
+		hash, err := nodestatus.ConfigHash(nodeConfig)
+		if err != nil {
+			log.Warn("Failed to hash node config for status report", zap.Error(err))
+		}
+		report.ConfigHash = hash
+	}
+
+	if kubeletVersion, err := kubelet.GetKubeletVersion(); err == nil {
+		report.KubernetesVersion = kubeletVersion
+	}
+
+	if err := nodestatus.Save(report); err != nil {
+		log.Warn("Failed to save node status report", zap.Error(err))
+	}
+}
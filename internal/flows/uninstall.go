@@ -173,3 +173,57 @@ func (u *Uninstaller) cleanup() error {
 
 	return nil
 }
+
+// Plan returns the changes Run would make to the host, in order, without applying them.
+func (u *Uninstaller) Plan(ctx context.Context) (*Plan, error) {
+	plan := &Plan{}
+	if u.Artifacts.Kubelet {
+		plan.Add(PlanStepDaemon, "stop", kubelet.KubeletDaemonName)
+		plan.Add(PlanStepArtifact, "remove", kubeletArtifact.name, kubelet.BinPath, kubelet.UnitPath)
+	}
+	if u.Artifacts.Ssm {
+		plan.Add(PlanStepDaemon, "stop", ssm.SsmDaemonName)
+		registration := ssm.NewSSMRegistration()
+		if instanceId, err := registration.GetManagedHybridInstanceId(); err == nil {
+			plan.Add(PlanStepSSM, "deregister", instanceId, "region: "+registration.GetRegion())
+		}
+		plan.addPackage(ctx, "uninstall", ssm.SsmDaemonName, u.PackageManager.GetSSMPackage().UninstallCmd)
+	}
+	if u.Artifacts.IamRolesAnywhere {
+		if status, err := u.DaemonManager.GetDaemonStatus(iamrolesanywhere.DaemonName); err == nil || status != daemon.DaemonStatusUnknown {
+			plan.Add(PlanStepDaemon, "stop", iamrolesanywhere.DaemonName)
+		}
+	}
+	if u.Artifacts.Containerd != tracker.ContainerdSourceNone {
+		plan.Add(PlanStepDaemon, "stop", containerd.ContainerdDaemonName)
+		if containerdPackage := containerd.PackageToUninstall(u.PackageManager); containerdPackage != nil {
+			plan.addPackage(ctx, "uninstall", "containerd", containerdPackage.UninstallCmd)
+		}
+	}
+
+	if u.Artifacts.Kubectl {
+		plan.Add(PlanStepArtifact, "remove", kubectlArtifact.name, kubectlArtifact.path)
+	}
+	if u.Artifacts.CniPlugins {
+		plan.Add(PlanStepArtifact, "remove", cniPluginsArtifact.name, cniPluginsArtifact.path)
+	}
+	if u.Artifacts.IamAuthenticator {
+		plan.Add(PlanStepArtifact, "remove", iamAuthenticatorArtifact.name, iamAuthenticatorArtifact.path)
+	}
+	if u.Artifacts.IamRolesAnywhere {
+		plan.Add(PlanStepArtifact, "remove", signingHelperArtifact.name, signingHelperArtifact.path, iamrolesanywhere.SigningHelperServiceFilePath)
+	}
+	if u.Artifacts.ImageCredentialProvider {
+		plan.Add(PlanStepArtifact, "remove", imageCredentialProviderArtifact.name, imageCredentialProviderArtifact.path)
+	}
+	if u.Artifacts.Iptables && iptables.IsInstalled() {
+		plan.addPackage(ctx, "uninstall", "iptables", u.PackageManager.GetIptables().UninstallCmd)
+	}
+
+	if dockerRepo := u.PackageManager.DockerRepo(); dockerRepo != "" {
+		plan.Add(PlanStepPackage, "remove repository", dockerRepo)
+	}
+	plan.Add(PlanStepFile, "remove", eksConfigDir)
+	plan.Add(PlanStepFile, "remove", tracker.Dir())
+	return plan, nil
+}
@@ -4,10 +4,12 @@ import (
 	"context"
 	"fmt"
 	"slices"
+	"time"
 
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 
+	"github.com/aws/eks-hybrid/internal/artifact"
 	"github.com/aws/eks-hybrid/internal/aws"
 	"github.com/aws/eks-hybrid/internal/cni"
 	"github.com/aws/eks-hybrid/internal/configenricher"
@@ -21,9 +23,12 @@ import (
 	"github.com/aws/eks-hybrid/internal/kubectl"
 	"github.com/aws/eks-hybrid/internal/kubelet"
 	"github.com/aws/eks-hybrid/internal/nodeprovider"
+	"github.com/aws/eks-hybrid/internal/nodestatus"
 	"github.com/aws/eks-hybrid/internal/packagemanager"
+	"github.com/aws/eks-hybrid/internal/rollback"
 	"github.com/aws/eks-hybrid/internal/ssm"
 	"github.com/aws/eks-hybrid/internal/tracker"
+	"github.com/aws/eks-hybrid/internal/validation"
 )
 
 const containerdMajorVersionUpgrade = "containerd-major-version-upgrade"
@@ -38,35 +43,123 @@ type Upgrader struct {
 	SkipPhases         []string
 	Logger             *zap.Logger
 	PrivateMode        bool
+	// SsmInstallerOpts configure where the SSM installer is downloaded from.
+	SsmInstallerOpts []ssm.SSMInstallerOption
+	// Validations records the results of the node validations for the node status
+	// report. The runner of the node validations must be configured to inform it.
+	Validations *validation.Report
+
+	// cniPlugins are the plugin binaries installed by the cni-plugins upgrade.
+	cniPlugins []string
 }
 
+// Run upgrades the node and writes the node status report with the outcome.
 func (u *Upgrader) Run(ctx context.Context) error {
+	start := time.Now()
+	err := u.run(ctx)
+	saveNodeStatus(nodestatus.OperationUpgrade, start, err, u.NodeProvider, u.SkipPhases, u.Validations, u.Logger)
+	return err
+}
+
+// upgradeStep is a step of the upgrade. Run applies the steps in order and Plan
+// describes the same steps, so a dry run follows the upgrade.
+type upgradeStep struct {
+	name string
+	run  func(ctx context.Context) error
+	// plan adds the changes run makes to the host. Steps that only update
+	// nodeadm's own state don't have a plan.
+	plan func(ctx context.Context, plan *Plan, daemonManager *daemon.RecordingDaemonManager) error
+	// replaces returns the files run replaces. They are saved before the upgrade
+	// and restored if it fails once the step has started.
+	replaces func(installed *tracker.Tracker) []string
+}
+
+// steps returns the upgrade steps, in order.
+func (u *Upgrader) steps() []upgradeStep {
+	steps := []upgradeStep{{name: "prefetch", run: u.prefetchArtifacts, plan: u.planPrefetchArtifacts}}
 	if !u.PrivateMode {
-		if err := u.upgradeDistroPackages(ctx); err != nil {
-			return err
+		steps = append(steps, upgradeStep{name: "distro-packages", run: u.upgradeDistroPackages, plan: u.planDistroPackages})
+	}
+	return append(steps,
+		upgradeStep{name: "credential-provider", run: u.upgradeCredentialProvider, plan: u.planCredentialProvider, replaces: u.credentialProviderPaths},
+		upgradeStep{name: "eks-artifacts", run: u.upgradeEksArtifacts, plan: u.planEksArtifacts, replaces: eksArtifactPaths},
+		upgradeStep{name: "tracker", run: u.recordArtifacts, replaces: func(*tracker.Tracker) []string { return []string{tracker.File()} }},
+		upgradeStep{name: "configuration", run: u.configure, plan: u.planConfiguration, replaces: func(*tracker.Tracker) []string { return configurationPaths() }},
+	)
+}
+
+// replacedPaths returns the files replaced by each step, in order.
+func replacedPaths(steps []upgradeStep, installed *tracker.Tracker) [][]string {
+	paths := make([][]string, len(steps))
+	for i, step := range steps {
+		if step.replaces != nil {
+			paths[i] = step.replaces(installed)
 		}
 	}
+	return paths
+}
 
-	if err := u.upgradeCredentialProvider(ctx); err != nil {
+// run takes a snapshot of the binaries and configuration the upgrade replaces
+// and, if the upgrade fails, restores the ones replaced by the steps that ran,
+// so the node isn't left half upgraded.
+func (u *Upgrader) run(ctx context.Context) error {
+	installed, err := tracker.GetCurrentState()
+	if err != nil {
 		return err
 	}
+	steps := u.steps()
+	replaced := replacedPaths(steps, installed)
 
-	if err := u.upgradeEksArtifacts(ctx); err != nil {
-		return err
+	u.Logger.Info("Saving binaries and configuration for rollback...")
+	snapshot, err := rollback.Create(rollback.Dir, slices.Concat(replaced...)...)
+	if err != nil {
+		return errors.Wrap(err, "saving binaries and configuration for rollback")
 	}
 
-	if err := u.NodeProvider.ConfigureAws(ctx); err != nil {
-		return err
+	var started []string
+	for i, step := range steps {
+		started = append(started, replaced[i]...)
+		if err := step.run(ctx); err != nil {
+			return u.restore(ctx, snapshot, started, err)
+		}
 	}
 
-	if err := u.NodeProvider.Enrich(ctx, configenricher.WithRegionConfig(&u.AwsSource.RegionInfo)); err != nil {
+	pruneArtifactCache(ctx, u.AwsSource, u.CredentialProvider, u.Logger)
+	return nil
+}
+
+// restore restores paths from the snapshot after the upgrade failed with err.
+func (u *Upgrader) restore(ctx context.Context, snapshot *rollback.Snapshot, paths []string, err error) error {
+	if len(paths) == 0 {
+		// The upgrade failed before replacing anything, like when downloading the artifacts.
 		return err
 	}
-	if err := initDaemons(ctx, u.NodeProvider, u.SkipPhases, u.Logger); err != nil {
-		return err
+
+	u.Logger.Error("Upgrade failed, restoring the previous binaries and configuration...", zap.Error(err))
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), restoreTimeout)
+	defer cancel()
+	if restoreErr := restoreSnapshot(ctx, snapshot, paths, u.DaemonManager, u.Logger); restoreErr != nil {
+		// The snapshot is the last good state of the node, the next upgrade must not replace it.
+		if markErr := snapshot.MarkRestoreFailed(); markErr != nil {
+			u.Logger.Error("Failed to record the failed restore in the snapshot", zap.Error(markErr))
+		}
+		return fmt.Errorf("%w; restoring previous binaries and configuration, use nodeadm rollback to retry: %w", err, restoreErr)
 	}
+	u.Logger.Info("Restored the previous binaries and configuration")
+	return err
+}
 
-	return u.NodeProvider.Cleanup()
+// credentialProviderPaths returns the binaries replaced when upgrading the credential provider.
+// The SSM agent is upgraded by its own installer and can't be restored.
+func (u *Upgrader) credentialProviderPaths(_ *tracker.Tracker) []string {
+	if u.CredentialProvider == creds.IamRolesAnywhereCredentialProvider {
+		return []string{signingHelperArtifact.path}
+	}
+	return nil
+}
+
+func (u *Upgrader) prefetchArtifacts(ctx context.Context) error {
+	return prefetchArtifacts(ctx, u.AwsSource, u.CredentialProvider, u.Logger)
 }
 
 func (u *Upgrader) upgradeDistroPackages(ctx context.Context) error {
@@ -108,7 +201,7 @@ func (u *Upgrader) upgradeCredentialProvider(ctx context.Context) error {
 		ssmInstaller := ssm.NewSSMInstaller(
 			u.Logger,
 			nodeConfig.Spec.Cluster.Region,
-			ssm.WithDnsSuffix(u.AwsSource.RegionInfo.DnsSuffix),
+			append([]ssm.SSMInstallerOption{ssm.WithDnsSuffix(u.AwsSource.RegionInfo.DnsSuffix)}, u.SsmInstallerOpts...)...,
 		)
 
 		u.Logger.Info("Upgrading SSM agent installer...")
@@ -126,26 +219,190 @@ func (u *Upgrader) upgradeCredentialProvider(ctx context.Context) error {
 }
 
 func (u *Upgrader) upgradeEksArtifacts(ctx context.Context) error {
-	u.Logger.Info("Upgrading kubelet...")
-	if err := kubelet.Upgrade(ctx, u.AwsSource, u.Logger); err != nil {
-		return errors.Wrap(err, "failed to upgrade kubelet")
+	for _, eksArtifact := range eksArtifacts {
+		u.Logger.Info("Upgrading artifact...", zap.String("name", eksArtifact.name))
+		if err := u.upgradeEksArtifact(ctx, eksArtifact); err != nil {
+			return errors.Wrapf(err, "failed to upgrade %s", eksArtifact.name)
+		}
+	}
+	return nil
+}
+
+func (u *Upgrader) upgradeEksArtifact(ctx context.Context, eksArtifact releaseArtifact) error {
+	switch eksArtifact {
+	case kubeletArtifact:
+		return kubelet.Upgrade(ctx, u.AwsSource, u.Logger)
+	case kubectlArtifact:
+		return kubectl.Upgrade(ctx, u.AwsSource, u.Logger)
+	case cniPluginsArtifact:
+		plugins, err := cni.Upgrade(ctx, u.AwsSource, u.Logger)
+		if err != nil {
+			return err
+		}
+		u.cniPlugins = plugins
+		return nil
+	case imageCredentialProviderArtifact:
+		return imagecredentialprovider.Upgrade(ctx, u.AwsSource, u.Logger)
+	case iamAuthenticatorArtifact:
+		return iamauthenticator.Upgrade(ctx, u.AwsSource, u.Logger)
+	default:
+		return fmt.Errorf("artifact %s is not supported for upgrade", eksArtifact.name)
 	}
+}
 
-	u.Logger.Info("Upgrading kubectl...")
-	if err := kubectl.Upgrade(ctx, u.AwsSource, u.Logger); err != nil {
+// recordArtifacts records the versions and checksums of the upgraded artifacts in the tracker.
+func (u *Upgrader) recordArtifacts(_ context.Context) error {
+	artifactsTracker, err := tracker.GetCurrentState()
+	if err != nil {
 		return err
 	}
+	for _, eksArtifact := range eksArtifacts {
+		version := u.AwsSource.EksArtifactVersion(eksArtifact.name)
+		if err := recordArtifact(artifactsTracker, version, eksArtifact, eksArtifactFiles(eksArtifact, u.cniPlugins)...); err != nil {
+			return err
+		}
+	}
+	if u.CredentialProvider == creds.IamRolesAnywhereCredentialProvider {
+		if err := recordArtifacts(artifactsTracker, u.AwsSource.Iam.Version, signingHelperArtifact); err != nil {
+			return err
+		}
+	}
+	return artifactsTracker.Save()
+}
 
-	u.Logger.Info("Upgrading image credential provider...")
-	if err := imagecredentialprovider.Upgrade(ctx, u.AwsSource, u.Logger); err != nil {
+// configure writes the configuration for the upgraded node and restarts the daemons.
+func (u *Upgrader) configure(ctx context.Context) error {
+	if err := u.NodeProvider.ConfigureAws(ctx); err != nil {
 		return err
 	}
 
-	u.Logger.Info("Upgrading IAM authenticator...")
-	if err := iamauthenticator.Upgrade(ctx, u.AwsSource, u.Logger); err != nil {
+	if err := u.NodeProvider.Enrich(ctx, configenricher.WithRegionConfig(&u.AwsSource.RegionInfo)); err != nil {
+		return err
+	}
+	if err := initDaemons(ctx, u.NodeProvider, u.SkipPhases, u.Logger); err != nil {
 		return err
 	}
 
-	u.Logger.Info("Upgrading cni-plugins...")
-	return cni.Upgrade(ctx, u.AwsSource, u.Logger)
+	return u.NodeProvider.Cleanup()
+}
+
+// Plan returns the changes Run would make to the host, in order, without applying them.
+// NodeProvider must be built with daemonManager, see Renderer.
+func (u *Upgrader) Plan(ctx context.Context, daemonManager *daemon.RecordingDaemonManager) (*Plan, error) {
+	installed, err := tracker.GetCurrentState()
+	if err != nil {
+		return nil, err
+	}
+	steps := u.steps()
+
+	plan := &Plan{}
+	plan.Add(PlanStepFile, "snapshot", rollback.Dir, slices.Concat(replacedPaths(steps, installed)...)...)
+	for _, step := range steps {
+		if step.plan == nil {
+			continue
+		}
+		if err := step.plan(ctx, plan, daemonManager); err != nil {
+			return nil, fmt.Errorf("planning %s: %w", step.name, err)
+		}
+	}
+	return plan, nil
+}
+
+func (u *Upgrader) planPrefetchArtifacts(_ context.Context, plan *Plan, _ *daemon.RecordingDaemonManager) error {
+	if u.AwsSource.Downloader == nil {
+		return nil
+	}
+	artifacts, err := artifactsToPrefetch(u.AwsSource, u.CredentialProvider)
+	if err != nil {
+		return err
+	}
+	for _, releaseArtifact := range artifacts {
+		plan.Add(PlanStepArtifact, "download", releaseArtifact.Name, "source: "+releaseArtifact.URI)
+	}
+	return nil
+}
+
+func (u *Upgrader) planDistroPackages(ctx context.Context, plan *Plan, _ *daemon.RecordingDaemonManager) error {
+	plan.addPackage(ctx, "refresh", "package metadata", u.PackageManager.RefreshMetadataCacheCmd)
+	if u.Artifacts.Containerd != tracker.ContainerdSourceNone {
+		skipContainerdMajorVersionUpgrade := slices.Contains(u.SkipPhases, containerdMajorVersionUpgrade)
+		containerdPackage, err := containerd.PackageToUpgrade(u.PackageManager, u.AwsSource.Eks.Version, skipContainerdMajorVersionUpgrade)
+		if err != nil {
+			return err
+		}
+		plan.addPackage(ctx, "upgrade", "containerd", containerdPackage.UpgradeCmd)
+	}
+	if u.Artifacts.Iptables && iptables.IsInstalled() {
+		plan.addPackage(ctx, "upgrade", "iptables", u.PackageManager.GetIptables().UpgradeCmd)
+	}
+	return nil
+}
+
+func (u *Upgrader) planCredentialProvider(ctx context.Context, plan *Plan, _ *daemon.RecordingDaemonManager) error {
+	switch u.CredentialProvider {
+	case creds.IamRolesAnywhereCredentialProvider:
+		source, err := u.AwsSource.IamRolesAnywhereArtifact(signingHelperArtifact.name)
+		if err != nil {
+			return err
+		}
+		return planArtifactUpgrade(ctx, plan, u.AwsSource, signingHelperArtifact, u.AwsSource.Iam.Version, source)
+	case creds.SsmCredentialProvider:
+		plan.Add(PlanStepSSM, "upgrade", ssm.SsmDaemonName, "region: "+u.NodeProvider.GetNodeConfig().Spec.Cluster.Region)
+		return nil
+	default:
+		return fmt.Errorf("installed credential provider %s is not supported for upgrade", u.CredentialProvider)
+	}
+}
+
+func (u *Upgrader) planEksArtifacts(ctx context.Context, plan *Plan, _ *daemon.RecordingDaemonManager) error {
+	for _, eksArtifact := range eksArtifacts {
+		source, err := u.AwsSource.EksArtifact(eksArtifact.name)
+		if err != nil {
+			return err
+		}
+		if eksArtifact == cniPluginsArtifact {
+			// cni-plugins are always re-installed, see cni.Upgrade.
+			plan.addArtifactDownload("upgrade", eksArtifact, u.AwsSource.EksArtifactVersion(eksArtifact.name), source)
+			continue
+		}
+		if err := planArtifactUpgrade(ctx, plan, u.AwsSource, eksArtifact, u.AwsSource.EksArtifactVersion(eksArtifact.name), source); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (u *Upgrader) planConfiguration(ctx context.Context, plan *Plan, daemonManager *daemon.RecordingDaemonManager) error {
+	renderer := &Renderer{
+		NodeProvider:  u.NodeProvider,
+		DaemonManager: daemonManager,
+		RegionConfig:  &u.AwsSource.RegionInfo,
+		SkipPhases:    u.SkipPhases,
+		Logger:        u.Logger,
+	}
+	rendered, err := renderer.Run(ctx)
+	if err != nil {
+		return err
+	}
+	if err := planCredentials(ctx, plan, rendered.NodeConfig, daemonManager, u.Logger); err != nil {
+		return err
+	}
+	return planDaemons(ctx, plan, u.NodeProvider, rendered, daemonManager, u.SkipPhases)
+}
+
+// planArtifactUpgrade adds the artifact download only if the installed artifact doesn't
+// match the release checksum, like artifact.Upgrade.
+func planArtifactUpgrade(ctx context.Context, plan *Plan, awsSource aws.Source, a releaseArtifact, version string, source aws.Artifact) error {
+	checksum, err := awsSource.Checksum(ctx, source)
+	if err != nil {
+		return err
+	}
+	match, err := artifact.ChecksumMatches(a.path, checksum)
+	if err != nil {
+		return err
+	}
+	if !match {
+		plan.addArtifactDownload("upgrade", a, version, source)
+	}
+	return nil
 }
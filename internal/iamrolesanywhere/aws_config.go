@@ -2,14 +2,14 @@ package iamrolesanywhere
 
 import (
 	"bytes"
+	"context"
 	_ "embed"
 	"errors"
 	"fmt"
-	"os"
-	"path"
 	"text/template"
 
 	"github.com/aws/eks-hybrid/internal/network"
+	"github.com/aws/eks-hybrid/internal/util"
 )
 
 const (
@@ -61,7 +61,8 @@ type AWSConfig struct {
 }
 
 // WriteAWSConfig writes an AWS configuration file with contents appropriate for node config
-func WriteAWSConfig(cfg AWSConfig) error {
+// to the filesystem carried by ctx.
+func WriteAWSConfig(ctx context.Context, cfg AWSConfig) error {
 	if cfg.ConfigPath == "" {
 		cfg.ConfigPath = DefaultAWSConfigPath
 	}
@@ -72,7 +73,7 @@ func WriteAWSConfig(cfg AWSConfig) error {
 		return err
 	}
 
-	return writeConfigFile(cfg)
+	return writeConfigFile(util.FileSystemFromContext(ctx), cfg)
 }
 
 func validateAWSConfig(cfg AWSConfig) error {
@@ -113,17 +114,13 @@ func validateAWSConfig(cfg AWSConfig) error {
 	return errors.Join(errs...)
 }
 
-func writeConfigFile(cfg AWSConfig) error {
+func writeConfigFile(fsys util.FileSystem, cfg AWSConfig) error {
 	var buf bytes.Buffer
 	if err := awsConfigTpl.Execute(&buf, cfg); err != nil {
 		return err
 	}
 
-	if err := os.MkdirAll(path.Dir(cfg.ConfigPath), os.ModeDir); err != nil {
-		return err
-	}
-
-	if err := os.WriteFile(cfg.ConfigPath, buf.Bytes(), 0o644); err != nil {
+	if err := util.WriteFileWithDirFS(fsys, cfg.ConfigPath, buf.Bytes(), 0o644); err != nil {
 		return fmt.Errorf("writing AWS config file: %w", err)
 	}
 
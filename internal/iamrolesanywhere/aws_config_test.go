@@ -2,6 +2,7 @@ package iamrolesanywhere_test
 
 import (
 	"bytes"
+	"context"
 	"os"
 	"path/filepath"
 	"testing"
@@ -32,7 +33,7 @@ func TestEnsureAWSConfig_Write(t *testing.T) {
 		PrivateKeyPath:       "/etc/certificates/iam/pki/my-server.key",
 	}
 
-	err = iamrolesanywhere.WriteAWSConfig(cfg)
+	err = iamrolesanywhere.WriteAWSConfig(context.Background(), cfg)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -81,7 +82,7 @@ func TestEnsureAWSConfig_ExistsSameContent(t *testing.T) {
 		PrivateKeyPath:       "/etc/certificates/iam/pki/my-server.key",
 	}
 
-	err = iamrolesanywhere.WriteAWSConfig(cfg)
+	err = iamrolesanywhere.WriteAWSConfig(context.Background(), cfg)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -115,7 +116,7 @@ func TestEnsureAWSConfig_ExistsDifferentContent(t *testing.T) {
 		PrivateKeyPath:  "/etc/certificates/iam/pki/my-server.key",
 	}
 
-	err := iamrolesanywhere.WriteAWSConfig(cfg)
+	err := iamrolesanywhere.WriteAWSConfig(context.Background(), cfg)
 	if err == nil {
 		t.Fatal("Expeted error, received nil")
 	}
@@ -159,7 +160,7 @@ func TestWriteAWSConfigValidation(t *testing.T) {
 			g := NewWithT(t)
 
 			g.Expect(
-				iamrolesanywhere.WriteAWSConfig(tc.config),
+				iamrolesanywhere.WriteAWSConfig(context.Background(), tc.config),
 			).To(MatchError(tc.wantErr))
 		})
 	}
@@ -220,7 +221,7 @@ func TestWriteAWSConfigProxy(t *testing.T) {
 				PrivateKeyPath:       "/etc/certificates/iam/pki/my-server.key",
 			}
 
-			err := iamrolesanywhere.WriteAWSConfig(cfg)
+			err := iamrolesanywhere.WriteAWSConfig(context.Background(), cfg)
 			if err != nil {
 				t.Fatalf("WriteAWSConfig failed: %v", err)
 			}
@@ -50,7 +50,7 @@ func (s *SigningHelperDaemon) Configure(ctx context.Context) error {
 		return err
 	}
 
-	if err := util.WriteFileWithDir(SigningHelperServiceFilePath, service, 0o644); err != nil {
+	if err := util.WriteFileWithDirFS(util.FileSystemFromContext(ctx), SigningHelperServiceFilePath, service, 0o644); err != nil {
 		return fmt.Errorf("writing aws_signing_helper_update service file %s: %v", EksHybridAwsCredentialsPath, err)
 	}
 
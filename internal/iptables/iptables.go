@@ -21,7 +21,7 @@ type Source interface {
 
 // Install iptables package required for kubelet.
 func Install(ctx context.Context, tracker *tracker.Tracker, source Source) error {
-	if !isIptablesInstalled() {
+	if !IsInstalled() {
 		iptablesSrc := source.GetIptables()
 		// Sometimes install fails due to conflicts with other processes
 		// updating packages, specially when automating at machine startup.
@@ -36,7 +36,7 @@ func Install(ctx context.Context, tracker *tracker.Tracker, source Source) error
 
 // Uninstall iptables package
 func Uninstall(ctx context.Context, source Source) error {
-	if isIptablesInstalled() {
+	if IsInstalled() {
 		iptablesSrc := source.GetIptables()
 		if err := cmd.Retry(ctx, iptablesSrc.UninstallCmd, 5*time.Second); err != nil {
 			return errors.Wrap(err, "failed to uninstall iptables")
@@ -46,7 +46,7 @@ func Uninstall(ctx context.Context, source Source) error {
 }
 
 func Upgrade(ctx context.Context, source Source) error {
-	if isIptablesInstalled() {
+	if IsInstalled() {
 		iptablesSrc := source.GetIptables()
 		if err := cmd.Retry(ctx, iptablesSrc.UpgradeCmd, 5*time.Second); err != nil {
 			return errors.Wrap(err, "failed to upgrade iptables")
@@ -55,7 +55,8 @@ func Upgrade(ctx context.Context, source Source) error {
 	return nil
 }
 
-func isIptablesInstalled() bool {
+// IsInstalled returns true if the iptables binary is in the PATH.
+func IsInstalled() bool {
 	_, err := exec.LookPath(iptablesBinName)
 	return err == nil
 }
@@ -8,6 +8,6 @@ const caCertificatePath = "/etc/kubernetes/pki/ca.crt"
 
 // Write the cluster certifcate authority to the filesystem where
 // both kubelet and kubeconfig can read it
-func writeClusterCaCert(caCert []byte) error {
-	return util.WriteFileWithDir(caCertificatePath, caCert, kubeletConfigPerm)
+func writeClusterCaCert(fsys util.FileSystem, caCert []byte) error {
+	return util.WriteFileWithDirFS(fsys, caCertificatePath, caCert, kubeletConfigPerm)
 }
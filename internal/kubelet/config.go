@@ -6,7 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"math"
+	"maps"
 	"net"
 	"net/url"
 	"os"
@@ -47,7 +47,7 @@ const (
 
 var nodeNameProviderIdRegexPattern = regexp.MustCompile(`^eks-hybrid:///[^/]+/[^/]+/(.+)$`)
 
-func (k *kubelet) writeKubeletConfig() error {
+func (k *kubelet) writeKubeletConfig(fsys util.FileSystem) error {
 	kubeletVersion, err := GetKubeletVersion()
 	if err != nil {
 		return err
@@ -55,9 +55,9 @@ func (k *kubelet) writeKubeletConfig() error {
 	// tracking: https://github.com/kubernetes/enhancements/issues/3983
 	// for enabling drop-in configuration
 	if semver.Compare(kubeletVersion, "v1.29.0") < 0 {
-		return k.writeKubeletConfigToFile()
+		return k.writeKubeletConfigToFile(fsys)
 	} else {
-		return k.writeKubeletConfigToDir()
+		return k.writeKubeletConfigToDir(fsys)
 	}
 }
 
@@ -89,6 +89,7 @@ type kubeletConfig struct {
 	RegisterWithTaints       []v1.Taint                       `json:"registerWithTaints,omitempty"`
 	SerializeImagePulls      bool                             `json:"serializeImagePulls"`
 	ServerTLSBootstrap       bool                             `json:"serverTLSBootstrap"`
+	SystemReserved           map[string]string                `json:"systemReserved,omitempty"`
 	SystemReservedCgroup     *string                          `json:"systemReservedCgroup,omitempty"`
 	TLSCipherSuites          []string                         `json:"tlsCipherSuites"`
 	ResolvConf               string                           `json:"resolvConf,omitempty"`
@@ -131,11 +132,7 @@ func defaultKubeletSubConfig() kubeletConfig {
 		CgroupRoot:               "/",
 		ClusterDomain:            "cluster.local",
 		ContainerRuntimeEndpoint: containerd.ContainerRuntimeEndpoint,
-		EvictionHard: map[string]string{
-			"memory.available":  "100Mi",
-			"nodefs.available":  "10%",
-			"nodefs.inodesFree": "5%",
-		},
+		EvictionHard:             defaultEvictionHard(),
 		FeatureGates: map[string]bool{
 			"RotateKubeletServerCertificate": true,
 		},
@@ -259,65 +256,61 @@ func (ksc *kubeletConfig) withHybridNodeLabels(cfg *api.NodeConfig, flags map[st
 	flags["node-labels"] = strings.Join(labels, ",")
 }
 
-// When the DefaultReservedResources flag is enabled, override the kubelet
-// config with reserved cgroup values on behalf of the user
-func (ksc *kubeletConfig) withDefaultReservedResources(cfg *api.NodeConfig) {
-	ksc.SystemReservedCgroup = ptr.String("/system")
-	ksc.KubeReservedCgroup = ptr.String("/runtime")
+// withMaxPods sets the max pods of the instance type of EC2 nodes.
+func (ksc *kubeletConfig) withMaxPods(cfg *api.NodeConfig) {
 	maxPods, ok := MaxPodsPerInstanceType[cfg.Status.Instance.Type]
 	if !ok {
 		ksc.MaxPods = CalcMaxPods(cfg.Status.Instance.Region, cfg.Status.Instance.Type)
 	} else {
 		ksc.MaxPods = int32(maxPods)
 	}
-	ksc.KubeReserved = map[string]string{
-		"cpu":               fmt.Sprintf("%dm", getCPUMillicoresToReserve()),
-		"ephemeral-storage": "1Gi",
-		"memory":            fmt.Sprintf("%dMi", getMemoryMebibytesToReserve(ksc.MaxPods)),
+}
+
+// withHybridMaxPods sets the max pods of hybrid nodes from the size of their pod CIDR,
+// when it's known. Otherwise, the kubelet's default is used.
+func (ksc *kubeletConfig) withHybridMaxPods(cfg *api.NodeConfig) error {
+	maxPods, err := getHybridMaxPods(cfg)
+	if err != nil {
+		return err
 	}
+	ksc.MaxPods = maxPods
+	return nil
 }
 
-// withHybridReservedResources reserves cpu and memory according to below computation
-// for kubelet in order for safe cluster management operation
-func (ksc *kubeletConfig) withHybridReservedResources() error {
+// withReservedResources reserves resources for the kubelet, the container runtime and
+// the operating system, and sets the eviction thresholds, according to the reservation
+// policy. The computed values are recorded in the node config status.
+func (ksc *kubeletConfig) withReservedResources(cfg *api.NodeConfig) error {
 	ksc.SystemReservedCgroup = ptr.String("/system")
 	ksc.KubeReservedCgroup = ptr.String("/runtime")
 
-	// calculate kube reserved memory
-	totalMemory, err := system.GetMachineMemoryCapacity()
+	// EC2 nodes reserve memory for their max pods by default, so their memory is only
+	// read when the policy reserves a percentage of it.
+	readMemory, err := reservationNeedsMemory(cfg.Spec.Kubelet.Reservation, cfg.IsHybridNode())
 	if err != nil {
 		return err
 	}
-	// Convert bytes to GiB
-	totalMemoryGiB := totalMemory / (1024 * 1024 * 1024)
-	var reserveMemoryString string
-
-	// For memory resources, nodeadm will reserve according to the following table for hybrid nodes
-	// 255 MiB when total memory is < 1GiB
-	// 25% of first 4GiB of total memory
-	// 20% of next 4GiB of total memory
-	// 10% of next 8 GiB of total memory
-	// 6% of next 112 GiB of total memory
-	// 2% of remaining total memory
-	switch {
-	case totalMemoryGiB < 1:
-		reserveMemoryString = fmt.Sprintf("%dMi", 255)
-	case totalMemoryGiB < 4:
-		reserveMemoryString = fmt.Sprintf("%dGi", int(math.Round(float64(totalMemoryGiB)*0.25)))
-	case totalMemoryGiB < 8:
-		reserveMemoryString = fmt.Sprintf("%dGi", int(math.Round((0.25*4)+float64(totalMemoryGiB-4)*0.2)))
-	case totalMemoryGiB < 16:
-		reserveMemoryString = fmt.Sprintf("%dGi", int(math.Round((0.25*4)+(0.20*4)+float64(totalMemoryGiB-8)*0.1)))
-	case totalMemoryGiB <= 128:
-		reserveMemoryString = fmt.Sprintf("%dGi", int(math.Round((0.25*4)+(0.20*4)+(0.10*8)+float64(totalMemoryGiB-16)*0.06)))
-	case totalMemoryGiB > 128:
-		reserveMemoryString = fmt.Sprintf("%dGi", int(math.Round((0.25*4)+(0.20*4)+(0.10*8)+(0.06*112)+float64(totalMemoryGiB-128)*0.02)))
+	capacity, err := getNodeCapacity(readMemory)
+	if err != nil {
+		return err
+	}
+	defaultMemory := fmt.Sprintf("%dMi", getMemoryMebibytesToReserve(ksc.MaxPods))
+	if cfg.IsHybridNode() {
+		defaultMemory = getHybridDefaultMemoryToReserve(capacity.memoryBytes, ksc.MaxPods)
+	}
+	reservation, err := newReservation(cfg.Spec.Kubelet.Reservation, capacity, defaultMemory)
+	if err != nil {
+		return err
 	}
 
-	ksc.KubeReserved = map[string]string{
-		"cpu":               fmt.Sprintf("%dm", getCPUMillicoresToReserve()),
-		"ephemeral-storage": "1Gi",
-		"memory":            reserveMemoryString,
+	ksc.KubeReserved = reservation.kubeReserved
+	ksc.SystemReserved = reservation.systemReserved
+	ksc.EvictionHard = reservation.evictionHard
+	cfg.Status.Kubelet = api.KubeletDetails{
+		MaxPods:        ksc.MaxPods,
+		KubeReserved:   maps.Clone(reservation.kubeReserved),
+		SystemReserved: maps.Clone(reservation.systemReserved),
+		EvictionHard:   maps.Clone(reservation.evictionHard),
 	}
 	return nil
 }
@@ -363,7 +356,10 @@ func (k *kubelet) GenerateKubeletConfig() (*kubeletConfig, error) {
 	if k.nodeConfig.IsHybridNode() {
 		kubeletConfig.withHybridCloudProvider(k.nodeConfig, k.flags)
 		kubeletConfig.withHybridNodeLabels(k.nodeConfig, k.flags)
-		if err := kubeletConfig.withHybridReservedResources(); err != nil {
+		if err := kubeletConfig.withHybridMaxPods(k.nodeConfig); err != nil {
+			return nil, err
+		}
+		if err := kubeletConfig.withReservedResources(k.nodeConfig); err != nil {
 			return nil, err
 		}
 
@@ -379,7 +375,10 @@ func (k *kubelet) GenerateKubeletConfig() (*kubeletConfig, error) {
 			return nil, err
 		}
 		kubeletConfig.withCloudProvider(kubeletVersion, k.nodeConfig, k.flags)
-		kubeletConfig.withDefaultReservedResources(k.nodeConfig)
+		kubeletConfig.withMaxPods(k.nodeConfig)
+		if err := kubeletConfig.withReservedResources(k.nodeConfig); err != nil {
+			return nil, err
+		}
 	}
 
 	return &kubeletConfig, nil
@@ -387,7 +386,7 @@ func (k *kubelet) GenerateKubeletConfig() (*kubeletConfig, error) {
 
 // WriteConfig writes the kubelet config to a file.
 // This should only be used for kubelet versions < 1.28.
-func (k *kubelet) writeKubeletConfigToFile() error {
+func (k *kubelet) writeKubeletConfigToFile(fsys util.FileSystem) error {
 	kubeletConfig, err := k.GenerateKubeletConfig()
 	if err != nil {
 		return err
@@ -413,14 +412,14 @@ func (k *kubelet) writeKubeletConfigToFile() error {
 	k.flags["config"] = configPath
 
 	zap.L().Info("Writing kubelet config to file...", zap.String("path", configPath))
-	return util.WriteFileWithDir(configPath, kubeletConfigBytes, kubeletConfigPerm)
+	return util.WriteFileWithDirFS(fsys, configPath, kubeletConfigBytes, kubeletConfigPerm)
 }
 
 // WriteKubeletConfigToDir writes nodeadm's generated kubelet config to the
 // standard config file and writes the user's provided config to a directory for
 // drop-in support. This is only supported on kubelet versions >= 1.28. see:
 // https://kubernetes.io/docs/tasks/administer-cluster/kubelet-config-file/#kubelet-conf-d
-func (k *kubelet) writeKubeletConfigToDir() error {
+func (k *kubelet) writeKubeletConfigToDir(fsys util.FileSystem) error {
 	kubeletConfig, err := k.GenerateKubeletConfig()
 	if err != nil {
 		return err
@@ -434,7 +433,7 @@ func (k *kubelet) writeKubeletConfigToDir() error {
 	k.flags["config"] = configPath
 
 	zap.L().Info("Writing kubelet config to file...", zap.String("path", configPath))
-	if err := util.WriteFileWithDir(configPath, kubeletConfigBytes, kubeletConfigPerm); err != nil {
+	if err := util.WriteFileWithDirFS(fsys, configPath, kubeletConfigBytes, kubeletConfigPerm); err != nil {
 		return err
 	}
 
@@ -459,7 +458,7 @@ func (k *kubelet) writeKubeletConfigToDir() error {
 		if err != nil {
 			return err
 		}
-		if err := util.WriteFileWithDir(filePath, userKubeletConfigBytes, kubeletConfigPerm); err != nil {
+		if err := util.WriteFileWithDirFS(fsys, filePath, userKubeletConfigBytes, kubeletConfigPerm); err != nil {
 			return err
 		}
 	}
@@ -511,41 +510,6 @@ func getNodeIp(ctx context.Context, imdsClient *imds.Client, cfg *api.NodeConfig
 	}
 }
 
-func getCPUMillicoresToReserve() int {
-	totalCPUMillicores, err := system.GetMilliNumCores()
-	if err != nil {
-		zap.L().Error("Error found when GetMilliNumCores", zap.Error(err))
-		return 0
-	}
-	cpuRanges := []int{0, 1000, 2000, 4000, totalCPUMillicores}
-	cpuPercentageReservedForRanges := []int{600, 100, 50, 25}
-	cpuToReserve := 0
-
-	for i, percentageToReserveForRange := range cpuPercentageReservedForRanges {
-		startRange := cpuRanges[i]
-		endRange := cpuRanges[i+1]
-		cpuToReserve += getResourceToReserveInRange(totalCPUMillicores, startRange, endRange, percentageToReserveForRange)
-	}
-
-	return cpuToReserve
-}
-
-// getResourceToReserveInRange calculates the CPU resources to reserve for a given range.
-func getResourceToReserveInRange(totalCPU, startRange, endRange, percentage int) int {
-	if totalCPU <= startRange {
-		return 0
-	}
-	reserved := totalCPU
-	if reserved > endRange {
-		reserved = endRange
-	}
-	return (reserved - startRange) * percentage / 10000
-}
-
-func getMemoryMebibytesToReserve(maxPods int32) int32 {
-	return 11*maxPods + 255
-}
-
 func getKubeletConfigFromDisk() (*kubeletConfig, error) {
 	data, err := os.ReadFile(filepath.Join(kubeletConfigRoot, kubeletConfigFile))
 	if err != nil {
@@ -11,6 +11,7 @@ import (
 	"github.com/aws/eks-hybrid/internal/api"
 	"github.com/aws/eks-hybrid/internal/daemon"
 	"github.com/aws/eks-hybrid/internal/kubernetes"
+	"github.com/aws/eks-hybrid/internal/util"
 	"github.com/aws/eks-hybrid/internal/validation"
 )
 
@@ -21,6 +22,24 @@ const (
 
 var _ daemon.Daemon = &kubelet{}
 
+// ValidationNames returns the names of the validations run by the kubelet daemon.
+func ValidationNames() []string {
+	return []string{kubernetesAuthenticationValidation}
+}
+
+// ConfigPaths returns the files and directories written when installing and
+// configuring the kubelet, other than its binary.
+func ConfigPaths() []string {
+	return []string{
+		UnitPath,
+		kubeletConfigRoot,
+		kubeletEnvironmentFilePath,
+		kubeconfigPath,
+		kubeconfigBootstrapPath,
+		imageCredentialProviderConfigPath,
+	}
+}
+
 type CredentialProviderAwsConfig struct {
 	Profile         string
 	CredentialsPath string
@@ -39,7 +58,7 @@ type kubelet struct {
 	logger                      *zap.Logger
 }
 
-func NewKubeletDaemon(daemonManager daemon.DaemonManager, cfg *api.NodeConfig, awsConfig *aws.Config, credentialProviderAwsConfig CredentialProviderAwsConfig, logger *zap.Logger, skipPhases []string) daemon.Daemon {
+func NewKubeletDaemon(daemonManager daemon.DaemonManager, cfg *api.NodeConfig, awsConfig *aws.Config, credentialProviderAwsConfig CredentialProviderAwsConfig, logger *zap.Logger, skipPhases []string, validationOpts ...validation.RunnerOpt) daemon.Daemon {
 	kubeletDaemon := &kubelet{
 		daemonManager:               daemonManager,
 		nodeConfig:                  cfg,
@@ -51,26 +70,31 @@ func NewKubeletDaemon(daemonManager daemon.DaemonManager, cfg *api.NodeConfig, a
 	}
 
 	if skipPhases != nil {
-		kubeletDaemon.validationRunner = validation.NewRunner[*api.NodeConfig](validation.NewLoggerPrinterWithLogger(logger), validation.WithSkipValidations(skipPhases...))
+		opts := []validation.RunnerOpt{
+			validation.WithSkipValidations(skipPhases...),
+			validation.WithDefaultTimeout(validation.DefaultTimeout),
+		}
+		kubeletDaemon.validationRunner = validation.NewRunner[*api.NodeConfig](validation.NewLoggerPrinterWithLogger(logger), append(opts, validationOpts...)...)
 	}
 
 	return kubeletDaemon
 }
 
 func (k *kubelet) Configure(ctx context.Context) error {
-	if err := k.writeKubeletConfig(); err != nil {
+	fsys := util.FileSystemFromContext(ctx)
+	if err := k.writeKubeletConfig(fsys); err != nil {
 		return err
 	}
-	if err := k.writeKubeconfig(); err != nil {
+	if err := k.writeKubeconfig(fsys); err != nil {
 		return err
 	}
-	if err := k.writeImageCredentialProviderConfig(); err != nil {
+	if err := k.writeImageCredentialProviderConfig(fsys); err != nil {
 		return err
 	}
-	if err := writeClusterCaCert(k.nodeConfig.Spec.Cluster.CertificateAuthority); err != nil {
+	if err := writeClusterCaCert(fsys, k.nodeConfig.Spec.Cluster.CertificateAuthority); err != nil {
 		return err
 	}
-	if err := k.writeKubeletEnvironment(); err != nil {
+	if err := k.writeKubeletEnvironment(fsys); err != nil {
 		return err
 	}
 
@@ -15,7 +15,7 @@ const (
 // Write environment variables needed for kubelet runtime. This should be the
 // last method called on the kubelet object so that environment side effects of
 // other methods are properly recorded
-func (k *kubelet) writeKubeletEnvironment() error {
+func (k *kubelet) writeKubeletEnvironment(fsys util.FileSystem) error {
 	// transform kubelet flags into a single string and write them to the
 	// kubelet environment variable
 	var kubeletFlags []string
@@ -31,7 +31,7 @@ func (k *kubelet) writeKubeletEnvironment() error {
 	for eKey, eValue := range k.environment {
 		kubeletEnvironment = append(kubeletEnvironment, fmt.Sprintf(`%s="%s"`, eKey, eValue))
 	}
-	return util.WriteFileWithDir(kubeletEnvironmentFilePath, []byte(strings.Join(kubeletEnvironment, "\n")), kubeletConfigPerm)
+	return util.WriteFileWithDirFS(fsys, kubeletEnvironmentFilePath, []byte(strings.Join(kubeletEnvironment, "\n")), kubeletConfigPerm)
 }
 
 // Add values to the environment variables map in a terse manner
@@ -0,0 +1,78 @@
+package kubelet
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/aws/eks-hybrid/internal/api"
+)
+
+// defaultNodeCIDRMaskSizes are the prefix lengths of the pod CIDR each CNI allocates to a
+// node by default: Cilium's clusterPoolIPv4MaskSize and Calico's IPAM blockSize.
+var defaultNodeCIDRMaskSizes = map[api.CNIType]int32{
+	api.CNITypeCilium: 24,
+	api.CNITypeCalico: 26,
+}
+
+// ValidatePodNetwork returns an error if spec.hybrid.podNetwork is invalid.
+func ValidatePodNetwork(podNetwork *api.PodNetworkOptions) error {
+	if podNetwork == nil {
+		return nil
+	}
+	if podNetwork.CNI == "" && podNetwork.NodeCIDRMaskSize == 0 {
+		return fmt.Errorf("podNetwork requires a cni or a nodeCIDRMaskSize")
+	}
+	if _, ok := defaultNodeCIDRMaskSizes[podNetwork.CNI]; podNetwork.CNI != "" && !ok {
+		return fmt.Errorf("invalid podNetwork cni %q, must be one of: cilium, calico", podNetwork.CNI)
+	}
+	if podNetwork.NodeCIDRMaskSize < 0 || podNetwork.NodeCIDRMaskSize > 30 {
+		return fmt.Errorf("invalid podNetwork nodeCIDRMaskSize %d, must be between 1 and 30, or 0 to leave it unset", podNetwork.NodeCIDRMaskSize)
+	}
+	return nil
+}
+
+// getHybridMaxPods returns the max pods of a hybrid node from the size of the pod CIDR
+// allocated to it, or 0 if it's unknown. The node's pod CIDR is set by the pod network
+// options, and it can't be larger than the cluster's largest remote pod network. Without
+// pod network options, the remote pod networks only set the max pods when they are too
+// small for the kubelet's default, since the node CIDR can be much smaller than them.
+func getHybridMaxPods(cfg *api.NodeConfig) (int32, error) {
+	hostBits := -1
+	if podNetwork := cfg.Spec.Hybrid.PodNetwork; podNetwork != nil {
+		maskSize := podNetwork.NodeCIDRMaskSize
+		if maskSize == 0 {
+			maskSize = defaultNodeCIDRMaskSizes[podNetwork.CNI]
+		}
+		hostBits = 32 - int(maskSize)
+	}
+
+	remoteHostBits := -1
+	for _, cidr := range cfg.Status.Hybrid.RemotePodNetworks {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return 0, fmt.Errorf("parsing remote pod network %s: %w", cidr, err)
+		}
+		ones, bits := network.Mask.Size()
+		remoteHostBits = max(remoteHostBits, bits-ones)
+	}
+
+	switch {
+	case hostBits >= 0 && remoteHostBits >= 0:
+		return podsInCIDR(min(hostBits, remoteHostBits)), nil
+	case hostBits >= 0:
+		return podsInCIDR(hostBits), nil
+	case remoteHostBits >= 0 && podsInCIDR(remoteHostBits) < defaultMaxPods:
+		return podsInCIDR(remoteHostBits), nil
+	default:
+		return 0, nil
+	}
+}
+
+// podsInCIDR returns the pods that fit in a CIDR with hostBits, without its network and
+// broadcast addresses, up to the kubelet's default max pods.
+func podsInCIDR(hostBits int) int32 {
+	if hostBits >= 8 {
+		return defaultMaxPods
+	}
+	return max(min(int32(1)<<hostBits-2, defaultMaxPods), 1)
+}
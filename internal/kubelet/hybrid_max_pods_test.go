@@ -0,0 +1,121 @@
+package kubelet
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aws/eks-hybrid/internal/api"
+)
+
+func TestGetHybridMaxPods(t *testing.T) {
+	tests := []struct {
+		name              string
+		podNetwork        *api.PodNetworkOptions
+		remotePodNetworks []string
+		expected          int32
+	}{
+		{
+			name:     "unknown pod CIDR",
+			expected: 0,
+		},
+		{
+			name:       "cilium default",
+			podNetwork: &api.PodNetworkOptions{CNI: api.CNITypeCilium},
+			expected:   110,
+		},
+		{
+			name:       "calico default",
+			podNetwork: &api.PodNetworkOptions{CNI: api.CNITypeCalico},
+			expected:   62,
+		},
+		{
+			name:       "node CIDR mask size",
+			podNetwork: &api.PodNetworkOptions{CNI: api.CNITypeCilium, NodeCIDRMaskSize: 27},
+			expected:   30,
+		},
+		{
+			name:       "smallest node CIDR",
+			podNetwork: &api.PodNetworkOptions{NodeCIDRMaskSize: 30},
+			expected:   2,
+		},
+		{
+			name:              "large remote pod network",
+			remotePodNetworks: []string{"10.2.0.0/16"},
+			expected:          0,
+		},
+		{
+			name:              "small remote pod network",
+			remotePodNetworks: []string{"10.2.0.0/28"},
+			expected:          14,
+		},
+		{
+			name:              "node CIDR larger than the remote pod networks",
+			podNetwork:        &api.PodNetworkOptions{CNI: api.CNITypeCilium},
+			remotePodNetworks: []string{"10.2.0.0/28", "10.3.0.0/27"},
+			expected:          30,
+		},
+		{
+			name:              "node CIDR smaller than the remote pod networks",
+			podNetwork:        &api.PodNetworkOptions{CNI: api.CNITypeCalico, NodeCIDRMaskSize: 28},
+			remotePodNetworks: []string{"10.2.0.0/16", "fd00::/56"},
+			expected:          14,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := &api.NodeConfig{
+				Spec: api.NodeConfigSpec{
+					Hybrid: &api.HybridOptions{PodNetwork: test.podNetwork},
+				},
+				Status: api.NodeConfigStatus{
+					Hybrid: api.HybridDetails{RemotePodNetworks: test.remotePodNetworks},
+				},
+			}
+			maxPods, err := getHybridMaxPods(cfg)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, maxPods)
+		})
+	}
+
+	cfg := &api.NodeConfig{
+		Spec:   api.NodeConfigSpec{Hybrid: &api.HybridOptions{}},
+		Status: api.NodeConfigStatus{Hybrid: api.HybridDetails{RemotePodNetworks: []string{"10.2.0.0"}}},
+	}
+	_, err := getHybridMaxPods(cfg)
+	assert.EqualError(t, err, "parsing remote pod network 10.2.0.0: invalid CIDR address: 10.2.0.0")
+}
+
+func TestValidatePodNetwork(t *testing.T) {
+	tests := []struct {
+		name       string
+		podNetwork *api.PodNetworkOptions
+		expected   string
+	}{
+		{
+			name:       "empty",
+			podNetwork: &api.PodNetworkOptions{},
+			expected:   "podNetwork requires a cni or a nodeCIDRMaskSize",
+		},
+		{
+			name:       "unknown CNI",
+			podNetwork: &api.PodNetworkOptions{CNI: "flannel"},
+			expected:   `invalid podNetwork cni "flannel", must be one of: cilium, calico`,
+		},
+		{
+			name:       "mask size too large",
+			podNetwork: &api.PodNetworkOptions{CNI: api.CNITypeCilium, NodeCIDRMaskSize: 31},
+			expected:   "invalid podNetwork nodeCIDRMaskSize 31, must be between 1 and 30, or 0 to leave it unset",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.EqualError(t, ValidatePodNetwork(test.podNetwork), test.expected)
+		})
+	}
+
+	assert.NoError(t, ValidatePodNetwork(nil))
+	assert.NoError(t, ValidatePodNetwork(&api.PodNetworkOptions{NodeCIDRMaskSize: 25}))
+}
@@ -29,7 +29,7 @@ const (
 
 var imageCredentialProviderConfigPath = path.Join(imageCredentialProviderRoot, imageCredentialProviderConfig)
 
-func (k *kubelet) writeImageCredentialProviderConfig() error {
+func (k *kubelet) writeImageCredentialProviderConfig(fsys util.FileSystem) error {
 	// fallback default for image credential provider binary if not overridden
 	ecrCredentialProviderBinPath := path.Join(imageCredentialProviderRoot, "ecr-credential-provider")
 	if binPath, set := os.LookupEnv(ecrCredentialProviderBinPathEnvironmentName); set {
@@ -48,7 +48,7 @@ func (k *kubelet) writeImageCredentialProviderConfig() error {
 	k.flags["image-credential-provider-bin-dir"] = path.Dir(ecrCredentialProviderBinPath)
 	k.flags["image-credential-provider-config"] = imageCredentialProviderConfigPath
 
-	return util.WriteFileWithDir(imageCredentialProviderConfigPath, credentialProviderConfig, imageCredentialProviderPerm)
+	return util.WriteFileWithDirFS(fsys, imageCredentialProviderConfigPath, credentialProviderConfig, imageCredentialProviderPerm)
 }
 
 func generateImageCredentialProviderConfig(cfg *api.NodeConfig, ecrCredentialProviderBinPath string, kubeletCredentialProviderAwsConfig CredentialProviderAwsConfig) ([]byte, error) {
@@ -33,7 +33,7 @@ var (
 	kubeconfigBootstrapPath      = path.Join(kubeconfigRoot, kubeconfigBootstrapFile)
 )
 
-func (k *kubelet) writeKubeconfig() error {
+func (k *kubelet) writeKubeconfig(fsys util.FileSystem) error {
 	kubeconfig, err := generateKubeconfig(k.nodeConfig)
 	if err != nil {
 		return err
@@ -43,10 +43,10 @@ func (k *kubelet) writeKubeconfig() error {
 		//   - if "aws eks describe-cluster" is bypassed, for local outpost, the value of CLUSTER_NAME parameter will be cluster id.
 		//   - otherwise, the cluster id will use the id returned by "aws eks describe-cluster".
 		k.flags["bootstrap-kubeconfig"] = kubeconfigBootstrapPath
-		return util.WriteFileWithDir(kubeconfigBootstrapPath, kubeconfig, kubeconfigPerm)
+		return util.WriteFileWithDirFS(fsys, kubeconfigBootstrapPath, kubeconfig, kubeconfigPerm)
 	} else {
 		k.flags["kubeconfig"] = kubeconfigPath
-		return util.WriteFileWithDir(kubeconfigPath, kubeconfig, kubeconfigPerm)
+		return util.WriteFileWithDirFS(fsys, kubeconfigPath, kubeconfig, kubeconfigPerm)
 	}
 }
 
@@ -0,0 +1,369 @@
+package kubelet
+
+import (
+	"fmt"
+	"maps"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/aws/eks-hybrid/internal/api"
+	"github.com/aws/eks-hybrid/internal/system"
+)
+
+const mebibyte = 1024 * 1024
+
+// percentRegex matches a percentage with up to two decimals.
+var percentRegex = regexp.MustCompile(`^([0-9]+)(?:\.([0-9]{1,2}))?$`)
+
+// defaultCPUTiers reserve 6% of the first core, 1% of the second, 0.5% of the next two
+// and 0.25% of the rest.
+var defaultCPUTiers = []reservationTier{
+	{upTo: 1000, basisPoints: 600},
+	{upTo: 2000, basisPoints: 100},
+	{upTo: 4000, basisPoints: 50},
+	{basisPoints: 25},
+}
+
+// reservationProfiles are the predefined policies of spec.kubelet.reservation.profile.
+var reservationProfiles = map[api.ReservationProfile]reservationProfile{
+	api.ReservationProfileDefault: {
+		kubeReserved: resourceReservation{
+			cpu:              defaultCPUTiers,
+			ephemeralStorage: "1Gi",
+		},
+		evictionHard: defaultEvictionHard(),
+	},
+	api.ReservationProfileMinimal: {
+		kubeReserved: resourceReservation{
+			cpu: defaultCPUTiers,
+			// half of the default hybrid memory tiers
+			memory: []reservationTier{
+				{upTo: 4 * 1024 * mebibyte, basisPoints: 1250},
+				{upTo: 8 * 1024 * mebibyte, basisPoints: 1000},
+				{upTo: 16 * 1024 * mebibyte, basisPoints: 500},
+				{upTo: 128 * 1024 * mebibyte, basisPoints: 300},
+				{basisPoints: 100},
+			},
+			ephemeralStorage: "1Gi",
+		},
+		evictionHard: defaultEvictionHard(),
+	},
+	api.ReservationProfileEdge: {
+		kubeReserved: resourceReservation{
+			cpu: []reservationTier{
+				{upTo: 1000, basisPoints: 300},
+				{upTo: 2000, basisPoints: 100},
+				{basisPoints: 25},
+			},
+			memory: []reservationTier{
+				{upTo: 2 * 1024 * mebibyte, basisPoints: 800},
+				{upTo: 8 * 1024 * mebibyte, basisPoints: 400},
+				{basisPoints: 100},
+			},
+			ephemeralStorage: "512Mi",
+		},
+		evictionHard: map[string]string{
+			"memory.available":  "50Mi",
+			"nodefs.available":  "5%",
+			"nodefs.inodesFree": "5%",
+		},
+	},
+}
+
+func defaultEvictionHard() map[string]string {
+	return map[string]string{
+		"memory.available":  "100Mi",
+		"nodefs.available":  "10%",
+		"nodefs.inodesFree": "5%",
+	}
+}
+
+type reservationProfile struct {
+	kubeReserved resourceReservation
+	evictionHard map[string]string
+}
+
+// resourceReservation is a parsed api.ResourceReservation. A kube reservation without
+// memory tiers reserves the default memory for the node.
+type resourceReservation struct {
+	cpu              []reservationTier
+	memory           []reservationTier
+	ephemeralStorage string
+}
+
+type reservationTier struct {
+	// upTo is the capacity the tier ends at, in millicores or bytes. Zero applies the
+	// tier to the rest of the capacity.
+	upTo int64
+	// basisPoints are the hundredths of a percent of the capacity reserved.
+	basisPoints int64
+}
+
+// nodeCapacity are the resources of the node reservations are computed from.
+type nodeCapacity struct {
+	cpuMillicores int64
+	memoryBytes   int64
+}
+
+// reservation are the kubelet reservation and eviction values computed from a policy.
+type reservation struct {
+	kubeReserved   map[string]string
+	systemReserved map[string]string
+	evictionHard   map[string]string
+}
+
+// ValidateReservation returns an error if spec.kubelet.reservation is invalid.
+func ValidateReservation(policy *api.ReservationPolicy) error {
+	_, _, err := parseReservationPolicy(policy)
+	return err
+}
+
+// newReservation computes the reservation of a node from the policy. defaultMemory is
+// reserved for the kubelet when neither the profile nor the policy set memory tiers.
+func newReservation(policy *api.ReservationPolicy, capacity nodeCapacity, defaultMemory string) (*reservation, error) {
+	profile, systemReserved, err := parseReservationPolicy(policy)
+	if err != nil {
+		return nil, err
+	}
+	kubeReserved := profile.kubeReserved.resources(capacity)
+	if profile.kubeReserved.memory == nil {
+		kubeReserved["memory"] = defaultMemory
+	}
+	reservation := &reservation{
+		kubeReserved: kubeReserved,
+		evictionHard: profile.evictionHard,
+	}
+	if resources := systemReserved.resources(capacity); len(resources) > 0 {
+		reservation.systemReserved = resources
+	}
+	return reservation, nil
+}
+
+// parseReservationPolicy returns the profile of the policy with its overrides, and the
+// system reservation.
+func parseReservationPolicy(policy *api.ReservationPolicy) (reservationProfile, resourceReservation, error) {
+	profileName := api.ReservationProfileDefault
+	if policy != nil && policy.Profile != "" {
+		profileName = policy.Profile
+	}
+	profile, ok := reservationProfiles[profileName]
+	if !ok {
+		return reservationProfile{}, resourceReservation{}, fmt.Errorf("invalid reservation profile %q, must be one of: default, minimal, edge", profileName)
+	}
+	profile.evictionHard = maps.Clone(profile.evictionHard)
+
+	var systemReserved resourceReservation
+	if policy == nil {
+		return profile, systemReserved, nil
+	}
+	if policy.KubeReserved != nil {
+		kubeReserved, err := profile.kubeReserved.override(*policy.KubeReserved)
+		if err != nil {
+			return reservationProfile{}, resourceReservation{}, fmt.Errorf("invalid kubeReserved: %w", err)
+		}
+		profile.kubeReserved = kubeReserved
+	}
+	if policy.SystemReserved != nil {
+		var err error
+		if systemReserved, err = systemReserved.override(*policy.SystemReserved); err != nil {
+			return reservationProfile{}, resourceReservation{}, fmt.Errorf("invalid systemReserved: %w", err)
+		}
+	}
+	for signal, threshold := range policy.EvictionHard {
+		if threshold == "" {
+			return reservationProfile{}, resourceReservation{}, fmt.Errorf("evictionHard threshold for %s is empty", signal)
+		}
+		profile.evictionHard[signal] = threshold
+	}
+	return profile, systemReserved, nil
+}
+
+// override returns a copy of r with the resources set in the API reservation replaced.
+func (r resourceReservation) override(reservation api.ResourceReservation) (resourceReservation, error) {
+	if len(reservation.CPU) > 0 {
+		tiers, err := parseReservationTiers(reservation.CPU, func(q resource.Quantity) int64 { return q.MilliValue() })
+		if err != nil {
+			return resourceReservation{}, fmt.Errorf("cpu: %w", err)
+		}
+		r.cpu = tiers
+	}
+	if len(reservation.Memory) > 0 {
+		tiers, err := parseReservationTiers(reservation.Memory, func(q resource.Quantity) int64 { return q.Value() })
+		if err != nil {
+			return resourceReservation{}, fmt.Errorf("memory: %w", err)
+		}
+		r.memory = tiers
+	}
+	if reservation.EphemeralStorage != "" {
+		if _, err := resource.ParseQuantity(reservation.EphemeralStorage); err != nil {
+			return resourceReservation{}, fmt.Errorf("ephemeralStorage %q: %w", reservation.EphemeralStorage, err)
+		}
+		r.ephemeralStorage = reservation.EphemeralStorage
+	}
+	return r, nil
+}
+
+// resources returns the resources reserved from the node capacity, keyed by resource name.
+func (r resourceReservation) resources(capacity nodeCapacity) map[string]string {
+	resources := map[string]string{}
+	if r.cpu != nil {
+		resources["cpu"] = fmt.Sprintf("%dm", reserveTiers(capacity.cpuMillicores, r.cpu))
+	}
+	if r.memory != nil {
+		resources["memory"] = fmt.Sprintf("%dMi", reserveTiers(capacity.memoryBytes, r.memory)/mebibyte)
+	}
+	if r.ephemeralStorage != "" {
+		resources["ephemeral-storage"] = r.ephemeralStorage
+	}
+	return resources
+}
+
+func parseReservationTiers(tiers []api.ReservationTier, value func(resource.Quantity) int64) ([]reservationTier, error) {
+	var parsed []reservationTier
+	var previous int64
+	for i, tier := range tiers {
+		basisPoints, err := parsePercent(tier.Percent)
+		if err != nil {
+			return nil, err
+		}
+		var upTo int64
+		if tier.UpTo == "" {
+			if i != len(tiers)-1 {
+				return nil, fmt.Errorf("only the last tier can omit upTo")
+			}
+		} else {
+			quantity, err := resource.ParseQuantity(tier.UpTo)
+			if err != nil {
+				return nil, fmt.Errorf("upTo %q: %w", tier.UpTo, err)
+			}
+			if upTo = value(quantity); upTo <= previous {
+				return nil, fmt.Errorf("upTo %s must be greater than the previous tier's", tier.UpTo)
+			}
+			previous = upTo
+		}
+		parsed = append(parsed, reservationTier{upTo: upTo, basisPoints: basisPoints})
+	}
+	return parsed, nil
+}
+
+// parsePercent returns the basis points of a percentage with up to two decimals.
+func parsePercent(percent string) (int64, error) {
+	matches := percentRegex.FindStringSubmatch(percent)
+	if matches == nil {
+		return 0, fmt.Errorf("invalid percent %q, must be a number with up to two decimals", percent)
+	}
+	whole, err := strconv.ParseInt(matches[1], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid percent %q: %w", percent, err)
+	}
+	var hundredths int64
+	if matches[2] != "" {
+		// "5" is 50 hundredths
+		if hundredths, err = strconv.ParseInt(matches[2]+strings.Repeat("0", 2-len(matches[2])), 10, 64); err != nil {
+			return 0, fmt.Errorf("invalid percent %q: %w", percent, err)
+		}
+	}
+	basisPoints := whole*100 + hundredths
+	if basisPoints > 10000 {
+		return 0, fmt.Errorf("invalid percent %q, must be at most 100", percent)
+	}
+	return basisPoints, nil
+}
+
+// reserveTiers returns the sum of the percentage of the capacity in each tier.
+func reserveTiers(capacity int64, tiers []reservationTier) int64 {
+	var reserved, start int64
+	for _, tier := range tiers {
+		if capacity <= start {
+			break
+		}
+		end := capacity
+		if tier.upTo > 0 && tier.upTo < capacity {
+			end = tier.upTo
+		}
+		reserved += (end - start) * tier.basisPoints / 10000
+		start = end
+	}
+	return reserved
+}
+
+// reservationNeedsMemory returns whether the memory capacity of the node is needed to
+// compute its reservation: hybrid nodes reserve memory for their capacity by default,
+// and memory tiers are percentages of the capacity.
+func reservationNeedsMemory(policy *api.ReservationPolicy, hybrid bool) (bool, error) {
+	profile, systemReserved, err := parseReservationPolicy(policy)
+	if err != nil {
+		return false, err
+	}
+	return hybrid || profile.kubeReserved.memory != nil || systemReserved.memory != nil, nil
+}
+
+// getNodeCapacity returns the CPU and memory of the node. If the CPU can't be read,
+// no CPU is reserved. The memory is only read when readMemory is set.
+func getNodeCapacity(readMemory bool) (nodeCapacity, error) {
+	totalCPUMillicores, err := system.GetMilliNumCores()
+	if err != nil {
+		zap.L().Error("Error found when GetMilliNumCores", zap.Error(err))
+	}
+	capacity := nodeCapacity{cpuMillicores: int64(totalCPUMillicores)}
+	if !readMemory {
+		return capacity, nil
+	}
+	totalMemory, err := system.GetMachineMemoryCapacity()
+	if err != nil {
+		return nodeCapacity{}, err
+	}
+	capacity.memoryBytes = int64(totalMemory)
+	return capacity, nil
+}
+
+// getHybridDefaultMemoryToReserve returns the memory reserved by default for the kubelet
+// on hybrid nodes. When the max pods of the node are known, the memory reserved for them
+// is used if it's lower than the memory reserved for the node's capacity.
+func getHybridDefaultMemoryToReserve(totalMemory int64, maxPods int32) string {
+	memory := getHybridMemoryToReserve(totalMemory)
+	if maxPods == 0 {
+		return memory
+	}
+	podsMemory := resource.MustParse(fmt.Sprintf("%dMi", getMemoryMebibytesToReserve(maxPods)))
+	if podsMemory.Cmp(resource.MustParse(memory)) < 0 {
+		return podsMemory.String()
+	}
+	return memory
+}
+
+// getHybridMemoryToReserve returns the memory reserved for the kubelet on hybrid nodes
+// from their capacity, according to the following table:
+// 255 MiB when total memory is < 1GiB
+// 25% of first 4GiB of total memory
+// 20% of next 4GiB of total memory
+// 10% of next 8 GiB of total memory
+// 6% of next 112 GiB of total memory
+// 2% of remaining total memory
+func getHybridMemoryToReserve(totalMemory int64) string {
+	// Convert bytes to GiB
+	totalMemoryGiB := totalMemory / (1024 * 1024 * 1024)
+	switch {
+	case totalMemoryGiB < 1:
+		return fmt.Sprintf("%dMi", 255)
+	case totalMemoryGiB < 4:
+		return fmt.Sprintf("%dGi", int(math.Round(float64(totalMemoryGiB)*0.25)))
+	case totalMemoryGiB < 8:
+		return fmt.Sprintf("%dGi", int(math.Round((0.25*4)+float64(totalMemoryGiB-4)*0.2)))
+	case totalMemoryGiB < 16:
+		return fmt.Sprintf("%dGi", int(math.Round((0.25*4)+(0.20*4)+float64(totalMemoryGiB-8)*0.1)))
+	case totalMemoryGiB <= 128:
+		return fmt.Sprintf("%dGi", int(math.Round((0.25*4)+(0.20*4)+(0.10*8)+float64(totalMemoryGiB-16)*0.06)))
+	default:
+		return fmt.Sprintf("%dGi", int(math.Round((0.25*4)+(0.20*4)+(0.10*8)+(0.06*112)+float64(totalMemoryGiB-128)*0.02)))
+	}
+}
+
+func getMemoryMebibytesToReserve(maxPods int32) int32 {
+	return 11*maxPods + 255
+}
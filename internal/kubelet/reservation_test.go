@@ -0,0 +1,223 @@
+package kubelet
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aws/eks-hybrid/internal/api"
+)
+
+const gibibyte = 1024 * mebibyte
+
+func TestNewReservation(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   *api.ReservationPolicy
+		capacity nodeCapacity
+		expected *reservation
+	}{
+		{
+			name:     "no policy",
+			capacity: nodeCapacity{cpuMillicores: 8000, memoryBytes: 16 * gibibyte},
+			expected: &reservation{
+				kubeReserved: map[string]string{
+					"cpu":               "90m",
+					"ephemeral-storage": "1Gi",
+					"memory":            "3Gi",
+				},
+				evictionHard: map[string]string{
+					"memory.available":  "100Mi",
+					"nodefs.available":  "10%",
+					"nodefs.inodesFree": "5%",
+				},
+			},
+		},
+		{
+			name:     "minimal profile",
+			policy:   &api.ReservationPolicy{Profile: api.ReservationProfileMinimal},
+			capacity: nodeCapacity{cpuMillicores: 4000, memoryBytes: 16 * gibibyte},
+			expected: &reservation{
+				kubeReserved: map[string]string{
+					"cpu":               "80m",
+					"ephemeral-storage": "1Gi",
+					"memory":            "1331Mi",
+				},
+				evictionHard: map[string]string{
+					"memory.available":  "100Mi",
+					"nodefs.available":  "10%",
+					"nodefs.inodesFree": "5%",
+				},
+			},
+		},
+		{
+			name:     "edge profile",
+			policy:   &api.ReservationPolicy{Profile: api.ReservationProfileEdge},
+			capacity: nodeCapacity{cpuMillicores: 2000, memoryBytes: 4 * gibibyte},
+			expected: &reservation{
+				kubeReserved: map[string]string{
+					"cpu":               "40m",
+					"ephemeral-storage": "512Mi",
+					"memory":            "245Mi",
+				},
+				evictionHard: map[string]string{
+					"memory.available":  "50Mi",
+					"nodefs.available":  "5%",
+					"nodefs.inodesFree": "5%",
+				},
+			},
+		},
+		{
+			name: "overrides",
+			policy: &api.ReservationPolicy{
+				KubeReserved: &api.ResourceReservation{
+					Memory: []api.ReservationTier{{UpTo: "4Gi", Percent: "10"}, {Percent: "5"}},
+				},
+				SystemReserved: &api.ResourceReservation{
+					CPU:              []api.ReservationTier{{Percent: "2.5"}},
+					Memory:           []api.ReservationTier{{Percent: "1"}},
+					EphemeralStorage: "2Gi",
+				},
+				EvictionHard: map[string]string{"memory.available": "200Mi"},
+			},
+			capacity: nodeCapacity{cpuMillicores: 2000, memoryBytes: 8 * gibibyte},
+			expected: &reservation{
+				kubeReserved: map[string]string{
+					"cpu":               "70m",
+					"ephemeral-storage": "1Gi",
+					"memory":            "614Mi",
+				},
+				systemReserved: map[string]string{
+					"cpu":               "50m",
+					"ephemeral-storage": "2Gi",
+					"memory":            "81Mi",
+				},
+				evictionHard: map[string]string{
+					"memory.available":  "200Mi",
+					"nodefs.available":  "10%",
+					"nodefs.inodesFree": "5%",
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			reservation, err := newReservation(test.policy, test.capacity, "3Gi")
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, reservation)
+		})
+	}
+
+	// overrides must not modify the profiles
+	assert.Equal(t, defaultEvictionHard(), reservationProfiles[api.ReservationProfileDefault].evictionHard)
+}
+
+func TestDefaultCPUReservation(t *testing.T) {
+	// the default tiers reserve the same CPU as the previous hard-coded formula
+	expected := map[int64]int64{0: 0, 500: 30, 1000: 60, 2000: 70, 4000: 80, 8000: 90, 96000: 310}
+	for cpuMillicores, reserved := range expected {
+		assert.Equal(t, reserved, reserveTiers(cpuMillicores, defaultCPUTiers), "cpu %dm", cpuMillicores)
+	}
+}
+
+func TestHybridDefaultMemoryReservation(t *testing.T) {
+	// without max pods, the memory is reserved for the node's capacity
+	assert.Equal(t, "3Gi", getHybridDefaultMemoryToReserve(16*gibibyte, 0))
+	assert.Equal(t, "1465Mi", getHybridDefaultMemoryToReserve(16*gibibyte, 110))
+	assert.Equal(t, "1Gi", getHybridDefaultMemoryToReserve(2*gibibyte, 110))
+	assert.Equal(t, "585Mi", getHybridDefaultMemoryToReserve(2*gibibyte, 30))
+}
+
+func TestReservationNeedsMemory(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   *api.ReservationPolicy
+		hybrid   bool
+		expected bool
+	}{
+		{name: "ec2 default", expected: false},
+		{name: "hybrid default", hybrid: true, expected: true},
+		{
+			name:     "ec2 cpu override",
+			policy:   &api.ReservationPolicy{KubeReserved: &api.ResourceReservation{CPU: []api.ReservationTier{{Percent: "1"}}}},
+			expected: false,
+		},
+		{name: "ec2 minimal profile", policy: &api.ReservationPolicy{Profile: api.ReservationProfileMinimal}, expected: true},
+		{
+			name:     "ec2 system memory",
+			policy:   &api.ReservationPolicy{SystemReserved: &api.ResourceReservation{Memory: []api.ReservationTier{{Percent: "1"}}}},
+			expected: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			needsMemory, err := reservationNeedsMemory(test.policy, test.hybrid)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, needsMemory)
+		})
+	}
+}
+
+func TestValidateReservation(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   *api.ReservationPolicy
+		expected string
+	}{
+		{
+			name:     "unknown profile",
+			policy:   &api.ReservationPolicy{Profile: "large"},
+			expected: `invalid reservation profile "large", must be one of: default, minimal, edge`,
+		},
+		{
+			name: "invalid percent",
+			policy: &api.ReservationPolicy{KubeReserved: &api.ResourceReservation{
+				CPU: []api.ReservationTier{{Percent: "1.005"}},
+			}},
+			expected: `invalid kubeReserved: cpu: invalid percent "1.005", must be a number with up to two decimals`,
+		},
+		{
+			name: "percent over 100",
+			policy: &api.ReservationPolicy{SystemReserved: &api.ResourceReservation{
+				Memory: []api.ReservationTier{{Percent: "100.5"}},
+			}},
+			expected: `invalid systemReserved: memory: invalid percent "100.5", must be at most 100`,
+		},
+		{
+			name: "upTo omitted before the last tier",
+			policy: &api.ReservationPolicy{KubeReserved: &api.ResourceReservation{
+				Memory: []api.ReservationTier{{Percent: "10"}, {Percent: "5"}},
+			}},
+			expected: "invalid kubeReserved: memory: only the last tier can omit upTo",
+		},
+		{
+			name: "decreasing upTo",
+			policy: &api.ReservationPolicy{KubeReserved: &api.ResourceReservation{
+				CPU: []api.ReservationTier{{UpTo: "2", Percent: "1"}, {UpTo: "1500m", Percent: "1"}},
+			}},
+			expected: "invalid kubeReserved: cpu: upTo 1500m must be greater than the previous tier's",
+		},
+		{
+			name: "invalid ephemeral storage",
+			policy: &api.ReservationPolicy{SystemReserved: &api.ResourceReservation{
+				EphemeralStorage: "lots",
+			}},
+			expected: `invalid systemReserved: ephemeralStorage "lots": quantities must match the regular expression '^([+-]?[0-9.]+)([eEinumkKMGTP]*[-+]?[0-9]*)$'`,
+		},
+		{
+			name:     "empty eviction threshold",
+			policy:   &api.ReservationPolicy{EvictionHard: map[string]string{"memory.available": ""}},
+			expected: "evictionHard threshold for memory.available is empty",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.EqualError(t, ValidateReservation(test.policy), test.expected)
+		})
+	}
+
+	assert.NoError(t, ValidateReservation(nil))
+}
@@ -0,0 +1,138 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const redactedValue = "[REDACTED]"
+
+var secrets = &secretRegistry{}
+
+type secretRegistry struct {
+	mu       sync.RWMutex
+	values   []string
+	replacer *strings.Replacer
+}
+
+// RegisterSecret marks value as sensitive. Registered values are replaced in every
+// entry written by a logger built with RedactSecrets.
+func RegisterSecret(value string) {
+	if value == "" {
+		return
+	}
+	secrets.mu.Lock()
+	defer secrets.mu.Unlock()
+	for _, existing := range secrets.values {
+		if existing == value {
+			return
+		}
+	}
+	secrets.values = append(secrets.values, value)
+	// Replace longer secrets first in case one secret contains another.
+	sort.Slice(secrets.values, func(i, j int) bool {
+		return len(secrets.values[i]) > len(secrets.values[j])
+	})
+	oldnew := make([]string, 0, 2*len(secrets.values))
+	for _, secret := range secrets.values {
+		oldnew = append(oldnew, secret, redactedValue)
+	}
+	secrets.replacer = strings.NewReplacer(oldnew...)
+}
+
+// Redact replaces every registered secret in s.
+func Redact(s string) string {
+	secrets.mu.RLock()
+	defer secrets.mu.RUnlock()
+	if secrets.replacer == nil {
+		return s
+	}
+	return secrets.replacer.Replace(s)
+}
+
+// RedactSecrets returns a zap option that removes registered secrets from
+// log messages and fields.
+func RedactSecrets() zap.Option {
+	return zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return &redactingCore{Core: core}
+	})
+}
+
+type redactingCore struct {
+	zapcore.Core
+}
+
+func (c *redactingCore) With(fields []zapcore.Field) zapcore.Core {
+	return &redactingCore{Core: c.Core.With(redactFields(fields))}
+}
+
+func (c *redactingCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	// The wrapped core, like a sampler, decides if the entry is written. It's then
+	// written through this core, so it's redacted.
+	if c.Core.Check(entry, nil) != nil {
+		return checked.AddCore(entry, c)
+	}
+	return checked
+}
+
+func (c *redactingCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	entry.Message = Redact(entry.Message)
+	return c.Core.Write(entry, redactFields(fields))
+}
+
+func redactFields(fields []zapcore.Field) []zapcore.Field {
+	secrets.mu.RLock()
+	empty := secrets.replacer == nil
+	secrets.mu.RUnlock()
+	if empty {
+		return fields
+	}
+
+	redacted := make([]zapcore.Field, 0, len(fields))
+	for _, field := range fields {
+		redacted = append(redacted, redactField(field))
+	}
+	return redacted
+}
+
+func redactField(field zapcore.Field) zapcore.Field {
+	switch field.Type {
+	case zapcore.StringType:
+		field.String = Redact(field.String)
+		return field
+	case zapcore.ErrorType:
+		if err, ok := field.Interface.(error); ok && err != nil {
+			if message := err.Error(); Redact(message) != message {
+				return zap.String(field.Key, Redact(message))
+			}
+		}
+		return field
+	case zapcore.StringerType:
+		if stringer, ok := field.Interface.(fmt.Stringer); ok && stringer != nil {
+			if value := stringer.String(); Redact(value) != value {
+				return zap.String(field.Key, Redact(value))
+			}
+		}
+		return field
+	case zapcore.ReflectType, zapcore.ObjectMarshalerType, zapcore.ArrayMarshalerType:
+		// Encode complex fields to check if any secret is present in their serialized form.
+		encoder := zapcore.NewMapObjectEncoder()
+		field.AddTo(encoder)
+		encoded, err := json.Marshal(encoder.Fields[field.Key])
+		if err != nil {
+			return field
+		}
+		if value := string(encoded); Redact(value) != value {
+			return zap.String(field.Key, Redact(value))
+		}
+		return field
+	default:
+		return field
+	}
+}
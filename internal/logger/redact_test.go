@@ -0,0 +1,55 @@
+package logger_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/aws/eks-hybrid/internal/logger"
+)
+
+func TestRedactSecrets(t *testing.T) {
+	g := NewWithT(t)
+	core, logs := observer.New(zapcore.InfoLevel)
+	log := zap.New(core, logger.RedactSecrets())
+
+	logger.RegisterSecret("super-secret-value")
+	logger.RegisterSecret("")
+
+	log.Info("registering with code super-secret-value",
+		zap.String("code", "super-secret-value"),
+		zap.Error(errors.New("running command [-code super-secret-value]")),
+		zap.Strings("args", []string{"-code", "super-secret-value"}),
+		zap.String("region", "us-west-2"),
+	)
+
+	g.Expect(logs.Len()).To(Equal(1))
+	entry := logs.All()[0]
+	g.Expect(entry.Message).To(Equal("registering with code [REDACTED]"))
+	fields := entry.ContextMap()
+	g.Expect(fields["code"]).To(Equal("[REDACTED]"))
+	g.Expect(fields["error"]).To(Equal("running command [-code [REDACTED]]"))
+	g.Expect(fields["args"]).To(Equal(`["-code","[REDACTED]"]`))
+	g.Expect(fields["region"]).To(Equal("us-west-2"))
+}
+
+func TestRedactSecretsWithSampler(t *testing.T) {
+	g := NewWithT(t)
+	core, logs := observer.New(zapcore.InfoLevel)
+	sampled := zapcore.NewSamplerWithOptions(core, time.Minute, 1, 0)
+	log := zap.New(sampled, logger.RedactSecrets())
+
+	logger.RegisterSecret("sampled-secret-value")
+	for range 3 {
+		log.Info("using sampled-secret-value")
+	}
+	log.Debug("debug sampled-secret-value")
+
+	g.Expect(logs.Len()).To(Equal(1))
+	g.Expect(logs.All()[0].Message).To(Equal("using [REDACTED]"))
+}
@@ -0,0 +1,96 @@
+package mirror
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DirDestination stores artifacts in a local directory tree, for example a USB drive
+// or an NFS share, that nodes read from baseURI.
+type DirDestination struct {
+	root    string
+	baseURI string
+}
+
+// NewDirDestination returns a destination that stores artifacts under root. If baseURI
+// is empty, nodes read them from the file:// URI of root.
+func NewDirDestination(root, baseURI string) (*DirDestination, error) {
+	root, err := filepath.Abs(root)
+	if err != nil {
+		return nil, err
+	}
+	if baseURI == "" {
+		baseURI = "file://" + root
+	}
+	return &DirDestination{root: root, baseURI: strings.TrimSuffix(baseURI, "/")}, nil
+}
+
+func (d *DirDestination) Has(_ context.Context, path string, digest []byte) (bool, error) {
+	filePath, err := d.filePath(path)
+	if err != nil {
+		return false, err
+	}
+	fh, err := os.Open(filePath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	defer fh.Close()
+
+	actual := sha256.New()
+	if _, err := io.Copy(actual, fh); err != nil {
+		return false, fmt.Errorf("calculating sha256 for %s: %w", fh.Name(), err)
+	}
+	return bytes.Equal(actual.Sum(nil), digest), nil
+}
+
+// Put writes content to a temporary file next to path and renames it, so readers never
+// see a partial artifact.
+func (d *DirDestination) Put(_ context.Context, path string, content io.ReadSeeker, _ int64, _ []byte) error {
+	dst, err := d.filePath(path)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(dst), filepath.Base(dst)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = io.Copy(tmp, content)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("writing %s: %w", dst, err)
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), dst)
+}
+
+func (d *DirDestination) URI(path string, _ []byte) string {
+	return d.baseURI + "/" + path
+}
+
+func (d *DirDestination) filePath(path string) (string, error) {
+	local := filepath.FromSlash(path)
+	if !filepath.IsLocal(local) {
+		return "", fmt.Errorf("invalid artifact path %s outside the destination directory", path)
+	}
+	return filepath.Join(d.root, local), nil
+}
@@ -0,0 +1,139 @@
+package mirror
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/aws/eks-hybrid/internal/artifact"
+)
+
+// HTTPDestination stores artifacts with PUT requests to an HTTP server, like a
+// WebDAV share or a generic artifact repository. Missing WebDAV collections are
+// created with MKCOL.
+//
+// The server can't report the checksum of its files, so an artifact is considered
+// stored if the checksum file Syncer stores next to it matches.
+type HTTPDestination struct {
+	base    *url.URL
+	baseURI string
+	fetcher *artifact.HTTPFetcher
+}
+
+// NewHTTPDestination returns a destination that uploads artifacts under baseURL,
+// with the client, user agent and credentials set by opts. The credentials are only
+// sent to the host of baseURL. If baseURI is empty, nodes read the artifacts from
+// baseURL.
+func NewHTTPDestination(baseURL, baseURI string, opts ...artifact.HTTPFetcherOpt) (*HTTPDestination, error) {
+	base, err := url.Parse(strings.TrimSuffix(baseURL, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("parsing destination url %s: %w", baseURL, err)
+	}
+	if (base.Scheme != "http" && base.Scheme != "https") || base.Host == "" {
+		return nil, fmt.Errorf("invalid destination url %s, expected http(s)://<host>[/<path>]", baseURL)
+	}
+	if baseURI == "" {
+		public := *base
+		public.User = nil
+		baseURI = public.String()
+	}
+	return &HTTPDestination{
+		base:    base,
+		baseURI: strings.TrimSuffix(baseURI, "/"),
+		fetcher: artifact.NewHTTPFetcher(append(opts, artifact.WithAuthHosts(base.Host))...),
+	}, nil
+}
+
+func (d *HTTPDestination) Has(ctx context.Context, path string, digest []byte) (bool, error) {
+	resp, err := d.do(ctx, http.MethodGet, d.url(path+ChecksumSuffix), nil, 0)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, fmt.Errorf("unexpected status code %d reading %s", resp.StatusCode, d.url(path+ChecksumSuffix))
+	}
+	gnuChecksum, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return false, err
+	}
+	stored, err := artifact.ParseGNUChecksum(gnuChecksum)
+	if err != nil {
+		// A corrupted checksum file is replaced with the artifact.
+		return false, nil
+	}
+	return bytes.Equal(stored, digest), nil
+}
+
+func (d *HTTPDestination) Put(ctx context.Context, path string, content io.ReadSeeker, size int64, _ []byte) error {
+	target := d.url(path)
+	resp, err := d.do(ctx, http.MethodPut, target, content, size)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode == http.StatusConflict {
+		// WebDAV servers reject uploads to collections that don't exist.
+		if err := d.createCollections(ctx, path); err != nil {
+			return err
+		}
+		if _, err := content.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+		if resp, err = d.do(ctx, http.MethodPut, target, content, size); err != nil {
+			return err
+		}
+		resp.Body.Close()
+	}
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusCreated, http.StatusNoContent:
+		return nil
+	default:
+		return fmt.Errorf("unexpected status code %d uploading %s", resp.StatusCode, target)
+	}
+}
+
+// createCollections creates the parent collections of path, from the top.
+func (d *HTTPDestination) createCollections(ctx context.Context, path string) error {
+	parts := strings.Split(path, "/")
+	for i := 1; i < len(parts); i++ {
+		collection := d.url(strings.Join(parts[:i], "/")) + "/"
+		resp, err := d.do(ctx, "MKCOL", collection, nil, 0)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		// 405 Method Not Allowed means the collection already exists.
+		if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusMethodNotAllowed {
+			return fmt.Errorf("unexpected status code %d creating collection %s", resp.StatusCode, collection)
+		}
+	}
+	return nil
+}
+
+func (d *HTTPDestination) URI(path string, _ []byte) string {
+	return d.baseURI + "/" + path
+}
+
+func (d *HTTPDestination) url(path string) string {
+	return d.base.String() + "/" + path
+}
+
+func (d *HTTPDestination) do(ctx context.Context, method, target string, body io.Reader, size int64) (*http.Response, error) {
+	request, err := http.NewRequestWithContext(ctx, method, target, body)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		request.ContentLength = size
+	}
+	return d.fetcher.Do(request)
+}
@@ -0,0 +1,94 @@
+package mirror_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-hybrid/internal/artifact"
+	"github.com/aws/eks-hybrid/internal/mirror"
+)
+
+// newWebDAVServer returns a server that stores files in memory and, like WebDAV,
+// rejects uploads to collections that don't exist.
+func newWebDAVServer(t *testing.T) (*httptest.Server, map[string][]byte) {
+	var lock sync.Mutex
+	files := map[string][]byte{}
+	collections := map[string]bool{"/": true, "/mirror/": true}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+		if r.Header.Get("Authorization") != "Bearer mirror-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		parent := r.URL.Path[:strings.LastIndex(strings.TrimSuffix(r.URL.Path, "/"), "/")+1]
+		switch r.Method {
+		case "MKCOL":
+			if collections[r.URL.Path] {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				return
+			}
+			if !collections[parent] {
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+			collections[r.URL.Path] = true
+			w.WriteHeader(http.StatusCreated)
+		case http.MethodPut:
+			if !collections[parent] {
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+			files[r.URL.Path], _ = io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusCreated)
+		case http.MethodGet:
+			data, ok := files[r.URL.Path]
+			if !ok {
+				http.NotFound(w, r)
+				return
+			}
+			w.Write(data)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server, files
+}
+
+func TestHTTPDestination(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	server, files := newWebDAVServer(t)
+	destination, err := mirror.NewHTTPDestination(server.URL+"/mirror/", "", artifact.WithBearerToken("mirror-token"))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	data := []byte("kubelet binary")
+	digest := sha256.Sum256(data)
+	has, err := destination.Has(ctx, "eks/1.31/kubelet", digest[:])
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(has).To(BeFalse())
+
+	g.Expect(destination.Put(ctx, "eks/1.31/kubelet", bytes.NewReader(data), int64(len(data)), digest[:])).To(Succeed())
+	g.Expect(files).To(HaveKeyWithValue("/mirror/eks/1.31/kubelet", data))
+	g.Expect(destination.URI("eks/1.31/kubelet", digest[:])).To(Equal(server.URL + "/mirror/eks/1.31/kubelet"))
+
+	checksum := []byte(hexChecksum(data) + "  kubelet\n")
+	g.Expect(destination.Put(ctx, "eks/1.31/kubelet"+mirror.ChecksumSuffix, bytes.NewReader(checksum), int64(len(checksum)), nil)).To(Succeed())
+	has, err = destination.Has(ctx, "eks/1.31/kubelet", digest[:])
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(has).To(BeTrue())
+}
+
+func TestHTTPDestinationInvalidURL(t *testing.T) {
+	g := NewWithT(t)
+	_, err := mirror.NewHTTPDestination("ftp://mirror.example.com", "")
+	g.Expect(err).To(MatchError(ContainSubstring("invalid destination url")))
+}
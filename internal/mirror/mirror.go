@@ -0,0 +1,141 @@
+package mirror
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"os"
+
+	"go.uber.org/zap"
+
+	"github.com/aws/eks-hybrid/internal/artifact"
+)
+
+// ChecksumSuffix is appended to the path of an artifact to store its checksum file.
+const ChecksumSuffix = ".sha256"
+
+// Destination stores synced artifacts for nodes to install from.
+type Destination interface {
+	// Has returns true if the destination already stores the content with the
+	// sha256 digest at path.
+	Has(ctx context.Context, path string, digest []byte) (bool, error)
+	// Put stores content, of size bytes and with the sha256 digest, at path.
+	Put(ctx context.Context, path string, content io.ReadSeeker, size int64, digest []byte) error
+	// URI returns the URI nodes read the content stored at path with digest from.
+	URI(path string, digest []byte) string
+}
+
+// Artifact is an artifact to sync to a Destination.
+type Artifact struct {
+	Name string
+	// URI and ChecksumURI are read with the Syncer's fetchers. ChecksumURI is the
+	// GNU checksum file of the artifact and is optional.
+	URI         string
+	ChecksumURI string
+	// Path is where the artifact is stored in the destination. Its checksum file
+	// is stored at Path + ChecksumSuffix.
+	Path string
+}
+
+// Synced is an artifact stored in a Destination.
+type Synced struct {
+	URI string
+	// ChecksumURI is empty if the artifact has no checksum.
+	ChecksumURI string
+	// Skipped is true if the artifact was already in the destination.
+	Skipped bool
+}
+
+// Syncer copies artifacts to a Destination, verifying their checksums.
+type Syncer struct {
+	Destination Destination
+	Fetchers    artifact.Fetchers
+	// Incremental skips artifacts the destination already has with the same checksum.
+	Incremental bool
+	Logger      *zap.Logger
+}
+
+// Sync copies a to the destination with its checksum file and returns the URIs
+// to read them from.
+func (s *Syncer) Sync(ctx context.Context, a Artifact) (Synced, error) {
+	var gnuChecksum, expected []byte
+	if a.ChecksumURI != "" {
+		var err error
+		if gnuChecksum, err = s.Fetchers.Read(ctx, a.ChecksumURI); err != nil {
+			return Synced{}, fmt.Errorf("reading %s checksum: %w", a.Name, err)
+		}
+		if expected, err = artifact.ParseGNUChecksum(gnuChecksum); err != nil {
+			return Synced{}, fmt.Errorf("parsing %s checksum: %w", a.Name, err)
+		}
+	}
+
+	skip := false
+	if s.Incremental && expected != nil {
+		has, err := s.Destination.Has(ctx, a.Path, expected)
+		if err != nil {
+			return Synced{}, fmt.Errorf("checking %s in destination: %w", a.Name, err)
+		}
+		skip = has
+	}
+
+	digest := expected
+	if skip {
+		s.Logger.Info("Artifact already synced, skipping", zap.String("name", a.Name), zap.String("path", a.Path))
+	} else {
+		s.Logger.Info("Syncing artifact", zap.String("name", a.Name), zap.String("uri", a.URI))
+		var err error
+		if digest, err = s.copy(ctx, a, expected); err != nil {
+			return Synced{}, err
+		}
+	}
+
+	synced := Synced{URI: s.Destination.URI(a.Path, digest), Skipped: skip}
+	if gnuChecksum != nil {
+		checksumPath := a.Path + ChecksumSuffix
+		checksumDigest := sha256.Sum256(gnuChecksum)
+		// The checksum file is stored after the artifact, so a skipped artifact already has it.
+		if !skip {
+			if err := s.Destination.Put(ctx, checksumPath, bytes.NewReader(gnuChecksum), int64(len(gnuChecksum)), checksumDigest[:]); err != nil {
+				return Synced{}, fmt.Errorf("storing %s checksum: %w", a.Name, err)
+			}
+		}
+		synced.ChecksumURI = s.Destination.URI(checksumPath, checksumDigest[:])
+	}
+	return synced, nil
+}
+
+// copy downloads the artifact to a temporary file, verifying it against the expected
+// checksum if there is one, and stores it in the destination. It returns its digest.
+func (s *Syncer) copy(ctx context.Context, a Artifact, expected []byte) ([]byte, error) {
+	body, err := s.Fetchers.Open(ctx, a.URI)
+	if err != nil {
+		return nil, fmt.Errorf("downloading %s: %w", a.Name, err)
+	}
+	defer body.Close()
+
+	tmp, err := os.CreateTemp("", "nodeadm-sync-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	digest := sha256.New()
+	size, err := io.Copy(io.MultiWriter(tmp, digest), body)
+	if err != nil {
+		return nil, fmt.Errorf("downloading %s: %w", a.Name, err)
+	}
+	actual := digest.Sum(nil)
+	if expected != nil && !bytes.Equal(actual, expected) {
+		return nil, fmt.Errorf("%s checksum %x does not match expected %x", a.Name, actual, expected)
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	if err := s.Destination.Put(ctx, a.Path, tmp, size, actual); err != nil {
+		return nil, fmt.Errorf("storing %s: %w", a.Name, err)
+	}
+	return actual, nil
+}
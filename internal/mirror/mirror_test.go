@@ -0,0 +1,131 @@
+package mirror_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+
+	"github.com/aws/eks-hybrid/internal/artifact"
+	"github.com/aws/eks-hybrid/internal/mirror"
+)
+
+func hexChecksum(data []byte) string {
+	checksum := sha256.Sum256(data)
+	return hex.EncodeToString(checksum[:])
+}
+
+// writeRelease writes an artifact and its GNU checksum file to dir and returns the
+// artifact to sync them.
+func writeRelease(t *testing.T, dir, name string, data []byte) mirror.Artifact {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path+".sha256", []byte(hexChecksum(data)+"  "+name+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return mirror.Artifact{
+		Name:        name,
+		URI:         "file://" + path,
+		ChecksumURI: "file://" + path + ".sha256",
+		Path:        "eks/" + name,
+	}
+}
+
+func TestSyncerDirDestination(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	release := t.TempDir()
+	root := t.TempDir()
+	kubelet := writeRelease(t, release, "kubelet", []byte("kubelet binary"))
+
+	destination, err := mirror.NewDirDestination(root, "https://mirror.example.com/eks")
+	g.Expect(err).NotTo(HaveOccurred())
+	syncer := &mirror.Syncer{
+		Destination: destination,
+		Fetchers:    artifact.DefaultFetchers(),
+		Incremental: true,
+		Logger:      zap.NewNop(),
+	}
+
+	synced, err := syncer.Sync(ctx, kubelet)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(synced).To(Equal(mirror.Synced{
+		URI:         "https://mirror.example.com/eks/eks/kubelet",
+		ChecksumURI: "https://mirror.example.com/eks/eks/kubelet.sha256",
+	}))
+	g.Expect(os.ReadFile(filepath.Join(root, "eks", "kubelet"))).To(Equal([]byte("kubelet binary")))
+	g.Expect(os.ReadFile(filepath.Join(root, "eks", "kubelet.sha256"))).To(ContainSubstring(hexChecksum([]byte("kubelet binary"))))
+
+	// The checksum file of a skipped artifact isn't stored again.
+	checksumFile := filepath.Join(root, "eks", "kubelet.sha256")
+	syncedAt := time.Now().Add(-time.Hour).Truncate(time.Second)
+	g.Expect(os.Chtimes(checksumFile, syncedAt, syncedAt)).To(Succeed())
+	synced, err = syncer.Sync(ctx, kubelet)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(synced.Skipped).To(BeTrue())
+	g.Expect(synced.ChecksumURI).To(Equal("https://mirror.example.com/eks/eks/kubelet.sha256"))
+	info, err := os.Stat(checksumFile)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(info.ModTime()).To(Equal(syncedAt))
+
+	// A new release of the artifact is synced again.
+	kubelet = writeRelease(t, release, "kubelet", []byte("new kubelet binary"))
+	synced, err = syncer.Sync(ctx, kubelet)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(synced.Skipped).To(BeFalse())
+	g.Expect(os.ReadFile(filepath.Join(root, "eks", "kubelet"))).To(Equal([]byte("new kubelet binary")))
+}
+
+func TestSyncerWithoutChecksum(t *testing.T) {
+	g := NewWithT(t)
+	release := t.TempDir()
+	root := t.TempDir()
+	g.Expect(os.WriteFile(filepath.Join(release, "ssm-setup-cli"), []byte("installer"), 0o644)).To(Succeed())
+
+	destination, err := mirror.NewDirDestination(root, "")
+	g.Expect(err).NotTo(HaveOccurred())
+	syncer := &mirror.Syncer{Destination: destination, Fetchers: artifact.DefaultFetchers(), Incremental: true, Logger: zap.NewNop()}
+
+	synced, err := syncer.Sync(context.Background(), mirror.Artifact{
+		Name: "ssm-setup-cli",
+		URI:  "file://" + filepath.Join(release, "ssm-setup-cli"),
+		Path: "ssm/ssm-setup-cli",
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(synced).To(Equal(mirror.Synced{URI: "file://" + root + "/ssm/ssm-setup-cli"}))
+	g.Expect(os.ReadFile(filepath.Join(root, "ssm", "ssm-setup-cli"))).To(Equal([]byte("installer")))
+}
+
+func TestSyncerChecksumMismatch(t *testing.T) {
+	g := NewWithT(t)
+	release := t.TempDir()
+	kubelet := writeRelease(t, release, "kubelet", []byte("kubelet binary"))
+	g.Expect(os.WriteFile(filepath.Join(release, "kubelet"), []byte("tampered"), 0o644)).To(Succeed())
+
+	root := t.TempDir()
+	destination, err := mirror.NewDirDestination(root, "")
+	g.Expect(err).NotTo(HaveOccurred())
+	syncer := &mirror.Syncer{Destination: destination, Fetchers: artifact.DefaultFetchers(), Logger: zap.NewNop()}
+
+	_, err = syncer.Sync(context.Background(), kubelet)
+	g.Expect(err).To(MatchError(ContainSubstring("does not match expected")))
+	g.Expect(filepath.Join(root, "eks", "kubelet")).NotTo(BeAnExistingFile())
+}
+
+func TestDirDestinationPathOutsideRoot(t *testing.T) {
+	g := NewWithT(t)
+	destination, err := mirror.NewDirDestination(t.TempDir(), "")
+	g.Expect(err).NotTo(HaveOccurred())
+
+	_, err = destination.Has(context.Background(), "../kubelet", nil)
+	g.Expect(err).To(MatchError(ContainSubstring("outside the destination directory")))
+}
@@ -0,0 +1,44 @@
+package mirror
+
+import (
+	"context"
+	"encoding/hex"
+	"io"
+	"path"
+
+	"github.com/aws/eks-hybrid/internal/artifact"
+)
+
+// OCIDestination stores artifacts as blobs in an OCI registry repository. Each blob is
+// the single layer of an artifact manifest tagged sha256-<digest>, so registries don't
+// garbage collect it. Nodes read artifacts by digest, so paths are only used to name
+// the layers.
+type OCIDestination struct {
+	repo    artifact.OCIRepository
+	fetcher *artifact.OCIFetcher
+}
+
+// NewOCIDestination returns a destination that pushes artifacts to repo with fetcher.
+func NewOCIDestination(repo artifact.OCIRepository, fetcher *artifact.OCIFetcher) *OCIDestination {
+	return &OCIDestination{repo: repo, fetcher: fetcher}
+}
+
+func (d *OCIDestination) Has(ctx context.Context, _ string, digest []byte) (bool, error) {
+	return d.fetcher.ManifestExists(ctx, d.repo, ociTag(digest))
+}
+
+func (d *OCIDestination) Put(ctx context.Context, artifactPath string, content io.ReadSeeker, size int64, digest []byte) error {
+	return d.fetcher.PushArtifact(ctx, d.repo, ociTag(digest), path.Base(artifactPath), ociDigest(digest), size, content)
+}
+
+func (d *OCIDestination) URI(_ string, digest []byte) string {
+	return d.repo.BlobURI(ociDigest(digest))
+}
+
+func ociDigest(digest []byte) string {
+	return "sha256:" + hex.EncodeToString(digest)
+}
+
+func ociTag(digest []byte) string {
+	return "sha256-" + hex.EncodeToString(digest)
+}
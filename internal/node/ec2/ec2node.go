@@ -19,18 +19,33 @@ type ec2NodeProvider struct {
 	validator     func(config *api.NodeConfig) error
 }
 
-func NewEc2NodeProvider(nodeConfig *api.NodeConfig, logger *zap.Logger) (nodeprovider.NodeProvider, error) {
+type NodeProviderOpt func(*ec2NodeProvider)
+
+func NewEc2NodeProvider(nodeConfig *api.NodeConfig, logger *zap.Logger, opts ...NodeProviderOpt) (nodeprovider.NodeProvider, error) {
 	np := &ec2NodeProvider{
 		nodeConfig: nodeConfig,
 		logger:     logger,
 	}
 	np.withEc2NodeValidators()
-	if err := np.withDaemonManager(); err != nil {
-		return nil, err
+	for _, opt := range opts {
+		opt(np)
+	}
+	if np.daemonManager == nil {
+		if err := np.withDaemonManager(); err != nil {
+			return nil, err
+		}
 	}
 	return np, nil
 }
 
+// WithDaemonManager sets the DaemonManager used by the node provider instead of
+// connecting to systemd.
+func WithDaemonManager(dm daemon.DaemonManager) NodeProviderOpt {
+	return func(enp *ec2NodeProvider) {
+		enp.daemonManager = dm
+	}
+}
+
 func (enp *ec2NodeProvider) GetNodeConfig() *api.NodeConfig {
 	return enp.nodeConfig
 }
@@ -4,6 +4,8 @@ import (
 	"fmt"
 
 	"github.com/aws/eks-hybrid/internal/api"
+	"github.com/aws/eks-hybrid/internal/containerd"
+	"github.com/aws/eks-hybrid/internal/kubelet"
 )
 
 func (enp *ec2NodeProvider) withEc2NodeValidators() {
@@ -25,6 +27,15 @@ func (enp *ec2NodeProvider) withEc2NodeValidators() {
 				return fmt.Errorf("CIDR is missing in cluster configuration")
 			}
 		}
+		if err := containerd.ValidateRegistries(cfg.Spec.Containerd.Registries); err != nil {
+			return err
+		}
+		if err := containerd.ValidateRuntimes(cfg.Spec.Containerd.Runtimes); err != nil {
+			return err
+		}
+		if err := kubelet.ValidateReservation(cfg.Spec.Kubelet.Reservation); err != nil {
+			return err
+		}
 		return nil
 	}
 }
@@ -88,7 +88,29 @@ type RolesAnywhereAWSConfigurator struct {
 }
 
 func (c RolesAnywhereAWSConfigurator) Configure(ctx context.Context, nodeConfig *api.NodeConfig) error {
-	if err := iamrolesanywhere.WriteAWSConfig(iamrolesanywhere.AWSConfig{
+	if err := c.WriteConfig(ctx, nodeConfig); err != nil {
+		return err
+	}
+
+	if !nodeConfig.Spec.Hybrid.EnableCredentialsFile {
+		return nil
+	}
+
+	signingHelper := iamrolesanywhere.NewSigningHelperDaemon(c.Manager, nodeConfig, c.Logger)
+	if err := signingHelper.EnsureRunning(ctx); err != nil {
+		return err
+	}
+	if err := signingHelper.PostLaunch(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// WriteConfig writes the AWS config and, if the credentials file is enabled,
+// the aws_signing_helper_update unit, without starting any daemon.
+func (c RolesAnywhereAWSConfigurator) WriteConfig(ctx context.Context, nodeConfig *api.NodeConfig) error {
+	if err := iamrolesanywhere.WriteAWSConfig(ctx, iamrolesanywhere.AWSConfig{
 		TrustAnchorARN:       nodeConfig.Spec.Hybrid.IAMRolesAnywhere.TrustAnchorARN,
 		ProfileARN:           nodeConfig.Spec.Hybrid.IAMRolesAnywhere.ProfileARN,
 		RoleARN:              nodeConfig.Spec.Hybrid.IAMRolesAnywhere.RoleARN,
@@ -108,17 +130,7 @@ func (c RolesAnywhereAWSConfigurator) Configure(ctx context.Context, nodeConfig
 
 	c.Logger.Info("Configuring aws_signing_helper_update daemon")
 	signingHelper := iamrolesanywhere.NewSigningHelperDaemon(c.Manager, nodeConfig, c.Logger)
-	if err := signingHelper.Configure(ctx); err != nil {
-		return err
-	}
-	if err := signingHelper.EnsureRunning(ctx); err != nil {
-		return err
-	}
-	if err := signingHelper.PostLaunch(); err != nil {
-		return err
-	}
-
-	return nil
+	return signingHelper.Configure(ctx)
 }
 
 func LoadAWSConfigForRolesAnywhere(ctx context.Context, nodeConfig *api.NodeConfig) (aws.Config, error) {
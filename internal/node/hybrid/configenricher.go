@@ -40,6 +40,17 @@ func (hnp *HybridNodeProvider) Enrich(ctx context.Context, opts ...configenriche
 		hnp.logger.Info("Cluster details populated", zap.Reflect("cluster", hnp.nodeConfig.Spec.Cluster))
 	}
 
+	// nodeadm doesn't describe the cluster when its details are set in the config, so the
+	// remote pod networks are only known when the cluster was read.
+	if hnp.cluster != nil && hnp.cluster.RemoteNetworkConfig != nil {
+		hnp.nodeConfig.Status.Hybrid.RemotePodNetworks = nil
+		for _, network := range hnp.cluster.RemoteNetworkConfig.RemotePodNetworks {
+			hnp.nodeConfig.Status.Hybrid.RemotePodNetworks = append(hnp.nodeConfig.Status.Hybrid.RemotePodNetworks, network.Cidrs...)
+		}
+	} else if hnp.nodeConfig.Spec.Hybrid.PodNetwork == nil {
+		hnp.logger.Info("Pod CIDR of the node is unknown, using the kubelet's default max pods. Set spec.hybrid.podNetwork to compute them from the CNI's node CIDR")
+	}
+
 	return nil
 }
 
@@ -46,6 +46,11 @@ func Test_hybridNodeProvider_Enrich(t *testing.T) {
 							Cidrs: []string{"10.1.0.0/16"},
 						},
 					},
+					RemotePodNetworks: []types.RemotePodNetwork{
+						{
+							Cidrs: []string{"10.2.0.0/16", "10.3.0.0/24"},
+						},
+					},
 				},
 			},
 			node: &api.NodeConfig{
@@ -80,7 +85,10 @@ func Test_hybridNodeProvider_Enrich(t *testing.T) {
 				CIDR:                 "172.0.0.0/16",
 			},
 			wantStatus: api.NodeConfigStatus{
-				Hybrid: api.HybridDetails{NodeName: "my-node"},
+				Hybrid: api.HybridDetails{
+					NodeName:          "my-node",
+					RemotePodNetworks: []string{"10.2.0.0/16", "10.3.0.0/24"},
+				},
 				Defaults: api.DefaultOptions{
 					SandboxImage: "602401143452.dkr.ecr.us-west-2.amazonaws.com/eks/pause:3.5",
 				},
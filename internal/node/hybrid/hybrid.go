@@ -31,6 +31,17 @@ const (
 	kubeletCurrentCertPath      = "/var/lib/kubelet/pki/kubelet-server-current.pem"
 )
 
+// ValidationNames returns the names of the validations run by the hybrid node provider
+// and its daemons.
+func ValidationNames() []string {
+	hnp := &HybridNodeProvider{nodeConfig: &api.NodeConfig{}, awsConfig: &aws.Config{}}
+	names := creds.ValidationNames()
+	for _, v := range hnp.validations() {
+		names = append(names, v.Name)
+	}
+	return append(names, kubelet.ValidationNames()...)
+}
+
 type HybridNodeProvider struct {
 	nodeConfig    *api.NodeConfig
 	validator     func(config *api.NodeConfig) error
@@ -44,6 +55,8 @@ type HybridNodeProvider struct {
 	// If not provided, defaults to kubelet.KubeletCurrentCertPath
 	certPath string
 	kubelet  Kubelet
+	// validationOpts configure the runners for the node validations.
+	validationOpts []validation.RunnerOpt
 }
 
 type NodeProviderOpt func(*HybridNodeProvider)
@@ -58,17 +71,28 @@ func NewHybridNodeProvider(nodeConfig *api.NodeConfig, skipPhases []string, logg
 		kubelet:    kubelet.New(),
 	}
 	np.withHybridValidators()
-	if err := np.withDaemonManager(); err != nil {
-		return nil, err
-	}
 
 	for _, opt := range opts {
 		opt(np)
 	}
 
+	if np.daemonManager == nil {
+		if err := np.withDaemonManager(); err != nil {
+			return nil, err
+		}
+	}
+
 	return np, nil
 }
 
+// WithValidationOpts configures the runners for the node validations,
+// like timeouts and retries for individual validations.
+func WithValidationOpts(opts ...validation.RunnerOpt) NodeProviderOpt {
+	return func(hnp *HybridNodeProvider) {
+		hnp.validationOpts = append(hnp.validationOpts, opts...)
+	}
+}
+
 func WithAWSConfig(config *aws.Config) NodeProviderOpt {
 	return func(hnp *HybridNodeProvider) {
 		hnp.awsConfig = config
@@ -123,18 +147,38 @@ func (hnp *HybridNodeProvider) Validate(ctx context.Context) error {
 	printer := validation.NewLoggerPrinterWithLogger(hnp.logger)
 
 	// Create validation runner with skip phases support
-	runner := validation.NewRunner[*api.NodeConfig](printer, validation.WithSkipValidations(hnp.skipPhases...))
+	opts := []validation.RunnerOpt{
+		validation.WithSkipValidations(hnp.skipPhases...),
+		validation.WithDefaultTimeout(validation.DefaultTimeout),
+	}
+	runner := validation.NewRunner[*api.NodeConfig](printer, append(opts, hnp.validationOpts...)...)
 
 	// Register AWS credential validations if AWS config is available
 	if hnp.awsConfig != nil {
 		runner.Register(creds.Validations(*hnp.awsConfig, hnp.nodeConfig)...)
-		runner.Register(
+	}
+	runner.Register(hnp.validations()...)
+
+	// Most validations are network bound, run them in parallel
+	if err := runner.Concurrently(ctx, hnp.nodeConfig); err != nil {
+		hnp.logger.Error("Hybrid node validation failures detected", zap.Error(err))
+		return err
+	}
+
+	hnp.logger.Info("All hybrid node validations passed successfully")
+	return nil
+}
+
+// validations returns the hybrid node validations. The AWS authentication is only
+// validated if AWS config is available.
+func (hnp *HybridNodeProvider) validations() []validation.Validation[*api.NodeConfig] {
+	var validations []validation.Validation[*api.NodeConfig]
+	if hnp.awsConfig != nil {
+		validations = append(validations,
 			validation.New(awsAuthValidation, sts.NewAuthenticationValidator(*hnp.awsConfig).Run),
 		)
 	}
-
-	// Register all hybrid node validations
-	runner.Register(
+	return append(validations,
 		validation.New(nodeIpValidation, network.NewNetworkInterfaceValidator(
 			network.WithMTUValidation(false),
 			network.WithCluster(hnp.cluster)).Run),
@@ -148,15 +192,6 @@ func (hnp *HybridNodeProvider) Validate(ctx context.Context) error {
 		validation.New(nodeInactiveValidation, hnp.ValidateNodeIsInactive),
 		validation.New(clusterAccessValidation, hnp.ValidateClusterAccess),
 	)
-
-	// Run all validations sequentially
-	if err := runner.Sequentially(ctx, hnp.nodeConfig); err != nil {
-		hnp.logger.Error("Hybrid node validation failures detected", zap.Error(err))
-		return err
-	}
-
-	hnp.logger.Info("All hybrid node validations passed successfully")
-	return nil
 }
 
 func (hnp *HybridNodeProvider) Cleanup() error {
@@ -7,6 +7,8 @@ import (
 
 	"github.com/aws/eks-hybrid/internal/api"
 	"github.com/aws/eks-hybrid/internal/certificate"
+	"github.com/aws/eks-hybrid/internal/containerd"
+	"github.com/aws/eks-hybrid/internal/kubelet"
 	"github.com/aws/eks-hybrid/internal/util/file"
 	"github.com/aws/eks-hybrid/internal/validation"
 )
@@ -83,6 +85,18 @@ func (hnp *HybridNodeProvider) withHybridValidators() {
 				return fmt.Errorf("invalid ActivationID format: %s. Must be in format: ^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$", cfg.Spec.Hybrid.SSM.ActivationID)
 			}
 		}
+		if err := containerd.ValidateRegistries(cfg.Spec.Containerd.Registries); err != nil {
+			return err
+		}
+		if err := containerd.ValidateRuntimes(cfg.Spec.Containerd.Runtimes); err != nil {
+			return err
+		}
+		if err := kubelet.ValidateReservation(cfg.Spec.Kubelet.Reservation); err != nil {
+			return err
+		}
+		if err := kubelet.ValidatePodNetwork(cfg.Spec.Hybrid.PodNetwork); err != nil {
+			return err
+		}
 		return nil
 	}
 }
@@ -457,6 +457,31 @@ func Test_HybridNodeProviderValidateConfig(t *testing.T) {
 			},
 			wantError: "invalid ActivationID format: e488f2f6-e686-4afb-8A04-ef6dfabcdefff. Must be in format: ^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$",
 		},
+		{
+			name: "invalid registry mirror",
+			node: &api.NodeConfig{
+				Spec: api.NodeConfigSpec{
+					Cluster: api.ClusterDetails{
+						Region: "us-west-2",
+						Name:   "my-cluster",
+					},
+					Containerd: api.ContainerdOptions{
+						Registries: map[string]api.RegistryOptions{
+							"docker.io": {
+								Mirrors: []api.RegistryMirror{{Endpoint: "harbor.example.com"}},
+							},
+						},
+					},
+					Hybrid: &api.HybridOptions{
+						SSM: &api.SSM{
+							ActivationCode: "Fjz3/sZfSvv78EXAMPLE",
+							ActivationID:   "e488f2f6-e686-4afb-8a04-ef6dfabcdeff",
+						},
+					},
+				},
+			},
+			wantError: "invalid endpoint for mirror 0 of registry docker.io: \"harbor.example.com\" must be an http:// or https:// URL",
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
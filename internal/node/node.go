@@ -1,17 +1,25 @@
 package node
 
 import (
+	"context"
+
 	"go.uber.org/zap"
 
+	"github.com/aws/eks-hybrid/internal/api"
 	"github.com/aws/eks-hybrid/internal/configprovider"
+	"github.com/aws/eks-hybrid/internal/creds"
+	"github.com/aws/eks-hybrid/internal/daemon"
 	"github.com/aws/eks-hybrid/internal/node/ec2"
 	"github.com/aws/eks-hybrid/internal/node/hybrid"
 	"github.com/aws/eks-hybrid/internal/nodeprovider"
+	"github.com/aws/eks-hybrid/internal/validation"
 )
 
-func NewNodeProvider(configSource string, skipPhases []string, logger *zap.Logger) (nodeprovider.NodeProvider, error) {
+// NewNodeProvider returns the NodeProvider for the configuration read from configSource.
+// validationOpts configure the runner for the node validations.
+func NewNodeProvider(configSource string, skipPhases []string, validationOpts []validation.RunnerOpt, logger *zap.Logger, opts ...configprovider.Option) (nodeprovider.NodeProvider, error) {
 	logger.Info("Loading configuration...", zap.String("configSource", configSource))
-	provider, err := configprovider.BuildConfigProvider(configSource)
+	provider, err := configprovider.BuildConfigProvider(configSource, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -21,8 +29,33 @@ func NewNodeProvider(configSource string, skipPhases []string, logger *zap.Logge
 	}
 	if nodeConfig.IsHybridNode() {
 		logger.Info("Setting up hybrid node provider...")
-		return hybrid.NewHybridNodeProvider(nodeConfig, skipPhases, logger)
+		return hybrid.NewHybridNodeProvider(nodeConfig, skipPhases, logger, hybrid.WithValidationOpts(validationOpts...))
 	}
 	logger.Info("Setting up EC2 node provider...")
 	return ec2.NewEc2NodeProvider(nodeConfig, logger)
 }
+
+// ValidationNames returns the names of the validations run by the node providers.
+// EC2 nodes don't run validations.
+func ValidationNames() []string {
+	return hybrid.ValidationNames()
+}
+
+// NewRecordingNodeProvider returns a NodeProvider that sends daemon operations to
+// daemonManager instead of systemd. For hybrid nodes, the AWS config is read the same way
+// the kubelet does instead of registering the node, so ConfigureAws doesn't need to be called.
+// validationOpts configure the runner for the node validations.
+func NewRecordingNodeProvider(ctx context.Context, nodeConfig *api.NodeConfig, skipPhases []string, validationOpts []validation.RunnerOpt, daemonManager daemon.DaemonManager, logger *zap.Logger) (nodeprovider.NodeProvider, error) {
+	if !nodeConfig.IsHybridNode() {
+		return ec2.NewEc2NodeProvider(nodeConfig, logger, ec2.WithDaemonManager(daemonManager))
+	}
+	awsConfig, err := creds.ReadConfigAsKubelet(ctx, nodeConfig)
+	if err != nil {
+		return nil, err
+	}
+	return hybrid.NewHybridNodeProvider(nodeConfig, skipPhases, logger,
+		hybrid.WithAWSConfig(&awsConfig),
+		hybrid.WithDaemonManager(daemonManager),
+		hybrid.WithValidationOpts(validationOpts...),
+	)
+}
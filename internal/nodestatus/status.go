@@ -0,0 +1,87 @@
+package nodestatus
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-hybrid/internal/api"
+	"github.com/aws/eks-hybrid/internal/util"
+	"github.com/aws/eks-hybrid/internal/validation"
+)
+
+const statusFile = "/opt/nodeadm/status"
+
+// Operation is the nodeadm command that wrote the status report.
+type Operation string
+
+const (
+	OperationInit    Operation = "init"
+	OperationUpgrade Operation = "upgrade"
+)
+
+// Outcome is the result of an operation.
+type Outcome string
+
+const (
+	OutcomeSucceeded Outcome = "succeeded"
+	OutcomeFailed    Outcome = "failed"
+)
+
+// Report is the status of the node after the last init or upgrade.
+type Report struct {
+	Operation      Operation `json:"operation"`
+	NodeadmVersion string    `json:"nodeadmVersion,omitempty"`
+	StartTime      time.Time `json:"startTime"`
+	EndTime        time.Time `json:"endTime"`
+	Outcome        Outcome   `json:"outcome"`
+	// Error is the error that made the operation fail.
+	Error         string   `json:"error,omitempty"`
+	SkippedPhases []string `json:"skippedPhases,omitempty"`
+	// KubernetesVersion is the version of the kubelet installed on the node.
+	KubernetesVersion string `json:"kubernetesVersion,omitempty"`
+	// ConfigHash is the SHA256 of the NodeConfig spec applied to the node.
+	ConfigHash       string                `json:"configHash,omitempty"`
+	NodeConfigStatus *api.NodeConfigStatus `json:"nodeConfigStatus,omitempty"`
+	// Validations are the results of the node validations run by the operation.
+	Validations []validation.Result `json:"validations,omitempty"`
+}
+
+// Save writes the report to the status file.
+func Save(report *Report) error {
+	data, err := yaml.Marshal(report)
+	if err != nil {
+		return err
+	}
+
+	return util.WriteFileWithDir(statusFile, data, 0o644)
+}
+
+// Load reads the report from the status file.
+func Load() (*Report, error) {
+	data, err := os.ReadFile(statusFile)
+	if err != nil {
+		return nil, err
+	}
+	var report Report
+	if err := yaml.Unmarshal(data, &report); err != nil {
+		return nil, errors.Wrap(err, "invalid yaml data in node status")
+	}
+	return &report, nil
+}
+
+// ConfigHash returns the SHA256 of the NodeConfig spec, so changes in the
+// applied configuration can be detected without storing it.
+func ConfigHash(nodeConfig *api.NodeConfig) (string, error) {
+	data, err := json.Marshal(nodeConfig.Spec)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:]), nil
+}
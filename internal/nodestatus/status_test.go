@@ -0,0 +1,34 @@
+package nodestatus_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-hybrid/internal/api"
+	"github.com/aws/eks-hybrid/internal/nodestatus"
+)
+
+func TestConfigHash(t *testing.T) {
+	g := NewWithT(t)
+	nodeConfig := &api.NodeConfig{
+		Spec: api.NodeConfigSpec{
+			Cluster: api.ClusterDetails{Name: "my-cluster", Region: "us-west-2"},
+		},
+	}
+
+	hash, err := nodestatus.ConfigHash(nodeConfig)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(hash).To(HavePrefix("sha256:"))
+
+	// Status is not part of the applied configuration.
+	nodeConfig.Status.Hybrid.NodeName = "mi-123"
+	sameHash, err := nodestatus.ConfigHash(nodeConfig)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(sameHash).To(Equal(hash))
+
+	nodeConfig.Spec.Cluster.Name = "other-cluster"
+	otherHash, err := nodestatus.ConfigHash(nodeConfig)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(otherHash).NotTo(Equal(hash))
+}
@@ -0,0 +1,109 @@
+package nodestatus
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/aws/eks-hybrid/internal/artifact"
+	"github.com/aws/eks-hybrid/internal/tracker"
+	"github.com/aws/eks-hybrid/internal/validation"
+)
+
+// WriteSummary writes a human readable summary of the node status report and the
+// components installed by nodeadm. Either of them can be nil if they haven't been
+// written to the node yet.
+func WriteSummary(w io.Writer, report *Report, installed *tracker.Tracker) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	if report == nil {
+		fmt.Fprintln(tw, "No node status report found. It's written by nodeadm init and nodeadm upgrade.")
+	} else {
+		writeReport(tw, report)
+	}
+
+	fmt.Fprintln(tw)
+	fmt.Fprintln(tw, "Installed components:")
+	if installed == nil || installed.Artifacts == nil {
+		fmt.Fprintln(tw, "  none")
+	} else {
+		writeArtifacts(tw, installed)
+	}
+
+	return tw.Flush()
+}
+
+func writeReport(w io.Writer, report *Report) {
+	fmt.Fprintf(w, "Last operation:\t%s %s\n", report.Operation, report.Outcome)
+	fmt.Fprintf(w, "Started:\t%s\n", report.StartTime.UTC().Format(time.RFC3339))
+	fmt.Fprintf(w, "Finished:\t%s (%s)\n", report.EndTime.UTC().Format(time.RFC3339), report.EndTime.Sub(report.StartTime).Round(time.Second))
+	writeOptional(w, "Error", report.Error)
+	writeOptional(w, "Nodeadm version", report.NodeadmVersion)
+	writeOptional(w, "Kubernetes version", report.KubernetesVersion)
+	if report.NodeConfigStatus != nil {
+		writeOptional(w, "Node name", report.NodeConfigStatus.Hybrid.NodeName)
+		writeOptional(w, "Instance ID", report.NodeConfigStatus.Instance.ID)
+	}
+	writeOptional(w, "Config hash", report.ConfigHash)
+	writeOptional(w, "Skipped phases", strings.Join(report.SkippedPhases, ", "))
+
+	if len(report.Validations) == 0 {
+		return
+	}
+
+	counts := map[validation.Status]int{}
+	for _, result := range report.Validations {
+		counts[result.Status]++
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "Validations:\t%d passed, %d warnings, %d failed, %d skipped\n",
+		counts[validation.StatusPass], counts[validation.StatusWarn], counts[validation.StatusFail], counts[validation.StatusSkip])
+	for _, result := range report.Validations {
+		switch result.Status {
+		case validation.StatusPass:
+			continue
+		case validation.StatusSkip:
+			fmt.Fprintf(w, "  [%s] %s\n", result.Status, result.Name)
+		default:
+			for _, e := range result.Errors {
+				fmt.Fprintf(w, "  [%s] %s: %s\n", result.Status, result.Name, e.Message)
+			}
+		}
+	}
+}
+
+func writeOptional(w io.Writer, name, value string) {
+	if value != "" {
+		fmt.Fprintf(w, "%s:\t%s\n", name, value)
+	}
+}
+
+func writeArtifacts(w io.Writer, installed *tracker.Tracker) {
+	artifacts := installed.Artifacts
+	fmt.Fprintf(w, "  %s\t%s\n", artifact.Containerd, artifacts.Containerd)
+	components := []struct {
+		name      string
+		installed bool
+	}{
+		{artifact.Kubelet, artifacts.Kubelet},
+		{artifact.Kubectl, artifacts.Kubectl},
+		{artifact.CniPlugins, artifacts.CniPlugins},
+		{artifact.ImageCredentialProvider, artifacts.ImageCredentialProvider},
+		{artifact.IamAuthenticator, artifacts.IamAuthenticator},
+		{artifact.IamRolesAnywhere, artifacts.IamRolesAnywhere},
+		{artifact.Ssm, artifacts.Ssm},
+		{artifact.Iptables, artifacts.Iptables},
+	}
+	for _, component := range components {
+		state := "not installed"
+		if component.installed {
+			state = "installed"
+			if details, ok := installed.Details[component.name]; ok && details.Version != "" {
+				state = fmt.Sprintf("installed (%s)", details.Version)
+			}
+		}
+		fmt.Fprintf(w, "  %s\t%s\n", component.name, state)
+	}
+}
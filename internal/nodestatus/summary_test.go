@@ -0,0 +1,80 @@
+package nodestatus_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-hybrid/internal/api"
+	"github.com/aws/eks-hybrid/internal/nodestatus"
+	"github.com/aws/eks-hybrid/internal/tracker"
+	"github.com/aws/eks-hybrid/internal/validation"
+)
+
+func TestWriteSummary(t *testing.T) {
+	g := NewWithT(t)
+	start := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	report := &nodestatus.Report{
+		Operation:         nodestatus.OperationInit,
+		NodeadmVersion:    "v1.0.0",
+		StartTime:         start,
+		EndTime:           start.Add(72 * time.Second),
+		Outcome:           nodestatus.OutcomeSucceeded,
+		KubernetesVersion: "v1.31.2",
+		ConfigHash:        "sha256:abc",
+		NodeConfigStatus: &api.NodeConfigStatus{
+			Hybrid: api.HybridDetails{NodeName: "mi-123"},
+		},
+		Validations: []validation.Result{
+			{Name: "aws-auth", Status: validation.StatusPass},
+			{Name: "ntp-sync", Status: validation.StatusWarn, Errors: []validation.ResultError{{Message: "clock drift", Warning: true}}},
+			{Name: "proxy-validation", Status: validation.StatusSkip},
+		},
+	}
+	installed := &tracker.Tracker{
+		Artifacts: &tracker.InstalledArtifacts{
+			Containerd: tracker.ContainerdSourceDistro,
+			Kubelet:    true,
+		},
+		Details: map[string]*tracker.ArtifactDetails{
+			"kubelet": {Version: "1.31.2"},
+		},
+	}
+
+	var out bytes.Buffer
+	g.Expect(nodestatus.WriteSummary(&out, report, installed)).To(Succeed())
+	g.Expect(out.String()).To(Equal(`Last operation:      init succeeded
+Started:             2024-01-02T03:04:05Z
+Finished:            2024-01-02T03:05:17Z (1m12s)
+Nodeadm version:     v1.0.0
+Kubernetes version:  v1.31.2
+Node name:           mi-123
+Config hash:         sha256:abc
+
+Validations:  1 passed, 1 warnings, 0 failed, 1 skipped
+  [warn] ntp-sync: clock drift
+  [skip] proxy-validation
+
+Installed components:
+  containerd               distro
+  kubelet                  installed (1.31.2)
+  kubectl                  not installed
+  cniPlugins               not installed
+  imageCredentialProvider  not installed
+  iamAuthenticator         not installed
+  iamRolesAnywhere         not installed
+  ssm                      not installed
+  iptables                 not installed
+`))
+}
+
+func TestWriteSummaryNotInitialized(t *testing.T) {
+	g := NewWithT(t)
+
+	var out bytes.Buffer
+	g.Expect(nodestatus.WriteSummary(&out, nil, nil)).To(Succeed())
+	g.Expect(out.String()).To(ContainSubstring("No node status report found"))
+	g.Expect(out.String()).To(ContainSubstring("Installed components:\n  none\n"))
+}
@@ -91,6 +91,12 @@ func (pm *DistroPackageManager) Configure(ctx context.Context) error {
 	return nil
 }
 
+// DockerRepo returns the docker repo Configure adds to the package manager and Cleanup
+// removes. It's empty unless containerd is installed from docker.
+func (pm *DistroPackageManager) DockerRepo() string {
+	return pm.dockerRepo
+}
+
 // configureYumPackageManagerWithDockerRepo configures yum package manager with docker repos
 func (pm *DistroPackageManager) configureYumPackageManagerWithDockerRepo(ctx context.Context) error {
 	// Check and remove runc if installed, as it conflicts with docker repo
@@ -218,10 +224,11 @@ func (pm *DistroPackageManager) getContainerdPackageNameWithVersionConstraint(ve
 
 // RefreshMetadataCache refreshes the package managers metadata cache
 func (pm *DistroPackageManager) RefreshMetadataCache(ctx context.Context) error {
-	return cmd.Retry(ctx, pm.refreshMetadataCacheCommand, 5*time.Second)
+	return cmd.Retry(ctx, pm.RefreshMetadataCacheCmd, 5*time.Second)
 }
 
-func (pm *DistroPackageManager) refreshMetadataCacheCommand(ctx context.Context) *exec.Cmd {
+// RefreshMetadataCacheCmd returns the command RefreshMetadataCache runs.
+func (pm *DistroPackageManager) RefreshMetadataCacheCmd(ctx context.Context) *exec.Cmd {
 	return exec.CommandContext(ctx, pm.manager, pm.refreshMetadataVerb)
 }
 
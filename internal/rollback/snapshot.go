@@ -0,0 +1,235 @@
+package rollback
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Dir is where nodeadm keeps the snapshot taken before the last upgrade.
+const Dir = "/opt/nodeadm/rollback"
+
+const (
+	manifestFile = "snapshot.yaml"
+	dataDir      = "data"
+)
+
+// Snapshot is a copy of the binaries and configuration files an upgrade replaces,
+// so they can be restored if the upgrade fails or is rolled back.
+type Snapshot struct {
+	CreatedAt time.Time
+	// Entries are the paths saved in the snapshot, in the order they were taken.
+	Entries []Entry
+	// RestoreFailed is true if restoring the snapshot after a failed upgrade failed,
+	// so the node might be half upgraded and the snapshot is its last good state.
+	RestoreFailed bool
+
+	dir string
+}
+
+// Entry is a file or directory saved in a snapshot.
+type Entry struct {
+	Path string
+	// Exists is false if the path didn't exist when the snapshot was taken,
+	// in which case restoring the snapshot removes it.
+	Exists bool
+}
+
+// Create copies paths to a new snapshot in dir, replacing any previous snapshot.
+// Paths can be files or directories and don't need to exist. It fails if the previous
+// snapshot couldn't be restored after a failed upgrade, so it isn't overwritten with
+// the half upgraded node.
+func Create(dir string, paths ...string) (*Snapshot, error) {
+	if previous, err := Load(dir); err == nil && previous.RestoreFailed {
+		return nil, fmt.Errorf("the snapshot taken at %s couldn't be restored after a failed upgrade, "+
+			"restore it with nodeadm rollback or remove %s to discard it", previous.CreatedAt.Format(time.RFC3339), dir)
+	}
+	if err := os.RemoveAll(dir); err != nil {
+		return nil, fmt.Errorf("removing previous snapshot: %w", err)
+	}
+
+	snapshot := &Snapshot{
+		CreatedAt: time.Now().UTC(),
+		dir:       dir,
+	}
+	for _, path := range paths {
+		entry := Entry{Path: path}
+		_, err := os.Lstat(path)
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+		case err != nil:
+			return nil, err
+		default:
+			entry.Exists = true
+			if err := copyPath(path, snapshot.backupPath(path)); err != nil {
+				return nil, fmt.Errorf("saving %s in snapshot: %w", path, err)
+			}
+		}
+		snapshot.Entries = append(snapshot.Entries, entry)
+	}
+
+	// The manifest is written last so a partial snapshot is never loaded.
+	if err := snapshot.writeManifest(); err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// MarkRestoreFailed records that restoring the snapshot after a failed upgrade failed,
+// so the next upgrade doesn't replace it until it's rolled back.
+func (s *Snapshot) MarkRestoreFailed() error {
+	s.RestoreFailed = true
+	return s.writeManifest()
+}
+
+func (s *Snapshot) writeManifest() error {
+	data, err := yaml.Marshal(s)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(s.dir, manifestFile), data, 0o644)
+}
+
+// Load reads the snapshot in dir.
+func Load(dir string) (*Snapshot, error) {
+	data, err := os.ReadFile(filepath.Join(dir, manifestFile))
+	if err != nil {
+		return nil, err
+	}
+	var snapshot Snapshot
+	if err := yaml.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("invalid yaml data in snapshot: %w", err)
+	}
+	snapshot.dir = dir
+	return &snapshot, nil
+}
+
+// Paths returns the paths saved in the snapshot.
+func (s *Snapshot) Paths() []string {
+	paths := make([]string, 0, len(s.Entries))
+	for _, entry := range s.Entries {
+		paths = append(paths, entry.Path)
+	}
+	return paths
+}
+
+// Restore puts back every path as it was when the snapshot was taken.
+// It keeps going on failures so as much as possible is restored, and returns all errors.
+func (s *Snapshot) Restore() error {
+	return s.RestorePaths(s.Paths()...)
+}
+
+// RestorePaths puts back the given paths as they were when the snapshot was taken.
+// Paths not saved in the snapshot are ignored. It keeps going on failures so as much
+// as possible is restored, and returns all errors.
+func (s *Snapshot) RestorePaths(paths ...string) error {
+	var errs []error
+	for _, entry := range s.Entries {
+		if !slices.Contains(paths, entry.Path) {
+			continue
+		}
+		if err := s.restore(entry); err != nil {
+			errs = append(errs, fmt.Errorf("restoring %s: %w", entry.Path, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (s *Snapshot) restore(entry Entry) error {
+	if !entry.Exists {
+		return os.RemoveAll(entry.Path)
+	}
+
+	backup := s.backupPath(entry.Path)
+	info, err := os.Lstat(backup)
+	if err != nil {
+		return err
+	}
+	if info.IsDir() {
+		if err := os.RemoveAll(entry.Path); err != nil {
+			return err
+		}
+		return copyPath(backup, entry.Path)
+	}
+
+	// Files are copied next to the destination and renamed, so binaries that are
+	// running, like the kubelet, can be replaced.
+	tmp := entry.Path + ".nodeadm-rollback"
+	if err := os.RemoveAll(tmp); err != nil {
+		return err
+	}
+	if err := copyPath(backup, tmp); err != nil {
+		return err
+	}
+	return os.Rename(tmp, entry.Path)
+}
+
+func (s *Snapshot) backupPath(path string) string {
+	return filepath.Join(s.dir, dataDir, path)
+}
+
+// copyPath copies a file, symlink or directory tree from src to dst, preserving permissions.
+func copyPath(src, dst string) error {
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case d.IsDir():
+			return os.MkdirAll(target, info.Mode().Perm())
+		case d.Type()&fs.ModeSymlink != 0:
+			link, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+				return err
+			}
+			return os.Symlink(link, target)
+		default:
+			return copyFile(path, target, info.Mode().Perm())
+		}
+	})
+}
+
+func copyFile(src, dst string, perm fs.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return err
+	}
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	if err := out.Close(); err != nil {
+		return err
+	}
+	// The umask might have restricted the permissions on create.
+	return os.Chmod(dst, perm)
+}
@@ -0,0 +1,125 @@
+package rollback_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-hybrid/internal/rollback"
+)
+
+func TestSnapshotCreateAndRestore(t *testing.T) {
+	g := NewWithT(t)
+	root := t.TempDir()
+	snapshotDir := filepath.Join(root, "snapshot")
+
+	binary := filepath.Join(root, "usr/bin/kubelet")
+	configDir := filepath.Join(root, "etc/kubernetes/kubelet")
+	added := filepath.Join(root, "etc/kubernetes/kubelet-new")
+	g.Expect(os.MkdirAll(filepath.Dir(binary), 0o755)).To(Succeed())
+	g.Expect(os.WriteFile(binary, []byte("kubelet v1.30"), 0o755)).To(Succeed())
+	g.Expect(os.MkdirAll(filepath.Join(configDir, "config.json.d"), 0o755)).To(Succeed())
+	g.Expect(os.WriteFile(filepath.Join(configDir, "config.json"), []byte("{}"), 0o644)).To(Succeed())
+	g.Expect(os.WriteFile(filepath.Join(configDir, "config.json.d", "00.conf"), []byte("{}"), 0o600)).To(Succeed())
+
+	snapshot, err := rollback.Create(snapshotDir, binary, configDir, added)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(snapshot.Entries).To(Equal([]rollback.Entry{
+		{Path: binary, Exists: true},
+		{Path: configDir, Exists: true},
+		{Path: added, Exists: false},
+	}))
+
+	// Simulate an upgrade.
+	g.Expect(os.WriteFile(binary, []byte("kubelet v1.31"), 0o755)).To(Succeed())
+	g.Expect(os.RemoveAll(filepath.Join(configDir, "config.json.d"))).To(Succeed())
+	g.Expect(os.WriteFile(filepath.Join(configDir, "extra.json"), []byte("{}"), 0o644)).To(Succeed())
+	g.Expect(os.WriteFile(added, []byte("new"), 0o644)).To(Succeed())
+
+	loaded, err := rollback.Load(snapshotDir)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(loaded.Entries).To(Equal(snapshot.Entries))
+	g.Expect(loaded.Restore()).To(Succeed())
+
+	g.Expect(os.ReadFile(binary)).To(Equal([]byte("kubelet v1.30")))
+	info, err := os.Stat(binary)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(info.Mode().Perm()).To(Equal(os.FileMode(0o755)))
+	g.Expect(filepath.Join(configDir, "extra.json")).NotTo(BeAnExistingFile())
+	g.Expect(os.ReadFile(filepath.Join(configDir, "config.json.d", "00.conf"))).To(Equal([]byte("{}")))
+	info, err = os.Stat(filepath.Join(configDir, "config.json.d", "00.conf"))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(info.Mode().Perm()).To(Equal(os.FileMode(0o600)))
+	g.Expect(added).NotTo(BeAnExistingFile())
+}
+
+func TestSnapshotRestorePaths(t *testing.T) {
+	g := NewWithT(t)
+	root := t.TempDir()
+	snapshotDir := filepath.Join(root, "snapshot")
+	kubelet := filepath.Join(root, "kubelet")
+	kubectl := filepath.Join(root, "kubectl")
+	g.Expect(os.WriteFile(kubelet, []byte("kubelet v1.30"), 0o755)).To(Succeed())
+	g.Expect(os.WriteFile(kubectl, []byte("kubectl v1.30"), 0o755)).To(Succeed())
+
+	snapshot, err := rollback.Create(snapshotDir, kubelet, kubectl)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(snapshot.Paths()).To(Equal([]string{kubelet, kubectl}))
+
+	g.Expect(os.WriteFile(kubelet, []byte("kubelet v1.31"), 0o755)).To(Succeed())
+	g.Expect(os.WriteFile(kubectl, []byte("kubectl v1.31"), 0o755)).To(Succeed())
+	g.Expect(snapshot.RestorePaths(kubelet, filepath.Join(root, "unknown"))).To(Succeed())
+
+	g.Expect(os.ReadFile(kubelet)).To(Equal([]byte("kubelet v1.30")))
+	g.Expect(os.ReadFile(kubectl)).To(Equal([]byte("kubectl v1.31")))
+}
+
+func TestSnapshotCreateReplacesPrevious(t *testing.T) {
+	g := NewWithT(t)
+	root := t.TempDir()
+	snapshotDir := filepath.Join(root, "snapshot")
+	first := filepath.Join(root, "first")
+	second := filepath.Join(root, "second")
+	g.Expect(os.WriteFile(first, []byte("first"), 0o644)).To(Succeed())
+	g.Expect(os.WriteFile(second, []byte("second"), 0o644)).To(Succeed())
+
+	_, err := rollback.Create(snapshotDir, first)
+	g.Expect(err).NotTo(HaveOccurred())
+	_, err = rollback.Create(snapshotDir, second)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	loaded, err := rollback.Load(snapshotDir)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(loaded.Entries).To(Equal([]rollback.Entry{{Path: second, Exists: true}}))
+	g.Expect(filepath.Join(snapshotDir, "data", first)).NotTo(BeAnExistingFile())
+}
+
+func TestSnapshotCreateKeepsRestoreFailed(t *testing.T) {
+	g := NewWithT(t)
+	root := t.TempDir()
+	snapshotDir := filepath.Join(root, "snapshot")
+	binary := filepath.Join(root, "kubelet")
+	g.Expect(os.WriteFile(binary, []byte("good"), 0o755)).To(Succeed())
+
+	snapshot, err := rollback.Create(snapshotDir, binary)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(snapshot.MarkRestoreFailed()).To(Succeed())
+
+	g.Expect(os.WriteFile(binary, []byte("half upgraded"), 0o755)).To(Succeed())
+	_, err = rollback.Create(snapshotDir, binary)
+	g.Expect(err).To(MatchError(ContainSubstring("couldn't be restored after a failed upgrade")))
+
+	loaded, err := rollback.Load(snapshotDir)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(loaded.RestoreFailed).To(BeTrue())
+	g.Expect(loaded.Restore()).To(Succeed())
+	g.Expect(os.ReadFile(binary)).To(Equal([]byte("good")))
+}
+
+func TestLoadSnapshotNotFound(t *testing.T) {
+	g := NewWithT(t)
+	_, err := rollback.Load(t.TempDir())
+	g.Expect(os.IsNotExist(err)).To(BeTrue())
+}
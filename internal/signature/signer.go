@@ -0,0 +1,51 @@
+package signature
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ProtonMail/gopenpgp/v3/crypto"
+)
+
+// Signer makes detached OpenPGP signatures with a private key.
+type Signer struct {
+	key *crypto.Key
+}
+
+// NewSigner returns a Signer that signs with the armored private key. The key must
+// not be locked with a passphrase.
+func NewSigner(armoredKey string) (*Signer, error) {
+	key, err := crypto.NewKeyFromArmored(armoredKey)
+	if err != nil {
+		return nil, fmt.Errorf("parsing signing key: %w", err)
+	}
+	if !key.IsPrivate() {
+		return nil, fmt.Errorf("signing key is not a private key")
+	}
+	locked, err := key.IsLocked()
+	if err != nil {
+		return nil, err
+	}
+	if locked {
+		return nil, fmt.Errorf("signing key is locked with a passphrase")
+	}
+	return &Signer{key: key}, nil
+}
+
+// LoadSigner returns a Signer that signs with the armored private key in the file at path.
+func LoadSigner(path string) (*Signer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading signing key: %w", err)
+	}
+	return NewSigner(string(data))
+}
+
+// Sign returns an armored detached signature of data.
+func (s *Signer) Sign(data []byte) ([]byte, error) {
+	signer, err := crypto.PGP().Sign().SigningKey(s.key).Detached().New()
+	if err != nil {
+		return nil, err
+	}
+	return signer.Sign(data, crypto.Armor)
+}
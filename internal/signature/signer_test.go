@@ -0,0 +1,39 @@
+package signature_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-hybrid/internal/signature"
+)
+
+func TestSignerSign(t *testing.T) {
+	g := NewGomegaWithT(t)
+	publicKey, privateKey := generateKeyPair(t)
+	armored, err := privateKey.Armor()
+	g.Expect(err).NotTo(HaveOccurred())
+	path := filepath.Join(t.TempDir(), "signing-key.asc")
+	g.Expect(os.WriteFile(path, []byte(armored), 0o600)).To(Succeed())
+
+	signer, err := signature.LoadSigner(path)
+	g.Expect(err).NotTo(HaveOccurred())
+	data := []byte("bundle checksums")
+	sig, err := signer.Sign(data)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	verifier, err := signature.NewVerifier(publicKey)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(verifier.Verify(data, sig)).To(Succeed())
+	g.Expect(verifier.Verify([]byte("altered checksums"), sig)).NotTo(Succeed())
+}
+
+func TestNewSignerRejectsPublicKey(t *testing.T) {
+	g := NewGomegaWithT(t)
+	publicKey, _ := generateKeyPair(t)
+
+	_, err := signature.NewSigner(publicKey)
+	g.Expect(err).To(MatchError("signing key is not a private key"))
+}
@@ -0,0 +1,70 @@
+package signature
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ProtonMail/gopenpgp/v3/crypto"
+)
+
+// Suffix is appended to the URI of a file to get the URI of its detached signature.
+const Suffix = ".sig"
+
+// Verifier verifies detached OpenPGP signatures against a trust root of public keys.
+// A signature is valid if it was made by any of the trusted keys.
+type Verifier struct {
+	keys *crypto.KeyRing
+}
+
+// NewVerifier returns a Verifier that trusts the armored public keys.
+func NewVerifier(armoredKeys ...string) (*Verifier, error) {
+	if len(armoredKeys) == 0 {
+		return nil, fmt.Errorf("trust root has no public keys")
+	}
+	keys, err := crypto.NewKeyRing(nil)
+	if err != nil {
+		return nil, err
+	}
+	for i, armored := range armoredKeys {
+		key, err := crypto.NewKeyFromArmored(armored)
+		if err != nil {
+			return nil, fmt.Errorf("parsing public key %d of trust root: %w", i, err)
+		}
+		if key.IsPrivate() {
+			return nil, fmt.Errorf("public key %d of trust root is a private key", i)
+		}
+		if err := keys.AddKey(key); err != nil {
+			return nil, err
+		}
+	}
+	return &Verifier{keys: keys}, nil
+}
+
+// LoadVerifier returns a Verifier that trusts the armored public keys in the files at paths.
+func LoadVerifier(paths ...string) (*Verifier, error) {
+	armoredKeys := make([]string, 0, len(paths))
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading trust root: %w", err)
+		}
+		armoredKeys = append(armoredKeys, string(data))
+	}
+	return NewVerifier(armoredKeys...)
+}
+
+// Verify verifies signature is a valid detached signature of data by a trusted key.
+// The signature can be armored or binary.
+func (v *Verifier) Verify(data, signature []byte) error {
+	verifier, err := crypto.PGP().Verify().
+		VerificationKeys(v.keys).
+		New()
+	if err != nil {
+		return err
+	}
+	result, err := verifier.VerifyDetached(data, signature, crypto.Auto)
+	if err != nil {
+		return err
+	}
+	return result.SignatureError()
+}
@@ -0,0 +1,120 @@
+package signature_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ProtonMail/gopenpgp/v3/crypto"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-hybrid/internal/signature"
+)
+
+func generateKeyPair(t *testing.T) (string, *crypto.Key) {
+	g := NewGomegaWithT(t)
+
+	key, err := crypto.PGP().KeyGeneration().
+		AddUserId("test", "test@example.com").
+		New().GenerateKey()
+	g.Expect(err).NotTo(HaveOccurred())
+
+	armoredPublicKey, err := key.GetArmoredPublicKey()
+	g.Expect(err).NotTo(HaveOccurred())
+
+	return armoredPublicKey, key
+}
+
+func sign(t *testing.T, key *crypto.Key, data []byte, encoding int8) []byte {
+	g := NewGomegaWithT(t)
+
+	signer, err := crypto.PGP().Sign().SigningKey(key).Detached().New()
+	g.Expect(err).NotTo(HaveOccurred())
+
+	signature, err := signer.Sign(data, encoding)
+	g.Expect(err).NotTo(HaveOccurred())
+	return signature
+}
+
+func TestVerifierVerify(t *testing.T) {
+	publicKey, privateKey := generateKeyPair(t)
+	otherPublicKey, otherPrivateKey := generateKeyPair(t)
+	_, untrustedPrivateKey := generateKeyPair(t)
+	data := []byte("supported_eks_releases: []\n")
+
+	tests := []struct {
+		name      string
+		data      []byte
+		signature []byte
+		wantErr   bool
+	}{
+		{
+			name:      "binary signature",
+			data:      data,
+			signature: sign(t, privateKey, data, crypto.Bytes),
+		},
+		{
+			name:      "armored signature",
+			data:      data,
+			signature: sign(t, privateKey, data, crypto.Armor),
+		},
+		{
+			name:      "signature by any trusted key",
+			data:      data,
+			signature: sign(t, otherPrivateKey, data, crypto.Bytes),
+		},
+		{
+			name:      "untrusted key",
+			data:      data,
+			signature: sign(t, untrustedPrivateKey, data, crypto.Bytes),
+			wantErr:   true,
+		},
+		{
+			name:      "altered data",
+			data:      []byte("supported_eks_releases: [altered]\n"),
+			signature: sign(t, privateKey, data, crypto.Bytes),
+			wantErr:   true,
+		},
+	}
+
+	verifier, err := signature.NewVerifier(publicKey, otherPublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			err := verifier.Verify(tc.data, tc.signature)
+			if tc.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestLoadVerifier(t *testing.T) {
+	g := NewGomegaWithT(t)
+	publicKey, privateKey := generateKeyPair(t)
+	path := filepath.Join(t.TempDir(), "trust-root.asc")
+	g.Expect(os.WriteFile(path, []byte(publicKey), 0o644)).To(Succeed())
+
+	verifier, err := signature.LoadVerifier(path)
+	g.Expect(err).NotTo(HaveOccurred())
+	data := []byte("kubelet checksum")
+	g.Expect(verifier.Verify(data, sign(t, privateKey, data, crypto.Bytes))).To(Succeed())
+
+	_, err = signature.LoadVerifier(filepath.Join(t.TempDir(), "missing.asc"))
+	g.Expect(err).To(MatchError(ContainSubstring("reading trust root")))
+}
+
+func TestNewVerifierRejectsPrivateKey(t *testing.T) {
+	g := NewGomegaWithT(t)
+	_, privateKey := generateKeyPair(t)
+	armored, err := privateKey.Armor()
+	g.Expect(err).NotTo(HaveOccurred())
+
+	_, err = signature.NewVerifier(armored)
+	g.Expect(err).To(MatchError(ContainSubstring("is a private key")))
+}
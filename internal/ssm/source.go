@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"os/exec"
+	"path"
 	"runtime"
 
 	"go.uber.org/zap"
@@ -122,7 +123,7 @@ func (s ssmInstallerSource) GetSSMInstaller(ctx context.Context) (io.ReadCloser,
 
 	s.logger.Info("Downloading SSM installer", zap.String("region", s.region), zap.String("url", endpoint))
 
-	obj, err := util.GetHttpFileReader(ctx, endpoint)
+	obj, err := util.OpenURI(ctx, endpoint)
 	if err != nil {
 		return nil, err
 	}
@@ -134,7 +135,7 @@ func (s ssmInstallerSource) GetSSMInstallerSignature(ctx context.Context) (io.Re
 	if err != nil {
 		return nil, err
 	}
-	obj, err := util.GetHttpFileReader(ctx, endpoint+".sig")
+	obj, err := util.OpenURI(ctx, endpoint+".sig")
 	if err != nil {
 		return nil, err
 	}
@@ -147,19 +148,42 @@ func (s ssmInstallerSource) PublicKey() string {
 
 // defaultBuildSSMURL builds the SSM installer URL with partition-aware DNS suffix
 func (s ssmInstallerSource) defaultBuildSSMURL() (string, error) {
+	platform, err := Platform()
+	if err != nil {
+		return "", err
+	}
+	return InstallerURL(s.region, s.dnsSuffix, platform), nil
+}
+
+// Platform returns the platform of the SSM installer for the host, in the format
+// <variant>_<arch>. The variant depends on the package manager in use.
+func Platform() (string, error) {
 	variant, err := detectPlatformVariant()
 	if err != nil {
 		return "", err
 	}
+	return fmt.Sprintf("%s_%s", variant, runtime.GOARCH), nil
+}
 
-	dnsSuffix := s.dnsSuffix
+// Platforms returns every platform the SSM installer is released for on arch.
+func Platforms(arch string) []string {
+	return []string{"linux_" + arch, "debian_" + arch}
+}
+
+// InstallerPath returns the path of the SSM installer for platform in bundles and
+// artifact mirrors, relative to their root. Its signature is stored next to it.
+func InstallerPath(platform string) string {
+	return path.Join("ssm", platform, "ssm-setup-cli")
+}
+
+// InstallerURL returns the URL of the SSM installer for the platform in the region.
+// If dnsSuffix is empty, it's derived from the region's partition.
+func InstallerURL(region, dnsSuffix, platform string) string {
 	if dnsSuffix == "" {
-		partition := awsinternal.GetPartitionFromRegionFallback(s.region)
+		partition := awsinternal.GetPartitionFromRegionFallback(region)
 		dnsSuffix = awsinternal.GetPartitionDNSSuffix(partition)
 	}
-
-	platform := fmt.Sprintf("%s_%s", variant, runtime.GOARCH)
-	return fmt.Sprintf("https://amazon-ssm-%s.s3.%s.%s/latest/%s/ssm-setup-cli", s.region, s.region, dnsSuffix, platform), nil
+	return fmt.Sprintf("https://amazon-ssm-%s.s3.%s.%s/latest/%s/ssm-setup-cli", region, region, dnsSuffix, platform)
 }
 
 // detectPlatformVariant returns a portion of the SSM installers URL that is dependent on the
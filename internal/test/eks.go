@@ -30,7 +30,7 @@ func (c camelCaseDescribeClusterOutput) MarshalJSON() ([]byte, error) {
 	return json.Marshal(toCamelCaseMap(c.DescribeClusterOutput))
 }
 
-// toCamelCaseMap recursively converts a struct into a map[string]interface{},
+// toCamelCaseMap recursively converts a struct, or a slice of structs, into a map[string]interface{},
 // where all field names are converted from PascalCase to camelCase. Input must be non-circular;
 // transforms only field names and not values.
 func toCamelCaseMap(v interface{}) interface{} {
@@ -46,6 +46,15 @@ func toCamelCaseMap(v interface{}) interface{} {
 	switch val.Kind() {
 	case reflect.Struct:
 		return convertStructToCamelCaseMap(val)
+	case reflect.Slice:
+		if val.Type().Elem().Kind() != reflect.Struct {
+			return val.Interface()
+		}
+		result := make([]interface{}, val.Len())
+		for i := range val.Len() {
+			result[i] = toCamelCaseMap(val.Index(i).Interface())
+		}
+		return result
 	default:
 		// No field names to convert, return unmodified
 		if val.CanInterface() {
@@ -23,8 +23,17 @@ const (
 
 const trackerFile = "/opt/nodeadm/tracker"
 
+// currentVersion is the version of the tracker file format.
+// Version 1, with no Version field, only tracked which artifacts were installed.
+// Version 2 adds the version, files and checksums of each artifact.
+const currentVersion = 2
+
 type Tracker struct {
+	Version   int
 	Artifacts *InstalledArtifacts
+	// Details are the version and files of the artifacts installed from
+	// a release, by artifact name.
+	Details map[string]*ArtifactDetails `json:"Details,omitempty"`
 }
 
 type InstalledArtifacts struct {
@@ -66,6 +75,12 @@ func (tracker *Tracker) Add(componentName string) error {
 
 // Save() saves the tracker to file
 func (tracker *Tracker) Save() error {
+	return tracker.SaveTo(util.HostFileSystem())
+}
+
+// SaveTo saves the tracker to the tracker file in fsys.
+func (tracker *Tracker) SaveTo(fsys util.FileSystem) error {
+	tracker.Version = currentVersion
 	// ensure containerd source is populated with none/distro/docker
 	containerdSource, err := ContainerdSource(string(tracker.Artifacts.Containerd))
 	if err != nil {
@@ -77,11 +92,21 @@ func (tracker *Tracker) Save() error {
 		return err
 	}
 
-	return util.WriteFileWithDir(trackerFile, data, 0o644)
+	return util.WriteFileWithDirFS(fsys, trackerFile, data, 0o644)
 }
 
 func Clear() error {
-	return os.RemoveAll(path.Dir(trackerFile))
+	return os.RemoveAll(Dir())
+}
+
+// File returns the path of the tracker file.
+func File() string {
+	return trackerFile
+}
+
+// Dir returns the directory holding the tracker file. It's removed by Clear.
+func Dir() string {
+	return path.Dir(trackerFile)
 }
 
 // GetInstalledArtifacts reads the tracker file and returns the current
@@ -103,10 +128,29 @@ func GetInstalledArtifacts() (*Tracker, error) {
 		return nil, err
 	}
 	artifacts.Artifacts.Containerd = containerdSource
+	artifacts.migrate()
 
 	return &artifacts, nil
 }
 
+// migrate upgrades a tracker read from an older file format. Artifacts installed
+// before details were tracked get details with no version or files, so they
+// are reported as untracked until they are installed or upgraded again.
+func (tracker *Tracker) migrate() {
+	if tracker.Version >= currentVersion {
+		return
+	}
+	if tracker.Details == nil {
+		tracker.Details = map[string]*ArtifactDetails{}
+	}
+	for _, name := range tracker.Artifacts.installed() {
+		if _, ok := tracker.Details[name]; !ok {
+			tracker.Details[name] = &ArtifactDetails{}
+		}
+	}
+	tracker.Version = currentVersion
+}
+
 // GetCurrentState reads the tracker file and returns current state
 // If tracker file does not exist, it creates a new tracker
 func GetCurrentState() (*Tracker, error) {
@@ -114,7 +158,9 @@ func GetCurrentState() (*Tracker, error) {
 	if err != nil {
 		if errors.Is(err, fs.ErrNotExist) {
 			return &Tracker{
+				Version:   currentVersion,
 				Artifacts: &InstalledArtifacts{},
+				Details:   map[string]*ArtifactDetails{},
 			}, nil
 		}
 		return nil, err
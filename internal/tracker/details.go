@@ -0,0 +1,158 @@
+package tracker
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/aws/eks-hybrid/internal/artifact"
+)
+
+// ArtifactDetails describes an artifact installed from a release.
+type ArtifactDetails struct {
+	// Version is the release version the artifact was installed from.
+	Version string `json:"Version,omitempty"`
+	// Files are the files installed for the artifact with their checksums.
+	Files []InstalledFile `json:"Files,omitempty"`
+	// InstalledAt is when the artifact was installed or last upgraded.
+	InstalledAt *time.Time `json:"InstalledAt,omitempty"`
+}
+
+// InstalledFile is a file installed for an artifact.
+type InstalledFile struct {
+	Path   string
+	SHA256 string
+}
+
+// Record adds an artifact as installed with the given release version, computing
+// the checksums of its files. It replaces any details previously recorded for it.
+func (tracker *Tracker) Record(componentName, version string, paths ...string) error {
+	if err := tracker.Add(componentName); err != nil {
+		return err
+	}
+
+	details := &ArtifactDetails{
+		Version: version,
+	}
+	for _, path := range paths {
+		checksum, err := fileSHA256(path)
+		if err != nil {
+			return fmt.Errorf("computing checksum of %s: %w", path, err)
+		}
+		details.Files = append(details.Files, InstalledFile{Path: path, SHA256: checksum})
+	}
+	now := time.Now().UTC()
+	details.InstalledAt = &now
+
+	if tracker.Details == nil {
+		tracker.Details = map[string]*ArtifactDetails{}
+	}
+	tracker.Details[componentName] = details
+	return nil
+}
+
+// FileState is the result of verifying an installed file.
+type FileState string
+
+const (
+	// FileUnchanged means the file matches the recorded checksum.
+	FileUnchanged FileState = "unchanged"
+	// FileModified means the file doesn't match the recorded checksum.
+	FileModified FileState = "modified"
+	// FileMissing means the file doesn't exist.
+	FileMissing FileState = "missing"
+	// FileUntracked means no files were recorded for the artifact, because it was
+	// installed by a nodeadm version that didn't record them.
+	FileUntracked FileState = "untracked"
+)
+
+// FileVerification is the result of verifying an installed file against the tracker.
+type FileVerification struct {
+	Artifact string
+	Version  string
+	Path     string
+	Expected string
+	Actual   string
+	State    FileState
+}
+
+// Verify re-computes the checksums of the files recorded for every artifact
+// and compares them with the recorded ones. Results are sorted by artifact name.
+func (tracker *Tracker) Verify() ([]FileVerification, error) {
+	names := make([]string, 0, len(tracker.Details))
+	for name := range tracker.Details {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var results []FileVerification
+	for _, name := range names {
+		details := tracker.Details[name]
+		if len(details.Files) == 0 {
+			results = append(results, FileVerification{Artifact: name, Version: details.Version, State: FileUntracked})
+			continue
+		}
+		for _, file := range details.Files {
+			result := FileVerification{
+				Artifact: name,
+				Version:  details.Version,
+				Path:     file.Path,
+				Expected: file.SHA256,
+			}
+			checksum, err := fileSHA256(file.Path)
+			switch {
+			case errors.Is(err, fs.ErrNotExist):
+				result.State = FileMissing
+			case err != nil:
+				return nil, fmt.Errorf("computing checksum of %s: %w", file.Path, err)
+			case checksum == file.SHA256:
+				result.Actual = checksum
+				result.State = FileUnchanged
+			default:
+				result.Actual = checksum
+				result.State = FileModified
+			}
+			results = append(results, result)
+		}
+	}
+	return results, nil
+}
+
+func fileSHA256(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// installed returns the names of the artifacts installed from a release.
+func (artifacts *InstalledArtifacts) installed() []string {
+	var names []string
+	for name, installed := range map[string]bool{
+		artifact.CniPlugins:              artifacts.CniPlugins,
+		artifact.IamAuthenticator:        artifacts.IamAuthenticator,
+		artifact.IamRolesAnywhere:        artifacts.IamRolesAnywhere,
+		artifact.ImageCredentialProvider: artifacts.ImageCredentialProvider,
+		artifact.Kubectl:                 artifacts.Kubectl,
+		artifact.Kubelet:                 artifacts.Kubelet,
+	} {
+		if installed {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
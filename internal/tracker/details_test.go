@@ -0,0 +1,80 @@
+package tracker
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-hybrid/internal/artifact"
+)
+
+func TestTrackerRecordAndVerify(t *testing.T) {
+	g := NewWithT(t)
+	dir := t.TempDir()
+	kubeletPath := filepath.Join(dir, "kubelet")
+	kubectlPath := filepath.Join(dir, "kubectl")
+	g.Expect(os.WriteFile(kubeletPath, []byte("kubelet"), 0o755)).To(Succeed())
+	g.Expect(os.WriteFile(kubectlPath, []byte("kubectl"), 0o755)).To(Succeed())
+
+	tracker := &Tracker{Artifacts: &InstalledArtifacts{}}
+	g.Expect(tracker.Record(artifact.Kubelet, "1.31.0", kubeletPath)).To(Succeed())
+	g.Expect(tracker.Record(artifact.Kubectl, "1.31.0", kubectlPath)).To(Succeed())
+	g.Expect(tracker.Artifacts.Kubelet).To(BeTrue())
+	g.Expect(tracker.Details[artifact.Kubelet].Version).To(Equal("1.31.0"))
+	g.Expect(tracker.Details[artifact.Kubelet].InstalledAt).NotTo(BeNil())
+	g.Expect(tracker.Details[artifact.Kubelet].Files).To(Equal([]InstalledFile{
+		// sha256 of "kubelet"
+		{Path: kubeletPath, SHA256: "1ca4bc7eb9b3d6f1e205da9cfab437c89d3760d0765a29a6bcbccf4ad51a2cb1"},
+	}))
+
+	g.Expect(os.WriteFile(kubeletPath, []byte("tampered"), 0o755)).To(Succeed())
+	g.Expect(os.Remove(kubectlPath)).To(Succeed())
+
+	results, err := tracker.Verify()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(results).To(HaveLen(2))
+	g.Expect(results[0].Artifact).To(Equal(artifact.Kubectl))
+	g.Expect(results[0].State).To(Equal(FileMissing))
+	g.Expect(results[1].Artifact).To(Equal(artifact.Kubelet))
+	g.Expect(results[1].State).To(Equal(FileModified))
+	g.Expect(results[1].Actual).NotTo(Equal(results[1].Expected))
+}
+
+func TestTrackerRecordMissingFile(t *testing.T) {
+	g := NewWithT(t)
+	tracker := &Tracker{Artifacts: &InstalledArtifacts{}}
+	g.Expect(tracker.Record(artifact.Kubelet, "1.31.0", filepath.Join(t.TempDir(), "missing"))).To(MatchError(ContainSubstring("computing checksum")))
+}
+
+func TestTrackerMigrate(t *testing.T) {
+	g := NewWithT(t)
+	v1 := []byte(`Artifacts:
+  Containerd: distro
+  CniPlugins: true
+  IamAuthenticator: false
+  IamRolesAnywhere: false
+  ImageCredentialProvider: true
+  Kubectl: true
+  Kubelet: true
+  Ssm: true
+  Iptables: true
+`)
+	var tracker Tracker
+	g.Expect(yaml.Unmarshal(v1, &tracker)).To(Succeed())
+
+	tracker.migrate()
+
+	g.Expect(tracker.Version).To(Equal(currentVersion))
+	g.Expect(tracker.Details).To(HaveLen(4))
+	g.Expect(tracker.Details).To(HaveKeyWithValue(artifact.Kubelet, &ArtifactDetails{}))
+
+	results, err := tracker.Verify()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(results).To(HaveLen(4))
+	for _, result := range results {
+		g.Expect(result.State).To(Equal(FileUntracked))
+	}
+}
@@ -20,6 +20,14 @@ func WriteFileWithDir(filePath string, data []byte, perm fs.FileMode) error {
 	return os.WriteFile(filePath, data, perm)
 }
 
+// WriteFileWithDirFS is WriteFileWithDir on the given FileSystem.
+func WriteFileWithDirFS(fsys FileSystem, filePath string, data []byte, perm fs.FileMode) error {
+	if err := fsys.MkdirAll(path.Dir(filePath), perm); err != nil {
+		return err
+	}
+	return fsys.WriteFile(filePath, data, perm)
+}
+
 // IsFilePathExists checks whether specific file path exists
 func IsFilePathExists(filePath string) (bool, error) {
 	_, err := os.Stat(filePath)
@@ -0,0 +1,157 @@
+package util
+
+import (
+	"context"
+	"io/fs"
+	"maps"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// FileSystem is the filesystem configuration files are written to.
+// It allows recording the files nodeadm would write instead of modifying the host.
+type FileSystem interface {
+	MkdirAll(path string, perm fs.FileMode) error
+	WriteFile(path string, data []byte, perm fs.FileMode) error
+	ReadFile(path string) ([]byte, error)
+	RemoveAll(path string) error
+}
+
+type fileSystemContextKey struct{}
+
+// NewFileSystemContext returns a new Context, derived from ctx, which carries the
+// FileSystem the files configured during the call are written to.
+func NewFileSystemContext(ctx context.Context, fsys FileSystem) context.Context {
+	return context.WithValue(ctx, fileSystemContextKey{}, fsys)
+}
+
+// FileSystemFromContext returns the FileSystem from the context.
+// If no FileSystem is found, the host filesystem is returned.
+func FileSystemFromContext(ctx context.Context) FileSystem {
+	fsys, ok := ctx.Value(fileSystemContextKey{}).(FileSystem)
+	if !ok {
+		return HostFileSystem()
+	}
+	return fsys
+}
+
+// HostFileSystem returns the FileSystem that writes to the host.
+func HostFileSystem() FileSystem {
+	return osFileSystem{}
+}
+
+type osFileSystem struct{}
+
+func (osFileSystem) MkdirAll(path string, perm fs.FileMode) error {
+	return os.MkdirAll(path, perm)
+}
+
+func (osFileSystem) WriteFile(path string, data []byte, perm fs.FileMode) error {
+	return os.WriteFile(path, data, perm)
+}
+
+func (osFileSystem) ReadFile(path string) ([]byte, error) {
+	return os.ReadFile(path)
+}
+
+func (osFileSystem) RemoveAll(path string) error {
+	return os.RemoveAll(path)
+}
+
+// MemoryFile is a file recorded by a MemoryFileSystem.
+type MemoryFile struct {
+	Path string
+	Data []byte
+	Perm fs.FileMode
+}
+
+// MemoryFileSystem records written and removed files in memory instead of modifying the
+// host. Reads return the recorded content and fall back to the host filesystem for files
+// that haven't been written or removed.
+type MemoryFileSystem struct {
+	mu      sync.Mutex
+	files   map[string]MemoryFile
+	removed []string
+}
+
+func NewMemoryFileSystem() *MemoryFileSystem {
+	return &MemoryFileSystem{
+		files: map[string]MemoryFile{},
+	}
+}
+
+func (m *MemoryFileSystem) MkdirAll(path string, perm fs.FileMode) error {
+	return nil
+}
+
+func (m *MemoryFileSystem) WriteFile(path string, data []byte, perm fs.FileMode) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.files[path] = MemoryFile{
+		Path: path,
+		Data: append([]byte(nil), data...),
+		Perm: perm,
+	}
+	return nil
+}
+
+func (m *MemoryFileSystem) ReadFile(path string) ([]byte, error) {
+	m.mu.Lock()
+	file, ok := m.files[path]
+	removed := slices.ContainsFunc(m.removed, func(removed string) bool {
+		return isWithin(path, removed)
+	})
+	m.mu.Unlock()
+	if ok {
+		return file.Data, nil
+	}
+	if removed {
+		return nil, &fs.PathError{Op: "open", Path: path, Err: fs.ErrNotExist}
+	}
+	return os.ReadFile(path)
+}
+
+// RemoveAll records the removal of path and everything it contains, forgetting the
+// files written under it.
+func (m *MemoryFileSystem) RemoveAll(path string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	maps.DeleteFunc(m.files, func(file string, _ MemoryFile) bool {
+		return isWithin(file, path)
+	})
+	if !slices.Contains(m.removed, path) {
+		m.removed = append(m.removed, path)
+		slices.Sort(m.removed)
+	}
+	return nil
+}
+
+// Removed returns the recorded removals sorted by path. Files written after a
+// removal are still returned by Files.
+func (m *MemoryFileSystem) Removed() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return slices.Clone(m.removed)
+}
+
+// isWithin returns true if path is dir or is inside it.
+func isWithin(path, dir string) bool {
+	return path == dir || strings.HasPrefix(path, strings.TrimSuffix(dir, "/")+"/")
+}
+
+// Files returns the recorded files sorted by path.
+func (m *MemoryFileSystem) Files() []MemoryFile {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	files := make([]MemoryFile, 0, len(m.files))
+	for _, file := range m.files {
+		files = append(files, file)
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].Path < files[j].Path
+	})
+	return files
+}
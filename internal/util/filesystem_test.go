@@ -0,0 +1,57 @@
+package util_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-hybrid/internal/util"
+)
+
+func TestMemoryFileSystem(t *testing.T) {
+	g := NewWithT(t)
+	dir := t.TempDir()
+	existingPath := filepath.Join(dir, "existing.conf")
+	g.Expect(os.WriteFile(existingPath, []byte("no-tty\n"), 0o644)).To(Succeed())
+
+	fileSystem := util.NewMemoryFileSystem()
+	configPath := filepath.Join(dir, "etc", "config.json")
+	g.Expect(util.WriteFileWithDirFS(fileSystem, configPath, []byte("{}"), 0o644)).To(Succeed())
+	g.Expect(filepath.Join(dir, "etc")).NotTo(BeADirectory())
+
+	g.Expect(fileSystem.ReadFile(configPath)).To(Equal([]byte("{}")))
+	g.Expect(fileSystem.ReadFile(existingPath)).To(Equal([]byte("no-tty\n")))
+	g.Expect(fileSystem.Files()).To(Equal([]util.MemoryFile{
+		{Path: configPath, Data: []byte("{}"), Perm: 0o644},
+	}))
+}
+
+func TestMemoryFileSystemRemoveAll(t *testing.T) {
+	g := NewWithT(t)
+	dir := t.TempDir()
+	existingPath := filepath.Join(dir, "certs.d", "registry.example.com", "hosts.toml")
+	g.Expect(os.MkdirAll(filepath.Dir(existingPath), 0o755)).To(Succeed())
+	g.Expect(os.WriteFile(existingPath, []byte("server = \"https://registry.example.com\"\n"), 0o644)).To(Succeed())
+
+	fileSystem := util.NewMemoryFileSystem()
+	writtenPath := filepath.Join(dir, "certs.d", "registry.example.com", "ca.crt")
+	g.Expect(fileSystem.WriteFile(writtenPath, []byte("ca"), 0o644)).To(Succeed())
+	g.Expect(fileSystem.RemoveAll(filepath.Join(dir, "certs.d", "registry.example.com"))).To(Succeed())
+
+	_, err := fileSystem.ReadFile(existingPath)
+	g.Expect(err).To(MatchError(os.ErrNotExist))
+	g.Expect(existingPath).To(BeAnExistingFile())
+	g.Expect(fileSystem.Files()).To(BeEmpty())
+	g.Expect(fileSystem.Removed()).To(Equal([]string{filepath.Join(dir, "certs.d", "registry.example.com")}))
+}
+
+func TestFileSystemFromContext(t *testing.T) {
+	g := NewWithT(t)
+	fileSystem := util.NewMemoryFileSystem()
+
+	g.Expect(util.FileSystemFromContext(context.Background())).To(Equal(util.HostFileSystem()))
+	g.Expect(util.FileSystemFromContext(util.NewFileSystemContext(context.Background(), fileSystem))).To(BeIdenticalTo(fileSystem))
+}
@@ -17,6 +17,11 @@ const userAgentHeader = "User-Agent"
 
 var userAgent = fmt.Sprintf("nodeadm/%s (%s/%s)", version.GitVersion, runtime.GOOS, runtime.GOARCH)
 
+// UserAgent returns the User-Agent nodeadm sends in HTTP requests.
+func UserAgent() string {
+	return userAgent
+}
+
 func GetHttpFile(ctx context.Context, uri string) ([]byte, error) {
 	reader, err := GetHttpFileReader(ctx, uri)
 	if err != nil {
@@ -0,0 +1,44 @@
+package util
+
+import (
+	"context"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const fileScheme = "file://"
+
+// OpenURI opens a file:// URI from disk and any other URI over HTTP.
+func OpenURI(ctx context.Context, uri string) (io.ReadCloser, error) {
+	if path, ok := strings.CutPrefix(uri, fileScheme); ok {
+		fh, err := os.Open(path)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed reading file from uri: %s", uri)
+		}
+		return fh, nil
+	}
+	return GetHttpFileReader(ctx, uri)
+}
+
+// ReadURI reads the contents of a file:// URI from disk and any other URI over HTTP.
+func ReadURI(ctx context.Context, uri string) ([]byte, error) {
+	reader, err := OpenURI(ctx, uri)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed reading file from uri: %s", uri)
+	}
+	return data, nil
+}
+
+// FileURI returns the file:// URI for a local path.
+func FileURI(path string) string {
+	return fileScheme + path
+}
@@ -0,0 +1,136 @@
+package validation
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+)
+
+// eventInformer is an Informer that records when validations start and finish
+// and the output they write. Buffered events are replayed to it with the time
+// they happened instead of the time they were replayed.
+type eventInformer interface {
+	startingAt(ctx context.Context, name, message string, at time.Time)
+	doneAt(ctx context.Context, name string, err error, at time.Time, output []string)
+}
+
+type informerEvent struct {
+	starting bool
+	name     string
+	message  string
+	err      error
+	at       time.Time
+	output   []string
+}
+
+// bufferedInformer records the events of a validation so they can be replayed later.
+type bufferedInformer struct {
+	mu     sync.Mutex
+	events []informerEvent
+	closed bool
+}
+
+var (
+	_ Informer      = &bufferedInformer{}
+	_ eventInformer = &bufferedInformer{}
+)
+
+func (b *bufferedInformer) Starting(ctx context.Context, name, message string) {
+	b.startingAt(ctx, name, message, time.Now())
+}
+
+func (b *bufferedInformer) Done(ctx context.Context, name string, err error) {
+	b.doneAt(ctx, name, err, time.Now(), nil)
+}
+
+func (b *bufferedInformer) startingAt(ctx context.Context, name, message string, at time.Time) {
+	b.record(informerEvent{starting: true, name: name, message: message, at: at})
+}
+
+func (b *bufferedInformer) doneAt(ctx context.Context, name string, err error, at time.Time, output []string) {
+	b.record(informerEvent{name: name, err: err, at: at, output: output})
+}
+
+func (b *bufferedInformer) record(event informerEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return
+	}
+	b.events = append(b.events, event)
+}
+
+// close stops recording events, discards the ones that happened after cutoff
+// and returns the names of the validations that were started but haven't finished.
+func (b *bufferedInformer) close(cutoff time.Time) []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.closed = true
+	b.events = slices.DeleteFunc(b.events, func(event informerEvent) bool {
+		return event.at.After(cutoff)
+	})
+	var pending []string
+	for _, event := range b.events {
+		if event.starting {
+			pending = append(pending, event.name)
+			continue
+		}
+		for i, name := range pending {
+			if name == event.name {
+				pending = append(pending[:i], pending[i+1:]...)
+				break
+			}
+		}
+	}
+	return pending
+}
+
+func (b *bufferedInformer) replay(ctx context.Context, informer Informer) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	eventInf, isEvent := informer.(eventInformer)
+	for _, event := range b.events {
+		switch {
+		case event.starting && isEvent:
+			eventInf.startingAt(ctx, event.name, event.message, event.at)
+		case event.starting:
+			informer.Starting(ctx, event.name, event.message)
+		case isEvent:
+			eventInf.doneAt(ctx, event.name, event.err, event.at, event.output)
+		default:
+			writeOutput(event.output)
+			informer.Done(ctx, event.name, event.err)
+		}
+	}
+}
+
+// orderedFlusher replays buffered validations to an informer in registration order,
+// as soon as every validation registered before them has been replayed.
+type orderedFlusher struct {
+	informer Informer
+
+	mu      sync.Mutex
+	next    int
+	buffers []*bufferedInformer
+}
+
+func newOrderedFlusher(informer Informer, size int) *orderedFlusher {
+	return &orderedFlusher{
+		informer: informer,
+		buffers:  make([]*bufferedInformer, size),
+	}
+}
+
+// done marks the validation at index as finished and replays every finished
+// validation that is next in order.
+func (f *orderedFlusher) done(ctx context.Context, index int, buffer *bufferedInformer) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.buffers[index] = buffer
+	for f.next < len(f.buffers) && f.buffers[f.next] != nil {
+		f.buffers[f.next].replay(ctx, f.informer)
+		f.buffers[f.next] = nil
+		f.next++
+	}
+}
@@ -20,6 +20,11 @@ func (e *remediableError) Remediation() string {
 	return e.remediation
 }
 
+// Unwrap returns the error the remediation was added to.
+func (e *remediableError) Unwrap() error {
+	return e.error
+}
+
 // NewRemediableErr returns a new [Remediable] error.
 func NewRemediableErr(err, remediation string) error {
 	return &remediableError{
@@ -75,6 +80,11 @@ func (e *warningError) Remediation() string {
 	return e.remediation
 }
 
+// Unwrap returns the error the warning was made from.
+func (e *warningError) Unwrap() error {
+	return e.error
+}
+
 // NewWarning returns a new Warning error.
 func NewWarning(err, remediation string) error {
 	return &warningError{
@@ -2,6 +2,7 @@ package validation
 
 import (
 	"context"
+	"time"
 
 	"go.uber.org/zap"
 
@@ -14,7 +15,10 @@ type LoggerPrinter struct {
 	logger *zap.Logger
 }
 
-var _ Informer = (*LoggerPrinter)(nil)
+var (
+	_ Informer      = (*LoggerPrinter)(nil)
+	_ eventInformer = (*LoggerPrinter)(nil)
+)
 
 // NewLoggerPrinter creates a new LoggerPrinter that uses the zap logger
 // from the provided context.
@@ -62,6 +66,21 @@ func (p *LoggerPrinter) Done(ctx context.Context, name string, err error) {
 	}
 }
 
+func (p *LoggerPrinter) startingAt(ctx context.Context, name, message string, _ time.Time) {
+	p.Starting(ctx, name, message)
+}
+
+// doneAt logs the output written by the validation, if any, and its result.
+func (p *LoggerPrinter) doneAt(ctx context.Context, name string, err error, _ time.Time, output []string) {
+	if len(output) > 0 {
+		p.logger.Info("Validation output",
+			zap.String("validation", name),
+			zap.Strings("output", output),
+		)
+	}
+	p.Done(ctx, name, err)
+}
+
 // logWarningWithRemediation logs an individual warning and its remediation if available.
 func (p *LoggerPrinter) logWarningWithRemediation(validationName string, err error) {
 	// Prepare log fields
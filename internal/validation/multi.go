@@ -0,0 +1,70 @@
+package validation
+
+import (
+	"context"
+	"time"
+)
+
+// SkipInformer is an Informer that is notified of validations
+// skipped by the Runner.
+type SkipInformer interface {
+	Skipped(name string)
+}
+
+// MultiInformer sends the validation events to several informers.
+type MultiInformer struct {
+	informers []Informer
+}
+
+var (
+	_ Informer      = &MultiInformer{}
+	_ eventInformer = &MultiInformer{}
+	_ SkipInformer  = &MultiInformer{}
+)
+
+// NewMultiInformer returns a new MultiInformer.
+func NewMultiInformer(informers ...Informer) *MultiInformer {
+	return &MultiInformer{informers: informers}
+}
+
+func (m *MultiInformer) Starting(ctx context.Context, name, message string) {
+	m.startingAt(ctx, name, message, time.Now())
+}
+
+func (m *MultiInformer) Done(ctx context.Context, name string, err error) {
+	m.doneAt(ctx, name, err, time.Now(), nil)
+}
+
+func (m *MultiInformer) Skipped(name string) {
+	for _, informer := range m.informers {
+		if skipInformer, ok := informer.(SkipInformer); ok {
+			skipInformer.Skipped(name)
+		}
+	}
+}
+
+func (m *MultiInformer) startingAt(ctx context.Context, name, message string, at time.Time) {
+	for _, informer := range m.informers {
+		if event, ok := informer.(eventInformer); ok {
+			event.startingAt(ctx, name, message, at)
+		} else {
+			informer.Starting(ctx, name, message)
+		}
+	}
+}
+
+func (m *MultiInformer) doneAt(ctx context.Context, name string, err error, at time.Time, output []string) {
+	written := false
+	for _, informer := range m.informers {
+		if event, ok := informer.(eventInformer); ok {
+			event.doneAt(ctx, name, err, at, output)
+			continue
+		}
+		if !written {
+			// Write the output once for all the informers that can't record it.
+			writeOutput(output)
+			written = true
+		}
+		informer.Done(ctx, name, err)
+	}
+}
@@ -0,0 +1,113 @@
+package validation
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+type outputContextKey struct{}
+
+// NewOutputContext returns a new Context, derived from ctx, which carries the
+// writer for the output of the external processes a validation runs.
+func NewOutputContext(ctx context.Context, out io.Writer) context.Context {
+	return context.WithValue(ctx, outputContextKey{}, out)
+}
+
+// OutputFromContext returns the writer for the output of the external processes
+// run by the validation in ctx, like a credential process. The Runner captures it
+// per validation and reports it to its informer with the validation result.
+// If no writer is found, os.Stderr is returned.
+func OutputFromContext(ctx context.Context) io.Writer {
+	out, ok := ctx.Value(outputContextKey{}).(io.Writer)
+	if !ok {
+		return os.Stderr
+	}
+	return out
+}
+
+// outputCapture records the output written by a single validation.
+type outputCapture struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+var _ io.Writer = &outputCapture{}
+
+func (c *outputCapture) Write(p []byte) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.buf.Write(p)
+}
+
+// lines returns the lines written since the last call.
+func (c *outputCapture) lines() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	output := strings.TrimRight(c.buf.String(), "\n")
+	c.buf.Reset()
+	if output == "" {
+		return nil
+	}
+	return strings.Split(output, "\n")
+}
+
+// capturingInformer reports the output captured for a validation
+// to informer with the result of the validation.
+type capturingInformer struct {
+	informer Informer
+	output   *outputCapture
+}
+
+var (
+	_ Informer      = capturingInformer{}
+	_ eventInformer = capturingInformer{}
+)
+
+func (c capturingInformer) Starting(ctx context.Context, name, message string) {
+	c.informer.Starting(ctx, name, message)
+}
+
+func (c capturingInformer) Done(ctx context.Context, name string, err error) {
+	c.doneAt(ctx, name, err, time.Now(), nil)
+}
+
+func (c capturingInformer) startingAt(ctx context.Context, name, message string, at time.Time) {
+	if event, ok := c.informer.(eventInformer); ok {
+		event.startingAt(ctx, name, message, at)
+		return
+	}
+	c.informer.Starting(ctx, name, message)
+}
+
+// doneAt reports the result of a validation with the output captured since the
+// previous result. Informers that can't record the output get it written to
+// os.Stderr, so it isn't lost.
+func (c capturingInformer) doneAt(ctx context.Context, name string, err error, at time.Time, output []string) {
+	output = slices.Concat(output, c.output.lines())
+	if event, ok := c.informer.(eventInformer); ok {
+		event.doneAt(ctx, name, err, at, output)
+		return
+	}
+	writeOutput(output)
+	c.informer.Done(ctx, name, err)
+}
+
+// writeOutput writes the output of a validation to os.Stderr.
+func writeOutput(output []string) {
+	for _, line := range output {
+		_, _ = io.WriteString(os.Stderr, line+"\n")
+	}
+}
+
+// withOutputCapture returns a context and informer for running a single validation
+// that capture its output and report it to informer.
+func withOutputCapture(ctx context.Context, informer Informer) (context.Context, Informer) {
+	output := &outputCapture{}
+	return NewOutputContext(ctx, output), capturingInformer{informer: informer, output: output}
+}
@@ -0,0 +1,233 @@
+package validation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/eks-hybrid/internal/retry"
+)
+
+// DefaultTimeout is a sensible timeout for validations that make network requests.
+// It's long enough for validations with their own retries to finish.
+const DefaultTimeout = 2 * time.Minute
+
+// Policy configures how a validation is run.
+type Policy struct {
+	// Timeout is the maximum time for a single run of the validation.
+	// If the validation doesn't finish in time, it fails with a TimeoutError,
+	// even if it doesn't respect the context cancellation.
+	// If zero, the validation is not timed out.
+	Timeout time.Duration
+	// Retrier retries the validation until it succeeds or the retrier gives up.
+	// Validations that only return warnings are not retried.
+	// If nil, the validation is run once.
+	Retrier *retry.Retrier
+}
+
+// ValidationOpt configures the Policy of a Validation.
+type ValidationOpt func(*Policy)
+
+// WithTimeout configures the timeout for a single run of a validation.
+func WithTimeout(timeout time.Duration) ValidationOpt {
+	return func(p *Policy) {
+		p.Timeout = timeout
+	}
+}
+
+// WithRetrier configures the retries for a validation.
+func WithRetrier(retrier retry.Retrier) ValidationOpt {
+	return func(p *Policy) {
+		p.Retrier = &retrier
+	}
+}
+
+// WithRetries configures a validation to be retried up to retries times
+// with exponential backoff.
+func WithRetries(retries int) ValidationOpt {
+	return WithRetrier(retry.Retrier{
+		Backoff: retry.Backoff{
+			Duration: 1 * time.Second,
+			Factor:   2,
+			Jitter:   0.1,
+			Steps:    retries + 1,
+		},
+	})
+}
+
+// TimeoutError is returned when a validation doesn't finish in time.
+type TimeoutError struct {
+	Validation string
+	Timeout    time.Duration
+}
+
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("validation %s timed out after %s", e.Validation, e.Timeout)
+}
+
+// Remediation returns a possible solution to the timeout.
+func (e *TimeoutError) Remediation() string {
+	return fmt.Sprintf("Ensure the node can reach the endpoints used by the %s validation and that DNS resolution works. "+
+		"On slow networks, increase the timeout for this validation.", e.Validation)
+}
+
+// IsTimeout checks if an error is or wraps a TimeoutError.
+func IsTimeout(err error) bool {
+	var timeoutErr *TimeoutError
+	return errors.As(err, &timeoutErr)
+}
+
+// ParsePolicyOverrides builds the Runner options to override the Policy of validations
+// by name. timeouts are in the format name=duration, like k8s-endpoint-network=30s.
+// retries are in the format name=count, like aws-auth=3. Only the validations in
+// validations can be overridden.
+func ParsePolicyOverrides(timeouts, retries, validations []string) ([]RunnerOpt, error) {
+	var opts []RunnerOpt
+	for _, override := range timeouts {
+		name, value, err := splitOverride(override, validations)
+		if err != nil {
+			return nil, err
+		}
+		timeout, err := time.ParseDuration(value)
+		if err != nil || timeout <= 0 {
+			return nil, fmt.Errorf("invalid timeout %q for validation %s, must be a positive duration like 30s", value, name)
+		}
+		opts = append(opts, WithValidationPolicy(name, WithTimeout(timeout)))
+	}
+
+	for _, override := range retries {
+		name, value, err := splitOverride(override, validations)
+		if err != nil {
+			return nil, err
+		}
+		count, err := strconv.Atoi(value)
+		if err != nil || count < 0 {
+			return nil, fmt.Errorf("invalid retries %q for validation %s, must be a non negative integer", value, name)
+		}
+		opts = append(opts, WithValidationPolicy(name, WithRetries(count)))
+	}
+
+	return opts, nil
+}
+
+func splitOverride(override string, validations []string) (name, value string, err error) {
+	name, value, ok := strings.Cut(override, "=")
+	if !ok || name == "" || value == "" {
+		return "", "", fmt.Errorf("invalid validation override %q, must be in the format name=value", override)
+	}
+	if !slices.Contains(validations, name) {
+		return "", "", fmt.Errorf("invalid validation override %q, unknown validation %s, must be one of: %s", override, name, strings.Join(validations, ", "))
+	}
+	return name, value, nil
+}
+
+// withPolicy wraps validate so it runs with the timeout and retries configured in policy.
+func withPolicy[O Validatable[O]](name string, policy Policy, validate Validate[O]) Validate[O] {
+	if policy.Timeout == 0 && policy.Retrier == nil {
+		return validate
+	}
+
+	if policy.Retrier == nil {
+		return func(ctx context.Context, informer Informer, obj O) error {
+			return runWithTimeout(ctx, name, policy.Timeout, informer, validate, obj)
+		}
+	}
+
+	return func(ctx context.Context, informer Informer, obj O) error {
+		retrier := *policy.Retrier
+		var lastAttempt *bufferedInformer
+		var lastErr error
+		err := retrier.Do(ctx, func(ctx context.Context) (bool, error) {
+			// Only the last attempt is reported, so the informer sees a single result.
+			lastAttempt = &bufferedInformer{}
+			lastErr = runWithTimeout(ctx, name, policy.Timeout, lastAttempt, validate, obj)
+			if lastErr == nil || onlyWarnings(lastErr) {
+				return true, nil
+			}
+			return false, lastErr
+		})
+		if lastAttempt != nil {
+			lastAttempt.replay(ctx, informer)
+		}
+		if lastErr != nil {
+			// Return the validation error instead of the retrier one
+			// so remediations and warnings are preserved.
+			return lastErr
+		}
+		return err
+	}
+}
+
+// cancellationGracePeriod is how long a timed out validation is given to return after
+// its context is cancelled, before it's abandoned.
+const cancellationGracePeriod = time.Second
+
+// runWithTimeout runs validate with a context that is cancelled once timeout expires.
+// It waits up to cancellationGracePeriod for validate to return after cancelling it, so
+// timed out validations don't keep running in the background, unless they ignore the
+// cancellation. Validations report to a buffer so events sent after the timeout are
+// discarded, as is the result of an abandoned validation.
+func runWithTimeout[O Validatable[O]](ctx context.Context, name string, timeout time.Duration, informer Informer, validate Validate[O], obj O) error {
+	if timeout == 0 {
+		return validate(ctx, informer, obj)
+	}
+
+	parent := ctx
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	buffer := &bufferedInformer{}
+	result := make(chan error, 1)
+	go func() {
+		result <- validate(ctx, buffer, obj)
+	}()
+
+	select {
+	case err := <-result:
+		buffer.replay(parent, informer)
+		return err
+	case <-ctx.Done():
+	}
+
+	var err error = &TimeoutError{Validation: name, Timeout: timeout}
+	if parent.Err() != nil {
+		err = parent.Err()
+	}
+
+	// Events sent after the deadline, like the ones sent by validations
+	// reacting to the cancellation, are discarded.
+	cutoff := time.Now()
+	if deadline, _ := ctx.Deadline(); deadline.Before(cutoff) {
+		cutoff = deadline
+	}
+	pending := buffer.close(cutoff)
+	cancel()
+	grace := time.NewTimer(cancellationGracePeriod)
+	select {
+	case <-result:
+	case <-grace.C:
+	}
+	grace.Stop()
+	buffer.replay(parent, informer)
+	if len(pending) == 0 {
+		informer.Starting(parent, name, fmt.Sprintf("Running %s validation", name))
+		pending = []string{name}
+	}
+	for _, started := range pending {
+		informer.Done(parent, started, err)
+	}
+	return err
+}
+
+func onlyWarnings(err error) bool {
+	for _, e := range Unwrap(err) {
+		if !IsWarning(e) {
+			return false
+		}
+	}
+	return true
+}
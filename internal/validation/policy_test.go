@@ -0,0 +1,186 @@
+package validation_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-hybrid/internal/retry"
+	"github.com/aws/eks-hybrid/internal/validation"
+)
+
+func TestRunnerValidationTimeout(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	report := validation.NewReport()
+	r := validation.NewRunner[*nodeConfig](report)
+	returned := false
+	r.Register(
+		validation.New("hung", func(ctx context.Context, informer validation.Informer, _ *nodeConfig) error {
+			informer.Starting(ctx, "hung", "Hung validation")
+			<-ctx.Done()
+			// Events sent after the timeout are discarded.
+			informer.Done(ctx, "hung", nil)
+			returned = true
+			return nil
+		}, validation.WithTimeout(10*time.Millisecond)),
+	)
+
+	err := r.Sequentially(ctx, &nodeConfig{})
+	// The runner waits for the cancelled validation to return.
+	g.Expect(returned).To(BeTrue())
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(validation.IsTimeout(err)).To(BeTrue())
+	errs := validation.Unwrap(err)
+	g.Expect(errs).To(HaveLen(1))
+	g.Expect(validation.IsRemediable(errs[0])).To(BeTrue())
+
+	results := report.Results()
+	g.Expect(results).To(HaveLen(1))
+	g.Expect(results[0].Name).To(Equal("hung"))
+	g.Expect(results[0].Status).To(Equal(validation.StatusFail))
+	g.Expect(results[0].Errors[0].Message).To(Equal("validation hung timed out after 10ms"))
+}
+
+func TestRunnerValidationTimeoutIgnoringContext(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	report := validation.NewReport()
+	r := validation.NewRunner[*nodeConfig](report)
+	blocked := make(chan struct{})
+	defer close(blocked)
+	r.Register(
+		validation.New("blocked", func(ctx context.Context, informer validation.Informer, _ *nodeConfig) error {
+			informer.Starting(ctx, "blocked", "Blocked validation")
+			// Ignores the context cancellation, like a blocked DNS lookup.
+			<-blocked
+			informer.Done(ctx, "blocked", nil)
+			return nil
+		}, validation.WithTimeout(10*time.Millisecond)),
+	)
+
+	start := time.Now()
+	err := r.Sequentially(ctx, &nodeConfig{})
+	// The runner abandons the validation after a grace period.
+	g.Expect(time.Since(start)).To(BeNumerically("<", 5*time.Second))
+	g.Expect(validation.IsTimeout(err)).To(BeTrue())
+
+	results := report.Results()
+	g.Expect(results).To(HaveLen(1))
+	g.Expect(results[0].Name).To(Equal("blocked"))
+	g.Expect(results[0].Status).To(Equal(validation.StatusFail))
+	g.Expect(results[0].Errors[0].Message).To(Equal("validation blocked timed out after 10ms"))
+}
+
+func TestRunnerDefaultTimeoutAndOverride(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	r := validation.NewRunner[*nodeConfig](
+		validation.NoOpInformer{},
+		validation.WithDefaultTimeout(10*time.Millisecond),
+		validation.WithValidationPolicy("slow", validation.WithTimeout(time.Second)),
+	)
+	r.Register(
+		validation.New("slow", func(ctx context.Context, _ validation.Informer, _ *nodeConfig) error {
+			time.Sleep(50 * time.Millisecond)
+			return nil
+		}),
+		validation.New("hung", func(ctx context.Context, _ validation.Informer, _ *nodeConfig) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}),
+	)
+
+	err := r.Sequentially(ctx, &nodeConfig{})
+	g.Expect(err).To(MatchError("validation hung timed out after 10ms"))
+}
+
+func TestRunnerValidationRetries(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	report := validation.NewReport()
+	r := validation.NewRunner[*nodeConfig](report)
+
+	attempts := 0
+	r.Register(
+		validation.New("flaky", func(ctx context.Context, informer validation.Informer, _ *nodeConfig) error {
+			attempts++
+			informer.Starting(ctx, "flaky", "Flaky validation")
+			var err error
+			if attempts < 3 {
+				err = errors.New("not yet")
+			}
+			informer.Done(ctx, "flaky", err)
+			return err
+		}, validation.WithRetrier(retry.Retrier{Backoff: retry.Backoff{Duration: time.Millisecond, Steps: 3}})),
+	)
+
+	g.Expect(r.Sequentially(ctx, &nodeConfig{})).To(Succeed())
+	g.Expect(attempts).To(Equal(3))
+	// Only the last attempt is reported.
+	results := report.Results()
+	g.Expect(results).To(HaveLen(1))
+	g.Expect(results[0].Status).To(Equal(validation.StatusPass))
+}
+
+func TestRunnerValidationRetriesExhausted(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	r := validation.NewRunner[*nodeConfig](validation.NoOpInformer{}, validation.WithValidationPolicy("failing",
+		validation.WithRetrier(retry.Retrier{Backoff: retry.Backoff{Duration: time.Millisecond, Steps: 2}}),
+	))
+
+	attempts := 0
+	e := validation.NewRemediableErr("always fails", "fix it")
+	r.Register(
+		validation.New("failing", func(ctx context.Context, _ validation.Informer, _ *nodeConfig) error {
+			attempts++
+			return e
+		}),
+	)
+
+	err := r.Sequentially(ctx, &nodeConfig{})
+	g.Expect(validation.Unwrap(err)).To(ConsistOf(e))
+	g.Expect(attempts).To(Equal(2))
+}
+
+func TestRunnerValidationWarningsNotRetried(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	r := validation.NewRunner[*nodeConfig](validation.NoOpInformer{})
+
+	attempts := 0
+	r.Register(
+		validation.New("warning", func(ctx context.Context, _ validation.Informer, _ *nodeConfig) error {
+			attempts++
+			return validation.NewWarning("just a warning", "")
+		}, validation.WithRetries(3)),
+	)
+
+	g.Expect(r.Sequentially(ctx, &nodeConfig{})).To(Succeed())
+	g.Expect(attempts).To(Equal(1))
+}
+
+func TestParsePolicyOverrides(t *testing.T) {
+	g := NewWithT(t)
+
+	names := []string{"k8s-endpoint-network", "aws-auth"}
+	opts, err := validation.ParsePolicyOverrides([]string{"k8s-endpoint-network=30s"}, []string{"aws-auth=3"}, names)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(opts).To(HaveLen(2))
+
+	_, err = validation.ParsePolicyOverrides([]string{"k8s-endpoint-network"}, nil, names)
+	g.Expect(err).To(MatchError(ContainSubstring("must be in the format name=value")))
+
+	_, err = validation.ParsePolicyOverrides([]string{"k8s-endpoint-network=fast"}, nil, names)
+	g.Expect(err).To(MatchError(ContainSubstring("must be a positive duration")))
+
+	_, err = validation.ParsePolicyOverrides(nil, []string{"aws-auth=-1"}, names)
+	g.Expect(err).To(MatchError(ContainSubstring("must be a non negative integer")))
+
+	_, err = validation.ParsePolicyOverrides([]string{"k8s-endpoint=30s"}, nil, names)
+	g.Expect(err).To(MatchError(ContainSubstring("unknown validation k8s-endpoint, must be one of: k8s-endpoint-network, aws-auth")))
+}
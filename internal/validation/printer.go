@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"slices"
+	"time"
 )
 
 // Informer is an informer that prints the validation steps to stdout.
@@ -14,7 +16,10 @@ type Printer struct {
 	color        Colorer
 }
 
-var _ Informer = Printer{}
+var (
+	_ Informer      = Printer{}
+	_ eventInformer = Printer{}
+)
 
 // PrinterOpt allows to configure the Printer.
 type PrinterOpt func(*Printer)
@@ -66,6 +71,16 @@ func (p Printer) Starting(ctx context.Context, name, message string) {
 // If a validation fails, it will print the error, external output if any and
 // the error remediation if the error is Remediable.
 func (p Printer) Done(ctx context.Context, name string, err error) {
+	p.doneAt(ctx, name, err, time.Now(), nil)
+}
+
+func (p Printer) startingAt(ctx context.Context, name, message string, _ time.Time) {
+	p.Starting(ctx, name, message)
+}
+
+// doneAt prints the result of a validation. The output written by the
+// validation is printed with the external logs if the validation fails.
+func (p Printer) doneAt(ctx context.Context, name string, err error, _ time.Time, output []string) {
 	if err == nil {
 		p.println("[%s]", p.color.Green("Success"))
 		return
@@ -78,7 +93,7 @@ func (p Printer) Done(ctx context.Context, name string, err error) {
 		p.printError(e)
 	}
 
-	p.printLastError(errs[len(errs)-1])
+	p.printLastError(errs[len(errs)-1], output)
 }
 
 func (p Printer) printError(err error) {
@@ -90,8 +105,8 @@ func (p Printer) printError(err error) {
 	}
 }
 
-func (p Printer) printLastError(err error) {
-	external := p.readExternal()
+func (p Printer) printLastError(err error, output []string) {
+	external := slices.Concat(output, p.readExternal())
 
 	if len(external) == 0 {
 		p.println("  └─ %s", p.color.Red("Error"))
@@ -135,7 +150,7 @@ func (p Printer) readExternal() []string {
 
 func (p Printer) printExternalLogs(prefixHeader, prefixLog string, lines []string) {
 	if len(lines) > 0 {
-		p.println("%s %s", prefixHeader, p.color.Yellow(p.externalLogs.Name()))
+		p.println("%s %s", prefixHeader, p.color.Yellow(p.externalLogsName()))
 		for _, line := range lines[:len(lines)-1] {
 			p.println("%s  ├─ %s", prefixLog, line)
 		}
@@ -144,6 +159,14 @@ func (p Printer) printExternalLogs(prefixHeader, prefixLog string, lines []strin
 	}
 }
 
+// externalLogsName returns the name the external logs are printed with.
+func (p Printer) externalLogsName() string {
+	if p.externalLogs == nil {
+		return "output"
+	}
+	return p.externalLogs.Name()
+}
+
 type NoOpInformer struct{}
 
 var _ Informer = NoOpInformer{}
@@ -0,0 +1,364 @@
+package validation
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Status is the outcome of a validation.
+type Status string
+
+const (
+	StatusPass Status = "pass"
+	StatusWarn Status = "warn"
+	StatusFail Status = "fail"
+	StatusSkip Status = "skip"
+)
+
+// Result is the outcome of a single validation recorded by a Report.
+type Result struct {
+	Name      string    `json:"name"`
+	Message   string    `json:"message,omitempty"`
+	StartTime time.Time `json:"startTime"`
+	EndTime   time.Time `json:"endTime"`
+	Status    Status    `json:"status"`
+	// Errors are the errors and warnings returned by the validation.
+	Errors []ResultError `json:"errors,omitempty"`
+	// Output are the lines written by the validation to its output and the lines
+	// read from the external logs while it ran.
+	Output []string `json:"output,omitempty"`
+}
+
+// ResultError is an error or warning returned by a validation.
+type ResultError struct {
+	Message string `json:"message"`
+	Warning bool   `json:"warning,omitempty"`
+	// Chain are the messages of the wrapped errors, starting with the outermost one.
+	Chain       []string `json:"chain,omitempty"`
+	Remediation string   `json:"remediation,omitempty"`
+}
+
+// Report is an informer that records the result of each validation so it can be
+// written in a structured format once all validations have finished.
+type Report struct {
+	externalLogs LineReader
+	now          func() time.Time
+
+	mu      sync.Mutex
+	results []*Result
+	running map[string]*Result
+}
+
+var (
+	_ Informer      = (*Report)(nil)
+	_ eventInformer = (*Report)(nil)
+	_ SkipInformer  = (*Report)(nil)
+)
+
+// ReportOpt allows to configure the Report.
+type ReportOpt func(*Report)
+
+// WithReportExternalLogs allows to configure an external source of logs that
+// will get included in the result of the validation running when they were read.
+func WithReportExternalLogs(in LineReader) ReportOpt {
+	return func(r *Report) {
+		r.externalLogs = in
+	}
+}
+
+// NewReport returns a new Report.
+func NewReport(opts ...ReportOpt) *Report {
+	r := &Report{
+		now:     time.Now,
+		running: map[string]*Result{},
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+// Starting records the start of a validation.
+func (r *Report) Starting(ctx context.Context, name, message string) {
+	r.startingAt(ctx, name, message, r.now())
+}
+
+func (r *Report) startingAt(ctx context.Context, name, message string, at time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	result := &Result{
+		Name:      name,
+		Message:   message,
+		StartTime: at,
+	}
+	r.results = append(r.results, result)
+	r.running[name] = result
+}
+
+// Done records the result of a validation.
+// Validations that only return warnings are recorded as StatusWarn.
+func (r *Report) Done(ctx context.Context, name string, err error) {
+	r.doneAt(ctx, name, err, r.now(), nil)
+}
+
+func (r *Report) doneAt(ctx context.Context, name string, err error, at time.Time, output []string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	result, ok := r.running[name]
+	if !ok {
+		// Done without Starting, record it as an instant validation.
+		result = &Result{Name: name, StartTime: at}
+		r.results = append(r.results, result)
+	}
+	delete(r.running, name)
+
+	result.EndTime = at
+	result.Status = StatusPass
+	result.Output = slices.Concat(output, r.readExternal())
+	if err == nil {
+		return
+	}
+
+	result.Status = StatusWarn
+	for _, e := range Unwrap(err) {
+		if !IsWarning(e) {
+			result.Status = StatusFail
+		}
+		result.Errors = append(result.Errors, ResultError{
+			Message:     e.Error(),
+			Warning:     IsWarning(e),
+			Chain:       errorChain(e),
+			Remediation: Remediation(e),
+		})
+	}
+}
+
+// Skipped records a validation skipped by the Runner.
+func (r *Report) Skipped(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.results = append(r.results, &Result{Name: name, Status: StatusSkip})
+}
+
+// Results returns the recorded results in the order the validations started.
+func (r *Report) Results() []Result {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	results := make([]Result, 0, len(r.results))
+	for _, result := range r.results {
+		results = append(results, *result)
+	}
+	return results
+}
+
+// Status returns StatusFail if any validation failed, StatusWarn if any validation
+// returned warnings and StatusPass otherwise.
+func (r *Report) Status() Status {
+	return overallStatus(r.Results())
+}
+
+func overallStatus(results []Result) Status {
+	status := StatusPass
+	for _, result := range results {
+		switch result.Status {
+		case StatusFail:
+			return StatusFail
+		case StatusWarn:
+			status = StatusWarn
+		}
+	}
+	return status
+}
+
+type jsonReport struct {
+	Status      Status   `json:"status"`
+	Validations []Result `json:"validations"`
+}
+
+// WriteJSON writes the report as a JSON document.
+func (r *Report) WriteJSON(w io.Writer) error {
+	results := r.Results()
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(jsonReport{
+		Status:      overallStatus(results),
+		Validations: results,
+	})
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	Time      string          `xml:"time,attr"`
+	Timestamp string          `xml:"timestamp,attr,omitempty"`
+	Cases     []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Skipped   *junitSkipped `xml:"skipped,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitSkipped struct {
+	Message string `xml:"message,attr,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// WriteJUnit writes the report as a JUnit XML document with one test suite named
+// suiteName. Failed validations are reported as test failures. Warnings don't fail
+// the test case and are included in its system-out. Skipped validations are
+// reported as skipped test cases.
+func (r *Report) WriteJUnit(w io.Writer, suiteName string) error {
+	results := r.Results()
+	suite := junitTestSuite{
+		Name:  suiteName,
+		Tests: len(results),
+	}
+
+	var start, end time.Time
+	for _, result := range results {
+		if result.Status == StatusSkip {
+			suite.Skipped++
+			suite.Cases = append(suite.Cases, junitTestCase{
+				Name:      result.Name,
+				ClassName: suiteName,
+				Time:      junitDuration(0),
+				Skipped:   &junitSkipped{Message: "skipped by user"},
+			})
+			continue
+		}
+		if start.IsZero() || result.StartTime.Before(start) {
+			start = result.StartTime
+		}
+		if result.EndTime.After(end) {
+			end = result.EndTime
+		}
+
+		testCase := junitTestCase{
+			Name:      result.Name,
+			ClassName: suiteName,
+			Time:      junitDuration(result.EndTime.Sub(result.StartTime)),
+		}
+		var failureMessage, failures, warnings string
+		for _, e := range result.Errors {
+			if e.Warning {
+				warnings += "warning: " + formatResultError(e)
+				continue
+			}
+			if failureMessage == "" {
+				failureMessage = e.Message
+			}
+			failures += formatResultError(e)
+		}
+		if result.Status == StatusFail {
+			suite.Failures++
+			testCase.Failure = &junitFailure{
+				Message: failureMessage,
+				Type:    string(StatusFail),
+				Text:    failures,
+			}
+		}
+		testCase.SystemOut = warnings
+		for _, line := range result.Output {
+			testCase.SystemOut += line + "\n"
+		}
+		suite.Cases = append(suite.Cases, testCase)
+	}
+
+	suite.Time = junitDuration(end.Sub(start))
+	if !start.IsZero() {
+		suite.Timestamp = start.UTC().Format(time.RFC3339)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(junitTestSuites{
+		Name:     suiteName,
+		Tests:    suite.Tests,
+		Failures: suite.Failures,
+		Time:     suite.Time,
+		Suites:   []junitTestSuite{suite},
+	}); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func (r *Report) readExternal() []string {
+	if r.externalLogs == nil {
+		return nil
+	}
+
+	var lines []string
+	for line, ok := r.externalLogs.Line(); ok; line, ok = r.externalLogs.Line() {
+		lines = append(lines, line)
+	}
+
+	return lines
+}
+
+// errorChain returns the messages of err and every error it wraps.
+// Wrappers that don't add to the message of the error they wrap are omitted.
+func errorChain(err error) []string {
+	var chain []string
+	for ; err != nil; err = errors.Unwrap(err) {
+		message := err.Error()
+		if len(chain) > 0 && chain[len(chain)-1] == message {
+			continue
+		}
+		chain = append(chain, message)
+	}
+	return chain
+}
+
+func formatResultError(e ResultError) string {
+	text := e.Message + "\n"
+	for _, cause := range e.Chain[1:] {
+		text += "  caused by: " + cause + "\n"
+	}
+	if e.Remediation != "" {
+		text += "  remediation: " + e.Remediation + "\n"
+	}
+	return text
+}
+
+func junitDuration(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
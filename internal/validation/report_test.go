@@ -0,0 +1,164 @@
+package validation_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-hybrid/internal/validation"
+)
+
+func TestReportResults(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	report := validation.NewReport()
+
+	report.Starting(ctx, "pass", "Passing validation")
+	report.Done(ctx, "pass", nil)
+	report.Starting(ctx, "warn", "Warning validation")
+	report.Done(ctx, "warn", validation.NewWarning("clock drift", "sync the clock"))
+	g.Expect(report.Status()).To(Equal(validation.StatusWarn))
+
+	report.Starting(ctx, "fail", "Failing validation")
+	report.Done(ctx, "fail", errors.Join(
+		validation.WithRemediation(fmt.Errorf("calling api: %w", errors.New("connection refused")), "open the firewall"),
+		validation.NewWarning("slow response", ""),
+	))
+	g.Expect(report.Status()).To(Equal(validation.StatusFail))
+
+	results := report.Results()
+	g.Expect(results).To(HaveLen(3))
+
+	g.Expect(results[0].Name).To(Equal("pass"))
+	g.Expect(results[0].Message).To(Equal("Passing validation"))
+	g.Expect(results[0].Status).To(Equal(validation.StatusPass))
+	g.Expect(results[0].Errors).To(BeEmpty())
+	g.Expect(results[0].EndTime).NotTo(BeTemporally("<", results[0].StartTime))
+
+	g.Expect(results[1].Status).To(Equal(validation.StatusWarn))
+	g.Expect(results[1].Errors).To(ConsistOf(validation.ResultError{
+		Message:     "clock drift",
+		Warning:     true,
+		Chain:       []string{"clock drift"},
+		Remediation: "sync the clock",
+	}))
+
+	g.Expect(results[2].Status).To(Equal(validation.StatusFail))
+	g.Expect(results[2].Errors).To(ConsistOf(
+		validation.ResultError{
+			Message:     "calling api: connection refused",
+			Chain:       []string{"calling api: connection refused", "connection refused"},
+			Remediation: "open the firewall",
+		},
+		validation.ResultError{
+			Message: "slow response",
+			Warning: true,
+			Chain:   []string{"slow response"},
+		},
+	))
+}
+
+func TestReportDoneWithoutStarting(t *testing.T) {
+	g := NewWithT(t)
+	report := validation.NewReport()
+
+	report.Done(context.Background(), "instant", errors.New("failed"))
+
+	results := report.Results()
+	g.Expect(results).To(HaveLen(1))
+	g.Expect(results[0].Name).To(Equal("instant"))
+	g.Expect(results[0].Status).To(Equal(validation.StatusFail))
+}
+
+func TestReportExternalLogs(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	logs := make(chan string, 10)
+	report := validation.NewReport(validation.WithReportExternalLogs(validation.NewChannelReader(logs, "stderr")))
+
+	report.Starting(ctx, "first", "First")
+	logs <- "line 1"
+	logs <- "line 2"
+	report.Done(ctx, "first", errors.New("failed"))
+	report.Starting(ctx, "second", "Second")
+	report.Done(ctx, "second", nil)
+
+	results := report.Results()
+	g.Expect(results[0].Output).To(Equal([]string{"line 1", "line 2"}))
+	g.Expect(results[1].Output).To(BeEmpty())
+}
+
+func TestReportWriteJSON(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	report := validation.NewReport()
+	report.Starting(ctx, "swap", "Validating swap")
+	report.Done(ctx, "swap", validation.NewRemediableErr("swap is enabled", "disable swap"))
+
+	var buf bytes.Buffer
+	g.Expect(report.WriteJSON(&buf)).To(Succeed())
+
+	var got struct {
+		Status      validation.Status   `json:"status"`
+		Validations []validation.Result `json:"validations"`
+	}
+	g.Expect(json.Unmarshal(buf.Bytes(), &got)).To(Succeed())
+	g.Expect(got.Status).To(Equal(validation.StatusFail))
+	g.Expect(got.Validations).To(HaveLen(1))
+	g.Expect(got.Validations[0].Name).To(Equal("swap"))
+	g.Expect(got.Validations[0].Errors[0].Remediation).To(Equal("disable swap"))
+}
+
+func TestReportWriteJUnit(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	report := validation.NewReport()
+	report.Starting(ctx, "ntp-sync", "Validating NTP")
+	report.Done(ctx, "ntp-sync", validation.NewWarning("clock drift", "sync the clock"))
+	report.Starting(ctx, "swap", "Validating swap")
+	report.Done(ctx, "swap", errors.Join(
+		validation.NewWarning("swap is large", ""),
+		validation.NewRemediableErr("swap is enabled", "disable swap"),
+	))
+
+	var buf bytes.Buffer
+	g.Expect(report.WriteJUnit(&buf, "nodeadm-debug")).To(Succeed())
+	g.Expect(buf.String()).To(HavePrefix(xml.Header))
+
+	var got struct {
+		Tests    int `xml:"tests,attr"`
+		Failures int `xml:"failures,attr"`
+		Suites   []struct {
+			Name  string `xml:"name,attr"`
+			Cases []struct {
+				Name    string `xml:"name,attr"`
+				Failure *struct {
+					Message string `xml:"message,attr"`
+					Text    string `xml:",chardata"`
+				} `xml:"failure"`
+				SystemOut string `xml:"system-out"`
+			} `xml:"testcase"`
+		} `xml:"testsuite"`
+	}
+	g.Expect(xml.Unmarshal(buf.Bytes(), &got)).To(Succeed())
+	g.Expect(got.Tests).To(Equal(2))
+	g.Expect(got.Failures).To(Equal(1))
+	g.Expect(got.Suites).To(HaveLen(1))
+	g.Expect(got.Suites[0].Name).To(Equal("nodeadm-debug"))
+
+	cases := got.Suites[0].Cases
+	g.Expect(cases).To(HaveLen(2))
+	g.Expect(cases[0].Name).To(Equal("ntp-sync"))
+	g.Expect(cases[0].Failure).To(BeNil())
+	g.Expect(cases[0].SystemOut).To(ContainSubstring("warning: clock drift"))
+	g.Expect(cases[1].Name).To(Equal("swap"))
+	g.Expect(cases[1].Failure).NotTo(BeNil())
+	g.Expect(cases[1].Failure.Message).To(Equal("swap is enabled"))
+	g.Expect(cases[1].Failure.Text).To(ContainSubstring("remediation: disable swap"))
+}
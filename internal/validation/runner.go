@@ -5,8 +5,14 @@ import (
 	"errors"
 	"reflect"
 	"strings"
+	"sync"
+	"time"
 )
 
+// DefaultWorkers is the maximum number of validations Concurrently
+// runs at the same time when the Runner is not configured with WithWorkers.
+const DefaultWorkers = 4
+
 // Validatable is anything that can be validated.
 type Validatable[O any] interface {
 	DeepCopy() O
@@ -16,10 +22,19 @@ type Validatable[O any] interface {
 type Validation[O Validatable[O]] struct {
 	Name     string
 	Validate Validate[O]
+	// Policy configures the timeout and retries for the validation.
+	Policy Policy
+	// composed is true for validations built from other validations,
+	// which get their policies applied individually.
+	composed bool
 }
 
-func New[O Validatable[O]](name string, validate Validate[O]) Validation[O] {
-	return Validation[O]{Name: name, Validate: validate}
+func New[O Validatable[O]](name string, validate Validate[O], opts ...ValidationOpt) Validation[O] {
+	v := Validation[O]{Name: name, Validate: validate}
+	for _, opt := range opts {
+		opt(&v.Policy)
+	}
+	return v
 }
 
 // Validate is the logic for a validation of a type O.
@@ -40,6 +55,10 @@ type Informer interface {
 // RunnerConfig holds the configuration for the Runner.
 type RunnerConfig struct {
 	skipValidations []string
+	workers         int
+	defaultTimeout  time.Duration
+	policies        map[string][]ValidationOpt
+	informers       []Informer
 }
 
 // RunnerOpt allows to configure the Runner.
@@ -53,16 +72,56 @@ func WithSkipValidations(namesToSkip ...string) RunnerOpt {
 	}
 }
 
+// WithWorkers configures the maximum number of validations
+// Concurrently runs at the same time.
+func WithWorkers(workers int) RunnerOpt {
+	return func(c *RunnerConfig) {
+		c.workers = workers
+	}
+}
+
+// WithDefaultTimeout configures the timeout for validations that don't set one.
+func WithDefaultTimeout(timeout time.Duration) RunnerOpt {
+	return func(c *RunnerConfig) {
+		c.defaultTimeout = timeout
+	}
+}
+
+// WithValidationPolicy overrides the Policy of the validation with the given name.
+func WithValidationPolicy(name string, opts ...ValidationOpt) RunnerOpt {
+	return func(c *RunnerConfig) {
+		if c.policies == nil {
+			c.policies = map[string][]ValidationOpt{}
+		}
+		c.policies[name] = append(c.policies[name], opts...)
+	}
+}
+
+// WithInformers configures informers that receive the validation
+// events in addition to the Runner informer.
+func WithInformers(informers ...Informer) RunnerOpt {
+	return func(c *RunnerConfig) {
+		c.informers = append(c.informers, informers...)
+	}
+}
+
 // NewRunner constructs a new Runner.
 func NewRunner[O Validatable[O]](informer Informer, opts ...RunnerOpt) *Runner[O] {
 	r := &Runner[O]{
 		informer: informer,
+		config: RunnerConfig{
+			workers: DefaultWorkers,
+		},
 	}
 
 	for _, opt := range opts {
 		opt(&r.config)
 	}
 
+	if len(r.config.informers) > 0 {
+		r.informer = NewMultiInformer(append([]Informer{informer}, r.config.informers...)...)
+	}
+
 	return r
 }
 
@@ -77,13 +136,15 @@ func (r *Runner[O]) Register(validations ...Validation[O]) {
 
 // Sequentially runs all validations one after the other and waits until they all finish,
 // aggregating the errors if present. Warnings are logged but don't cause failure.
+// The output each validation writes to OutputFromContext is reported with its result.
 // obj must not be modified. If it is, this indicates a programming error and the method will panic.
 func (r *Runner[O]) Sequentially(ctx context.Context, obj O) error {
 	copyObj := obj.DeepCopy()
 	var errs []error
 
 	for _, validation := range r.validations {
-		err := validation.Validate(ctx, r.informer, copyObj)
+		validationCtx, informer := withOutputCapture(ctx, r.informer)
+		err := r.validate(validation)(validationCtx, informer, copyObj)
 		if err != nil {
 			unwrappedErrs := Unwrap(err)
 			for _, e := range unwrappedErrs {
@@ -102,22 +163,93 @@ func (r *Runner[O]) Sequentially(ctx context.Context, obj O) error {
 	return errors.Join(errs...)
 }
 
+// Concurrently runs the validations in parallel, with at most the configured number
+// of workers, and waits until they all finish, aggregating the errors if present.
+// Validations composed with UntilError run as a single unit, in order.
+// Each validation reports to a buffer that is replayed to the Runner informer once the
+// validation finishes, in registration order, so the output of different validations
+// is never interleaved. The output each validation writes to OutputFromContext is
+// reported with its result. Errors are aggregated in registration order as well.
+// obj must not be modified. If it is, this indicates a programming error and the method will panic.
+func (r *Runner[O]) Concurrently(ctx context.Context, obj O) error {
+	copyObj := obj.DeepCopy()
+	workers := r.config.workers
+	if workers < 1 {
+		workers = 1
+	}
+
+	results := make([]error, len(r.validations))
+	flusher := newOrderedFlusher(r.informer, len(r.validations))
+	sem := make(chan struct{}, workers)
+	var wg sync.WaitGroup
+	for i, validation := range r.validations {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			buffer := &bufferedInformer{}
+			validationCtx, informer := withOutputCapture(ctx, buffer)
+			results[i] = r.validate(validation)(validationCtx, informer, copyObj)
+			flusher.done(ctx, i, buffer)
+		}()
+	}
+	wg.Wait()
+
+	if !reflect.DeepEqual(obj, copyObj) {
+		panic("validations must not modify the object under validation")
+	}
+
+	var errs []error
+	for _, err := range results {
+		if err == nil {
+			continue
+		}
+		for _, e := range Unwrap(err) {
+			if !IsWarning(e) {
+				errs = append(errs, e)
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
 func (r *Runner[O]) UntilError(validations ...Validation[O]) Validation[O] {
 	var accepted []Validate[O]
 	var names []string
 	for _, v := range validations {
 		if r.shouldRegister(v.Name) {
-			accepted = append(accepted, v.Validate)
+			accepted = append(accepted, r.validate(v))
 			names = append(names, v.Name)
 		}
 	}
 
-	return New("until-error-"+strings.Join(names, "/"), UntilError(accepted...))
+	v := New("until-error-"+strings.Join(names, "/"), UntilError(accepted...))
+	v.composed = true
+	return v
+}
+
+// validate returns the Validate of v with its Policy applied. Policies configured
+// in the Runner for the validation name override the ones in v. Composed validations
+// don't get the default timeout, since their validations already have it applied.
+func (r *Runner[O]) validate(v Validation[O]) Validate[O] {
+	policy := v.Policy
+	if policy.Timeout == 0 && !v.composed {
+		policy.Timeout = r.config.defaultTimeout
+	}
+	for _, opt := range r.config.policies[v.Name] {
+		opt(&policy)
+	}
+	return withPolicy(v.Name, policy, v.Validate)
 }
 
 func (r *Runner[O]) shouldRegister(name string) bool {
 	for _, skip := range r.config.skipValidations {
 		if skip == name {
+			if skipInformer, ok := r.informer.(SkipInformer); ok {
+				skipInformer.Skipped(name)
+			}
 			return false
 		}
 	}
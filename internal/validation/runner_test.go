@@ -1,9 +1,13 @@
 package validation_test
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"fmt"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
 
@@ -165,6 +169,187 @@ func TestRunnerWithSkipValidations(t *testing.T) {
 	g.Expect(r.Sequentially(ctx, config)).To(Succeed())
 }
 
+func TestRunnerConcurrentlyError(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	r := validation.NewRunner[*nodeConfig](validation.NewPrinter(), validation.WithWorkers(3))
+
+	e1 := errors.New("slow error")
+	e2 := errors.New("fast error")
+	e3 := errors.New("until error")
+	r.Register(
+		newValidation(func(ctx context.Context, _ validation.Informer, _ *nodeConfig) error {
+			time.Sleep(50 * time.Millisecond)
+			return e1
+		}),
+		newValidation(func(ctx context.Context, _ validation.Informer, _ *nodeConfig) error {
+			return e2
+		}),
+		r.UntilError(
+			newValidation(func(ctx context.Context, _ validation.Informer, _ *nodeConfig) error {
+				return e3
+			}),
+			newValidation(func(ctx context.Context, _ validation.Informer, _ *nodeConfig) error {
+				return errors.New("should not run")
+			}),
+		),
+		newValidation(func(ctx context.Context, _ validation.Informer, _ *nodeConfig) error {
+			return validation.NewWarning("just a warning", "")
+		}),
+	)
+
+	err := r.Concurrently(ctx, &nodeConfig{})
+	g.Expect(validation.Unwrap(err)).To(Equal([]error{e1, e2, e3}))
+}
+
+func TestRunnerConcurrentlyWorkerLimit(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	r := validation.NewRunner[*nodeConfig](validation.NoOpInformer{}, validation.WithWorkers(2))
+
+	var running, maxRunning atomic.Int32
+	for range 6 {
+		r.Register(newValidation(func(ctx context.Context, _ validation.Informer, _ *nodeConfig) error {
+			current := running.Add(1)
+			for {
+				observed := maxRunning.Load()
+				if current <= observed || maxRunning.CompareAndSwap(observed, current) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			running.Add(-1)
+			return nil
+		}))
+	}
+
+	g.Expect(r.Concurrently(ctx, &nodeConfig{})).To(Succeed())
+	g.Expect(maxRunning.Load()).To(BeNumerically("<=", 2))
+}
+
+func TestRunnerConcurrentlyGroupedOutput(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	var buf bytes.Buffer
+	r := validation.NewRunner[*nodeConfig](
+		validation.NewPrinter(validation.WithNoColor(), validation.WithOutWriter(&buf)),
+		validation.WithWorkers(2),
+	)
+
+	r.Register(
+		newValidation(func(ctx context.Context, informer validation.Informer, _ *nodeConfig) error {
+			informer.Starting(ctx, "slow", "Slow validation")
+			time.Sleep(50 * time.Millisecond)
+			informer.Done(ctx, "slow", nil)
+			return nil
+		}),
+		newValidation(func(ctx context.Context, informer validation.Informer, _ *nodeConfig) error {
+			informer.Starting(ctx, "fast", "Fast validation")
+			informer.Done(ctx, "fast", errors.New("failed"))
+			return nil
+		}),
+	)
+
+	g.Expect(r.Concurrently(ctx, &nodeConfig{})).To(Succeed())
+	g.Expect(buf.String()).To(Equal(`* Slow validation [Success]
+* Fast validation [Failed]
+  └─ Error
+     └─ failed
+`))
+}
+
+func TestRunnerConcurrentlyOutput(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	var buf bytes.Buffer
+	report := validation.NewReport()
+	r := validation.NewRunner[*nodeConfig](
+		validation.NewPrinter(validation.WithNoColor(), validation.WithOutWriter(&buf)),
+		validation.WithWorkers(2),
+		validation.WithInformers(report),
+	)
+
+	started := make(chan struct{})
+	r.Register(
+		newValidation(func(ctx context.Context, informer validation.Informer, _ *nodeConfig) error {
+			informer.Starting(ctx, "slow", "Slow validation")
+			<-started
+			fmt.Fprintln(validation.OutputFromContext(ctx), "slow output")
+			informer.Done(ctx, "slow", errors.New("slow failed"))
+			return nil
+		}),
+		newValidation(func(ctx context.Context, informer validation.Informer, _ *nodeConfig) error {
+			informer.Starting(ctx, "fast", "Fast validation")
+			fmt.Fprintln(validation.OutputFromContext(ctx), "fast output")
+			close(started)
+			informer.Done(ctx, "fast", errors.New("fast failed"))
+			return nil
+		}),
+	)
+
+	g.Expect(r.Concurrently(ctx, &nodeConfig{})).To(Succeed())
+	g.Expect(buf.String()).To(Equal(`* Slow validation [Failed]
+  └─ Error
+     ├─ slow failed
+     └─ output
+        └─ slow output
+* Fast validation [Failed]
+  └─ Error
+     ├─ fast failed
+     └─ output
+        └─ fast output
+`))
+	results := report.Results()
+	g.Expect(results).To(HaveLen(2))
+	g.Expect(results[0].Output).To(Equal([]string{"slow output"}))
+	g.Expect(results[1].Output).To(Equal([]string{"fast output"}))
+}
+
+func TestRunnerConcurrentlyReportTimes(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	report := validation.NewReport()
+	r := validation.NewRunner[*nodeConfig](report, validation.WithWorkers(2))
+
+	r.Register(
+		newValidation(func(ctx context.Context, informer validation.Informer, _ *nodeConfig) error {
+			informer.Starting(ctx, "slow", "Slow validation")
+			time.Sleep(50 * time.Millisecond)
+			informer.Done(ctx, "slow", nil)
+			return nil
+		}),
+		newValidation(func(ctx context.Context, informer validation.Informer, _ *nodeConfig) error {
+			informer.Starting(ctx, "fast", "Fast validation")
+			informer.Done(ctx, "fast", nil)
+			return nil
+		}),
+	)
+
+	g.Expect(r.Concurrently(ctx, &nodeConfig{})).To(Succeed())
+	results := report.Results()
+	g.Expect(results).To(HaveLen(2))
+	// The fast validation is replayed after the slow one but keeps the time it ran.
+	g.Expect(results[1].Name).To(Equal("fast"))
+	g.Expect(results[1].EndTime).To(BeTemporally("<", results[0].EndTime))
+}
+
+func TestRunnerConcurrentlyPanicAfterModifyingObject(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	r := validation.NewRunner[*nodeConfig](validation.NoOpInformer{})
+	r.Register(
+		newValidation(func(ctx context.Context, _ validation.Informer, config *nodeConfig) error {
+			config.maxPods = 5
+			return nil
+		}),
+	)
+
+	run := func() {
+		_ = r.Concurrently(ctx, &nodeConfig{})
+	}
+	g.Expect(run).To(PanicWith("validations must not modify the object under validation"))
+}
+
 type nodeConfig struct {
 	maxPods int
 	name    string